@@ -15,68 +15,21 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/cats": {
+        "/admin/country-risk-levels": {
             "get": {
-                "description": "ability to view the list of cats",
+                "description": "Countries without a level earn no country_risk bonuses.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cats"
-                ],
-                "summary": "List spy cats",
-                "parameters": [
-                    {
-                        "maximum": 200,
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 0,
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by breed",
-                        "name": "breed",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Min experience",
-                        "name": "min_years",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Max experience",
-                        "name": "max_years",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "List country risk levels",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetCatsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.RiskLevelsResponse"
                         }
                     },
                     "500": {
@@ -88,9 +41,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/cats/create": {
-            "post": {
-                "description": "Used to create a new spy cat",
+        "/admin/country-risk-levels/{country}": {
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -98,25 +50,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cats"
+                    "admin"
                 ],
-                "summary": "Create a new spy cat",
+                "summary": "Set country risk level",
                 "parameters": [
                     {
-                        "description": "Request to create a cat",
-                        "name": "CreateCatRequest",
+                        "type": "string",
+                        "description": "ISO 3166-1 alpha-2, alpha-3 code or English name",
+                        "name": "country",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Risk level",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateCatRequest"
+                            "$ref": "#/definitions/dto.SetRiskLevelRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateCatResponse"
+                            "$ref": "#/definitions/dto.RiskLevelResponse"
                         }
                     },
                     "400": {
@@ -132,33 +91,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/cats/{id}": {
-            "get": {
-                "description": "The ability to receive information about a single cat",
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
                 "tags": [
-                    "cats"
+                    "admin"
                 ],
-                "summary": "Get a single spy cat",
+                "summary": "Delete country risk level",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID Cat",
-                        "name": "id",
+                        "type": "string",
+                        "description": "ISO 3166-1 alpha-2, alpha-3 code or English name",
+                        "name": "country",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.CatResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -179,42 +129,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a cat by id",
+            }
+        },
+        "/admin/exchange-rates": {
+            "get": {
+                "description": "Every stored rate. A pair without a rate is converted with the\ninverse rate or through USD when possible.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cats"
-                ],
-                "summary": "Delete cat",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Cat ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "List exchange rates",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.DeleteCatResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.ExchangeRatesResponse"
                         }
                     },
                     "500": {
@@ -226,9 +157,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/cats/{id}/salary": {
-            "patch": {
-                "description": "Updates salary for a specific cat",
+        "/admin/exchange-rates/{from}/{to}": {
+            "put": {
+                "description": "Creates or replaces the rate of a currency pair: 1 {from} = rate {to}.",
                 "consumes": [
                     "application/json"
                 ],
@@ -236,24 +167,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cats"
+                    "admin"
                 ],
-                "summary": "Update cat salary",
+                "summary": "Set exchange rate",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Cat ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Source ISO 4217 currency",
+                        "name": "from",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "New salary",
+                        "type": "string",
+                        "description": "Target ISO 4217 currency",
+                        "name": "to",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rate",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateSalaryRequest"
+                            "$ref": "#/definitions/dto.SetRateRequest"
                         }
                     }
                 ],
@@ -261,7 +199,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CatResponse"
+                            "$ref": "#/definitions/dto.ExchangeRateResponse"
                         }
                     },
                     "400": {
@@ -270,12 +208,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -283,33 +215,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/mission/{id}": {
-            "get": {
-                "description": "The ability to receive information about a single mission",
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
                 "tags": [
-                    "missions"
+                    "admin"
                 ],
-                "summary": "Get a single mission",
+                "summary": "Delete exchange rate",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID Mission",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Source ISO 4217 currency",
+                        "name": "from",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target ISO 4217 currency",
+                        "name": "to",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.MissionResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -332,58 +262,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/missions": {
+        "/bonus-rules": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "missions"
-                ],
-                "summary": "List missions",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "planned|active|completed",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Cat ID",
-                        "name": "catId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "search by title",
-                        "name": "q",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "limit (1..200)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query"
-                    }
+                    "bonus-rules"
                 ],
+                "summary": "List bonus rules",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetMissionsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.RulesResponse"
                         }
                     },
                     "500": {
@@ -395,7 +287,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Used to create a new mission",
+                "description": "When a mission completes, every active rule awards a bonus to the assigned cat (the lead;\nother team members earn no bonuses):\nflat pays amount once, per_goal pays amount per done goal and country_risk pays\namount per done goal in a country of risk_level.",
                 "consumes": [
                     "application/json"
                 ],
@@ -403,17 +295,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "missions"
+                    "bonus-rules"
                 ],
-                "summary": "Create a new mission",
+                "summary": "Create a bonus rule",
                 "parameters": [
                     {
-                        "description": "Request to create a mission",
-                        "name": "CreateMissionRequest",
+                        "description": "Rule",
+                        "name": "SaveRuleRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateMissionRequest"
+                            "$ref": "#/definitions/dto.SaveRuleRequest"
                         }
                     }
                 ],
@@ -421,7 +313,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateMissionResponse"
+                            "$ref": "#/definitions/dto.RuleResponse"
                         }
                     },
                     "400": {
@@ -430,61 +322,39 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    },
-                    "502": {
-                        "description": "Bad Gateway",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/missions/{id}/assign": {
-            "patch": {
-                "description": "Used to link or unlink a mission with a cat",
-                "consumes": [
-                    "application/json"
-                ],
+        "/bonus-rules/{id}": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "missions"
+                    "bonus-rules"
                 ],
-                "summary": "Assign mission to a cat (or unassign with null)",
+                "summary": "Get a bonus rule",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Mission ID",
+                        "description": "Rule ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Assignment payload",
-                        "name": "AssignMissionRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.AssignMissionRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RuleResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -505,10 +375,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/missions/{id}/goals": {
-            "post": {
+            },
+            "put": {
+                "description": "Bonuses already awarded keep their amounts.",
                 "consumes": [
                     "application/json"
                 ],
@@ -516,33 +385,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "missions"
+                    "bonus-rules"
                 ],
-                "summary": "Add goal to mission",
+                "summary": "Replace a bonus rule",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Mission ID",
+                        "description": "Rule ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Goal payload",
-                        "name": "body",
+                        "description": "Rule",
+                        "name": "SaveRuleRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.AddGoalRequest"
+                            "$ref": "#/definitions/dto.SaveRuleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GoalResponse"
+                            "$ref": "#/definitions/dto.RuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Bonuses already awarded are kept.",
+                "tags": [
+                    "bonus-rules"
+                ],
+                "summary": "Delete a bonus rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -565,41 +473,74 @@ const docTemplate = `{
                 }
             }
         },
-        "/missions/{id}/status": {
-            "patch": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/cats": {
+            "get": {
+                "description": "ability to view the list of cats",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "missions"
+                    "cats"
                 ],
-                "summary": "Update mission status",
+                "summary": "List spy cats",
                 "parameters": [
                     {
+                        "maximum": 200,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
                         "type": "integer",
-                        "description": "Mission ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     },
                     {
-                        "description": "new status",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateMissionStatusRequest"
-                        }
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by breed",
+                        "name": "breed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Min experience",
+                        "name": "min_years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max experience",
+                        "name": "max_years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated keys from id|name|years_experience|breed|salary|created_at, prefix with - for descending, e.g. -salary,name; salary sorts by currency, then amount",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convert salaries to this ISO 4217 currency",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MissionResponse"
+                            "$ref": "#/definitions/dto.GetCatsResponse"
                         }
                     },
                     "400": {
@@ -608,14 +549,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/cats/create": {
+            "post": {
+                "description": "Used to create a new spy cat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Create a new spy cat",
+                "parameters": [
+                    {
+                        "description": "Request to create a cat",
+                        "name": "CreateCatRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCatResponse"
+                        }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -628,138 +603,4473 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AddGoalRequest": {
-            "type": "object",
-            "required": [
-                "country",
-                "name"
-            ],
-            "properties": {
-                "country": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 64,
-                    "minLength": 2
-                },
-                "notes": {
-                    "type": "string",
-                    "maxLength": 1000
-                }
-            }
         },
-        "dto.AssignMissionRequest": {
-            "type": "object",
-            "properties": {
-                "catId": {
-                    "type": "integer"
+        "/cats/export": {
+            "get": {
+                "description": "Streams cats as CSV or NDJSON, chosen by the format parameter or the Accept header",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Export spy cats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by breed",
+                        "name": "breed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Min experience",
+                        "name": "min_years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max experience",
+                        "name": "max_years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv|ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convert salaries to this ISO 4217 currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "exported rows",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "dto.CatResponse": {
-            "type": "object",
-            "properties": {
-                "breed": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+        "/cats/import": {
+            "post": {
+                "description": "Accepts a CSV upload (multipart field \"file\" or a text/csv body) with the columns\nname, years_experience, breed and salary. Every row is validated and the accepted\nones are stored; dry_run only validates. Returns a per-row report.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Import spy cats from CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only, do not store",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportCatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/leaderboard": {
+            "get": {
+                "description": "Ranks cats by completed missions or done goals over the last N days.\nTies fall back to the other metric, then to the faster average completion.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Cat leaderboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "window in days (1..3650), default 30",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "missions|goals, default missions",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit (1..100), default 10",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/out-of-band": {
+            "get": {
+                "description": "Lists cats whose current salary is outside the band for their experience and breed,\nincluding salaries accepted with an override. Cats without a band are not listed,\nnor are cats paid in a currency without an exchange rate to their band's currency.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Cats outside their salary band",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutOfBandListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/{id}": {
+            "get": {
+                "description": "The ability to receive information about a single cat",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Get a single spy cat",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Cat",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convert the salary to this ISO 4217 currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft deletes a cat by id. A cat on an active mission cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Delete cat",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteCatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/{id}/bonuses": {
+            "get": {
+                "description": "Bonuses awarded to the cat for completed missions it led, newest first, with totals per currency.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Cat bonuses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CatBonusesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/{id}/missions": {
+            "get": {
+                "description": "Missions where the cat is on the team, in any role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "List missions of a cat",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CatMissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/{id}/performance": {
+            "get": {
+                "description": "Missions completed and failed (any team role), goals done, average time\nfrom start to completion and countries where the cat finished goals.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Cat performance profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the last N days (1..3650); whole history when omitted",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CatPerformanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/{id}/restore": {
+            "post": {
+                "description": "Restores a soft deleted cat. A cat already anonymised by the purge job can no longer be restored and returns 404",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Restore cat",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/{id}/salary": {
+            "patch": {
+                "description": "Updates salary for a specific cat and records the change in the salary ledger.\neffective_from (YYYY-MM-DD) defaults to today; it may be backdated but not before the latest change.\ncurrency switches the currency the cat is paid in; it defaults to the current one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Update cat salary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New salary",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateSalaryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/{id}/salary-history": {
+            "get": {
+                "description": "Returns the salary ledger of a cat, newest change first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Get cat salary history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SalaryHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/countries": {
+            "get": {
+                "description": "Countries accepted as goal targets. Goals may reference a country by\nalpha-2, alpha-3 or English name; it is stored as alpha-2.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "List ISO 3166-1 countries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Africa | Americas | Asia | Europe | Oceania | Antarctica",
+                        "name": "region",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CountriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission-schedules": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mission-schedules"
+                ],
+                "summary": "List recurring mission schedules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "limit (1..200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetSchedulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Repeats a template (templateId) or an existing mission (missionId) on a\nfive-field cron expression evaluated in the given time zone. The mission of\neach run is created leadTime ahead of it, at most once per run.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mission-schedules"
+                ],
+                "summary": "Create a recurring mission schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule",
+                        "name": "CreateScheduleRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission-schedules/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mission-schedules"
+                ],
+                "summary": "Get a recurring mission schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Missions already created by the schedule are kept.",
+                "tags": [
+                    "mission-schedules"
+                ],
+                "summary": "Delete a recurring mission schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Runs missed while a schedule was paused are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mission-schedules"
+                ],
+                "summary": "Pause or resume a recurring mission schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule state",
+                        "name": "UpdateScheduleRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission-templates": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mission-templates"
+                ],
+                "summary": "List mission templates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "limit (1..200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetTemplatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Title, description and goals may contain {{name}} placeholders,\na goal country may be a placeholder too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mission-templates"
+                ],
+                "summary": "Create a mission template",
+                "parameters": [
+                    {
+                        "description": "Template",
+                        "name": "SaveTemplateRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SaveTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission-templates/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mission-templates"
+                ],
+                "summary": "Get a mission template with its goals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces every field and the whole goal list of the template.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mission-templates"
+                ],
+                "summary": "Replace a mission template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template",
+                        "name": "SaveTemplateRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SaveTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Missions created from the template are kept.",
+                "tags": [
+                    "mission-templates"
+                ],
+                "summary": "Delete a mission template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mission/{id}": {
+            "get": {
+                "description": "The ability to receive information about a single mission",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Get a single mission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Mission",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MissionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "List missions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "planned|active|paused|completed|aborted|failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lead cat ID",
+                        "name": "catId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Any team member cat ID",
+                        "name": "memberCatId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "full-text search over title, description, goal names and notes",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "priority (1 highest..5 lowest)",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "deadline before (RFC3339)",
+                        "name": "dueBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "deadline at or after (RFC3339)",
+                        "name": "dueAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only active missions past their deadline",
+                        "name": "overdue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "missions with a goal inside minLon,minLat,maxLon,maxLat",
+                        "name": "bbox",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated keys from created_at|updated_at|due_at|priority|started_at|completed_at|title|status, prefix with - for descending, e.g. -updated_at,title",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit (1..200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetMissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Used to create a new mission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Create a new mission",
+                "parameters": [
+                    {
+                        "description": "Request to create a mission",
+                        "name": "CreateMissionRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMissionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/bulk": {
+            "post": {
+                "description": "Validates every mission up front and creates all of them in a single transaction.\nIf any item is invalid nothing is created and per-item errors are returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Create many missions at once",
+                "parameters": [
+                    {
+                        "description": "Missions to create",
+                        "name": "BulkCreateMissionsRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkCreateMissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkCreateMissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkCreateMissionsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/export": {
+            "get": {
+                "description": "Streams missions as CSV or NDJSON, chosen by the format parameter or the Accept header",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Export missions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "planned|active|paused|completed|aborted|failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lead cat ID",
+                        "name": "catId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Any team member cat ID",
+                        "name": "memberCatId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "full-text search over title, description, goal names and notes",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "priority (1 highest..5 lowest)",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "deadline before (RFC3339)",
+                        "name": "dueBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "deadline at or after (RFC3339)",
+                        "name": "dueAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only active missions past their deadline",
+                        "name": "overdue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "missions with a goal inside minLon,minLat,maxLon,maxLat",
+                        "name": "bbox",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv|ndjson",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "exported rows",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/from-template/{id}": {
+            "post": {
+                "description": "Fills the template placeholders from vars and creates a planned mission.\nThe body is optional when the template has no placeholders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Create a mission from a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Placeholder values and overrides",
+                        "name": "InstantiateTemplateRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InstantiateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMissionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/assign": {
+            "patch": {
+                "description": "Used to link or unlink a mission with a cat.\nReassigning an active or paused mission is a handover and requires a note.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Assign mission to a cat (or unassign with null)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assignment payload",
+                        "name": "AssignMissionRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AssignMissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/candidates": {
+            "get": {
+                "description": "Scores every cat not yet on the team by experience, breed traits, workload,\nsuccess in the mission's countries and salary cost. Each factor is returned\nseparately so the ranking can be explained.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Rank candidate cats for a mission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit (1..100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CandidatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/clone": {
+            "post": {
+                "description": "Creates a new planned mission with the same title, description, priority and goals.\nThe copy has no cat and all of its goals start as todo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Clone a mission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Overrides for the copy",
+                        "name": "CloneMissionRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CloneMissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMissionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/dependencies": {
+            "get": {
+                "description": "Returns every mission the mission depends on, directly or not, every mission\nthat depends on it, and the edges between them. blockedBy lists the direct\ndependencies that still keep the mission from becoming active.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Get the dependency graph of a mission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DependencyGraphResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The mission cannot become active until the other one is completed.\nOnly planned missions can gain dependencies, and cycles are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Make a mission depend on another one",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dependency",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddDependencyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DependencyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/dependencies/{dependsOnId}": {
+            "delete": {
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Remove a mission dependency",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the mission depended on",
+                        "name": "dependsOnId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/goals": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Add goal to mission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Goal payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddGoalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GoalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/goals.geojson": {
+            "get": {
+                "description": "Located goals as a FeatureCollection of points, ready for map clients.\nGoals without coordinates are omitted.",
+                "produces": [
+                    "application/geo+json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Mission goals as GeoJSON",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FeatureCollection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/goals/order": {
+            "put": {
+                "description": "goalIds must list every goal of the mission exactly once, in the new order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Reorder the goals of a mission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Goal ids in order",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReorderGoalsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MissionGoalsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/goals/{goalId}/assignee": {
+            "patch": {
+                "description": "Sets the cat responsible for a goal; the cat must be on the mission team. Null clears it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Assign a goal to a team member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Goal ID",
+                        "name": "goalId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assignee",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AssignGoalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GoalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/goals/{goalId}/status": {
+            "patch": {
+                "description": "Goals move todo -\u003e in_progress -\u003e done, failed or skipped; failed and skipped\ngoals can be reopened to todo. Starting, finishing or failing a goal needs an\nactive mission. A done goal is credited to its assignee or the mission lead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Change the status of a goal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Goal ID",
+                        "name": "goalId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateGoalStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GoalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/handovers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "List mission handovers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MissionHandoversResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/status": {
+            "patch": {
+                "description": "Moves the mission along the status graph, see /missions/{id}/transitions.\nA reason is required when aborting or failing a mission.\nA mission cannot become active until all of its dependencies are completed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Update mission status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new status",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateMissionStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MissionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/team": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Get mission team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MissionTeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a support or lookout member. The lead is set with /missions/{id}/assign.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Add a cat to the mission team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Team member",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddTeamMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TeamMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/team/{catId}": {
+            "delete": {
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Remove a cat from the mission team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cat ID",
+                        "name": "catId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/missions/{id}/transitions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "List allowed status transitions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MissionTransitionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll": {
+            "get": {
+                "description": "Returns what every cat earned in a month. Salary changes inside the month are prorated by day;\nmission bonuses are paid in the month they were awarded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cats"
+                ],
+                "summary": "Monthly payroll report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month, YYYY-MM",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convert every amount to this ISO 4217 currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayrollResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/salary-bands": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "salary-bands"
+                ],
+                "summary": "List salary bands",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BandsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "A band limits the salary of cats with min_years..max_years of experience.\nWithout a breed it applies to every breed; a breed band wins over it.\nBands of the same breed may not overlap.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "salary-bands"
+                ],
+                "summary": "Create a salary band",
+                "parameters": [
+                    {
+                        "description": "Band",
+                        "name": "SaveBandRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SaveBandRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BandResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/salary-bands/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "salary-bands"
+                ],
+                "summary": "Get a salary band",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Band ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BandResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Salaries already outside the new band are kept; see /cats/out-of-band.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "salary-bands"
+                ],
+                "summary": "Replace a salary band",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Band ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Band",
+                        "name": "SaveBandRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SaveBandRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BandResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "salary-bands"
+                ],
+                "summary": "Delete a salary band",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Band ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Matches title, description, goal names and goal notes, ranked by relevance.\nq accepts web search syntax: \"quoted phrase\", or, -excluded.\nMatched terms are wrapped in \u003cmark\u003e tags; all other text is HTML-escaped.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "missions"
+                ],
+                "summary": "Full-text search over missions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "planned|active|paused|completed|aborted|failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit (1..100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset (\u003e=0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Missions and goals by status, cats without missions, average mission\nduration, salary totals per breed and completions per week.\nThe snapshot is cached for a short time; generatedAt tells its age.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Dashboard statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AddDependencyRequest": {
+            "type": "object",
+            "required": [
+                "dependsOnId"
+            ],
+            "properties": {
+                "dependsOnId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AddGoalRequest": {
+            "type": "object",
+            "required": [
+                "country",
+                "name"
+            ],
+            "properties": {
+                "country": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "location": {
+                    "$ref": "#/definitions/dto.GoalLocationRequest"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 2
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "dto.AddTeamMemberRequest": {
+            "type": "object",
+            "required": [
+                "catId",
+                "role"
+            ],
+            "properties": {
+                "catId": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "support",
+                        "lookout"
+                    ]
+                }
+            }
+        },
+        "dto.AssignGoalRequest": {
+            "type": "object",
+            "properties": {
+                "catId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AssignMissionRequest": {
+            "type": "object",
+            "properties": {
+                "catId": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "dto.BandResponse": {
+            "type": "object",
+            "properties": {
+                "breed": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_salary": {
+                    "type": "number"
+                },
+                "max_years": {
+                    "type": "integer"
+                },
+                "min_salary": {
+                    "type": "number"
+                },
+                "min_years": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BandsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BandResponse"
+                    }
+                }
+            }
+        },
+        "dto.BonusResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "awarded_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "mission_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "rule_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BreedSalaryResponse": {
+            "type": "object",
+            "properties": {
+                "breed": {
+                    "type": "string"
+                },
+                "cats": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.BulkCreateMissionsRequest": {
+            "type": "object",
+            "required": [
+                "missions"
+            ],
+            "properties": {
+                "missions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.CreateMissionRequest"
+                    }
+                }
+            }
+        },
+        "dto.BulkCreateMissionsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkItemResult"
+                    }
+                }
+            }
+        },
+        "dto.BulkItemError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BulkItemResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/dto.BulkItemError"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CandidateResponse": {
+            "type": "object",
+            "properties": {
+                "breakdown": {
+                    "$ref": "#/definitions/dto.ScoreBreakdownResponse"
+                },
+                "breed": {
+                    "type": "string"
+                },
+                "busy": {
+                    "type": "boolean"
+                },
+                "catId": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "salary": {
+                    "type": "number"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "successRate": {
+                    "type": "number"
+                },
+                "yearsExperience": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CandidatesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CandidateResponse"
+                    }
+                },
+                "missionId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CatBonusesResponse": {
+            "type": "object",
+            "properties": {
+                "cat_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BonusResponse"
+                    }
+                },
+                "totals": {
+                    "description": "Totals has one entry per currency.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TotalResponse"
+                    }
+                }
+            }
+        },
+        "dto.CatMissionResponse": {
+            "type": "object",
+            "properties": {
+                "catId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dueAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CatMissionsResponse": {
+            "type": "object",
+            "properties": {
+                "catId": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CatMissionResponse"
+                    }
+                }
+            }
+        },
+        "dto.CatPerformanceResponse": {
+            "type": "object",
+            "properties": {
+                "avgCompletionSeconds": {
+                    "type": "integer"
+                },
+                "catId": {
+                    "type": "integer"
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CountryActivityResponse"
+                    }
+                },
+                "goalsDone": {
+                    "type": "integer"
+                },
+                "missionsCompleted": {
+                    "type": "integer"
+                },
+                "missionsFailed": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CatResponse": {
+            "type": "object",
+            "properties": {
+                "breed": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "salary": {
+                    "type": "number"
+                },
+                "years_experience": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CloneMissionRequest": {
+            "type": "object",
+            "properties": {
+                "dueAt": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.CountriesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CountryResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CountryActivityResponse": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "goalsDone": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CountryResponse": {
+            "type": "object",
+            "properties": {
+                "alpha2": {
+                    "type": "string",
+                    "example": "UA"
+                },
+                "alpha3": {
+                    "type": "string",
+                    "example": "UKR"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Ukraine"
+                },
+                "numeric": {
+                    "type": "string",
+                    "example": "804"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "Europe"
+                }
+            }
+        },
+        "dto.CreateCatRequest": {
+            "type": "object",
+            "required": [
+                "breed",
+                "name"
+            ],
+            "properties": {
+                "breed": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 2
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 2
+                },
+                "override_reason": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "salary": {
+                    "type": "number"
+                },
+                "salary_override": {
+                    "type": "boolean"
+                },
+                "years_experience": {
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.CreateCatResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateGoalRequest": {
+            "type": "object",
+            "required": [
+                "country",
+                "name"
+            ],
+            "properties": {
+                "country": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "location": {
+                    "$ref": "#/definitions/dto.GoalLocationRequest"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 2
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "dto.CreateMissionRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "dueAt": {
+                    "type": "string"
+                },
+                "goals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CreateGoalRequest"
+                    }
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "planned",
+                        "active",
+                        "paused",
+                        "completed",
+                        "aborted",
+                        "failed"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.CreateMissionResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateScheduleRequest": {
+            "type": "object",
+            "required": [
+                "cron"
+            ],
+            "properties": {
+                "cron": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "0 9 * * 1"
+                },
+                "leadTime": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "48h"
+                },
+                "missionId": {
+                    "type": "integer"
+                },
+                "templateId": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Europe/Kyiv"
+                },
+                "vars": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.DeleteCatResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DependencyGraphResponse": {
+            "type": "object",
+            "properties": {
+                "blockedBy": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependencyResponse"
+                    }
+                },
+                "missionId": {
+                    "type": "integer"
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependencyNodeResponse"
+                    }
+                }
+            }
+        },
+        "dto.DependencyNodeResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DependencyResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "dependsOnId": {
+                    "type": "integer"
+                },
+                "missionId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INVALID_INPUT"
+                },
+                "details": {},
+                "message": {
+                    "type": "string",
+                    "example": "validation error"
+                }
+            }
+        },
+        "dto.ExchangeRateResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "rate": {
+                    "type": "number",
+                    "example": 1.0842
+                },
+                "to": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExchangeRatesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExchangeRateResponse"
+                    }
+                }
+            }
+        },
+        "dto.Feature": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "$ref": "#/definitions/dto.PointGeometry"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "properties": {
+                    "$ref": "#/definitions/dto.GoalFeatureProps"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Feature"
+                }
+            }
+        },
+        "dto.FeatureCollection": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Feature"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "FeatureCollection"
+                }
+            }
+        },
+        "dto.GetCatsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CatResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_offset": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetMissionsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MissionListItem"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetSchedulesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ScheduleResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetTemplatesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TemplateResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GoalFeatureProps": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "missionId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "radiusM": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GoalLocationRequest": {
+            "type": "object",
+            "required": [
+                "lat",
+                "lon"
+            ],
+            "properties": {
+                "lat": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90,
+                    "example": 50.4501
+                },
+                "lon": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180,
+                    "example": 30.5234
+                },
+                "radiusM": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "minimum": 1,
+                    "example": 500
+                }
+            }
+        },
+        "dto.GoalLocationResponse": {
+            "type": "object",
+            "properties": {
+                "lat": {
+                    "type": "number"
+                },
+                "lon": {
+                    "type": "number"
+                },
+                "radiusM": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GoalResponse": {
+            "type": "object",
+            "properties": {
+                "assigneeCatId": {
+                    "type": "integer"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "countryName": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "doneByCatId": {
+                    "type": "integer"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "$ref": "#/definitions/dto.GoalLocationResponse"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.HandoverResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "fromCatId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "toCatId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportCatRow": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImportCatsResponse": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportCatRow"
+                    }
+                }
+            }
+        },
+        "dto.InstantiateTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "dueAt": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 3
+                },
+                "vars": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.LeaderboardEntryResponse": {
+            "type": "object",
+            "properties": {
+                "avgCompletionSeconds": {
+                    "type": "integer"
+                },
+                "breed": {
+                    "type": "string"
+                },
+                "catId": {
+                    "type": "integer"
+                },
+                "goalsDone": {
+                    "type": "integer"
+                },
+                "missionsCompleted": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntryResponse"
+                    }
+                },
+                "metric": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MissionGoalsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GoalResponse"
+                    }
+                },
+                "missionId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MissionHandoversResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.HandoverResponse"
+                    }
+                },
+                "missionId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MissionListItem": {
+            "type": "object",
+            "properties": {
+                "catId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dueAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MissionResponse": {
+            "type": "object",
+            "properties": {
+                "catId": {
+                    "type": "integer"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dueAt": {
+                    "type": "string"
+                },
+                "goals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GoalResponse"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "$ref": "#/definitions/dto.ProgressResponse"
+                },
+                "scheduleId": {
+                    "type": "integer"
+                },
+                "scheduledFor": {
+                    "type": "string"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "statusReason": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MissionTeamResponse": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TeamMemberResponse"
+                    }
+                },
+                "missionId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MissionTransitionsResponse": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TransitionResponse"
+                    }
+                },
+                "missionId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "terminal": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.MoneyResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OutOfBandListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutOfBandResponse"
+                    }
+                }
+            }
+        },
+        "dto.OutOfBandResponse": {
+            "type": "object",
+            "properties": {
+                "band_currency": {
+                    "type": "string"
+                },
+                "band_id": {
+                    "type": "integer"
+                },
+                "band_max": {
+                    "type": "number"
+                },
+                "band_min": {
+                    "type": "number"
+                },
+                "breed": {
+                    "type": "string"
+                },
+                "cat_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "gap": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string",
+                    "enum": [
+                        "below",
+                        "above"
+                    ]
                 },
                 "salary": {
                     "type": "number"
                 },
+                "salary_in_band": {
+                    "description": "SalaryInBand is the salary in the band's currency.",
+                    "type": "number"
+                },
                 "years_experience": {
                     "type": "integer"
                 }
             }
         },
-        "dto.CreateCatRequest": {
+        "dto.PayrollLineResponse": {
             "type": "object",
-            "required": [
-                "breed",
-                "name"
-            ],
             "properties": {
+                "amount": {
+                    "description": "Amount is salary plus bonus.",
+                    "type": "number"
+                },
+                "bonus": {
+                    "type": "number"
+                },
                 "breed": {
-                    "type": "string",
-                    "maxLength": 64,
-                    "minLength": 2
+                    "type": "string"
+                },
+                "cat_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "days_paid": {
+                    "type": "integer"
                 },
                 "name": {
-                    "type": "string",
-                    "maxLength": 64,
-                    "minLength": 2
+                    "type": "string"
                 },
                 "salary": {
-                    "type": "number",
-                    "maximum": 1000000,
-                    "minimum": 0
+                    "type": "number"
+                }
+            }
+        },
+        "dto.PayrollResponse": {
+            "type": "object",
+            "properties": {
+                "days_in_month": {
+                    "type": "integer"
                 },
-                "years_experience": {
-                    "type": "integer",
-                    "maximum": 60,
-                    "minimum": 0
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PayrollLineResponse"
+                    }
+                },
+                "month": {
+                    "type": "string"
+                },
+                "totals": {
+                    "description": "Totals has one entry per currency, or one in total when converted.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MoneyResponse"
+                    }
                 }
             }
         },
-        "dto.CreateCatResponse": {
+        "dto.PointGeometry": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Point"
+                }
+            }
+        },
+        "dto.ProgressResponse": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "inProgress": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "todo": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReorderGoalsRequest": {
+            "type": "object",
+            "required": [
+                "goalIds"
+            ],
+            "properties": {
+                "goalIds": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.RiskLevelResponse": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RiskLevelsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RiskLevelResponse"
+                    }
+                }
+            }
+        },
+        "dto.RuleResponse": {
             "type": "object",
             "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "risk_level": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.CreateGoalRequest": {
+        "dto.RulesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RuleResponse"
+                    }
+                }
+            }
+        },
+        "dto.SalaryChangeResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_salary": {
+                    "type": "number"
+                },
+                "old_currency": {
+                    "type": "string"
+                },
+                "old_salary": {
+                    "type": "number"
+                },
+                "overridden": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SalaryHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "cat_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SalaryChangeResponse"
+                    }
+                }
+            }
+        },
+        "dto.SaveBandRequest": {
             "type": "object",
             "required": [
-                "country",
+                "max_salary"
+            ],
+            "properties": {
+                "breed": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "max_salary": {
+                    "type": "number"
+                },
+                "max_years": {
+                    "description": "MaxYears is open ended when omitted.",
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 0
+                },
+                "min_salary": {
+                    "type": "number"
+                },
+                "min_years": {
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.SaveRuleRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "kind",
                 "name"
             ],
             "properties": {
-                "country": {
+                "active": {
+                    "description": "Active defaults to true.",
+                    "type": "boolean"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "description": "Currency defaults to USD.",
                     "type": "string"
                 },
-                "name": {
+                "kind": {
                     "type": "string",
-                    "maxLength": 64,
-                    "minLength": 2
+                    "enum": [
+                        "flat",
+                        "per_goal",
+                        "country_risk"
+                    ]
                 },
-                "notes": {
+                "name": {
                     "type": "string",
-                    "maxLength": 1000
+                    "maxLength": 64
+                },
+                "risk_level": {
+                    "description": "RiskLevel is required for country_risk rules and rejected otherwise.",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
                 }
             }
         },
-        "dto.CreateMissionRequest": {
+        "dto.SaveTemplateRequest": {
             "type": "object",
             "required": [
+                "name",
                 "title"
             ],
             "properties": {
                 "description": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 2000
                 },
                 "goals": {
                     "type": "array",
+                    "maxItems": 100,
                     "items": {
-                        "$ref": "#/definitions/dto.CreateGoalRequest"
+                        "$ref": "#/definitions/dto.TemplateGoalRequest"
                     }
                 },
-                "status": {
+                "name": {
                     "type": "string",
-                    "enum": [
-                        "planned",
-                        "active",
-                        "completed"
-                    ]
+                    "maxLength": 64,
+                    "minLength": 2
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
                 },
                 "title": {
                     "type": "string",
@@ -768,131 +5078,247 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.CreateMissionResponse": {
+        "dto.ScheduleResponse": {
             "type": "object",
             "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "cron": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "integer"
+                },
+                "lastRunAt": {
+                    "type": "string"
+                },
+                "leadTime": {
+                    "type": "string"
+                },
+                "missionId": {
+                    "type": "integer"
+                },
+                "nextRunAt": {
+                    "type": "string"
+                },
+                "templateId": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "vars": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.DeleteCatResponse": {
+        "dto.ScoreBreakdownResponse": {
             "type": "object",
             "properties": {
-                "deleted": {
-                    "type": "boolean"
+                "breedTraits": {
+                    "type": "number"
                 },
-                "id": {
-                    "type": "integer"
+                "countrySuccess": {
+                    "type": "number"
+                },
+                "experience": {
+                    "type": "number"
+                },
+                "salaryCost": {
+                    "type": "number"
+                },
+                "workload": {
+                    "type": "number"
                 }
             }
         },
-        "dto.ErrorResponse": {
+        "dto.SearchHitResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "catId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "snippet": {
                     "type": "string",
-                    "example": "INVALID_INPUT"
+                    "example": "… cats near the \u003cmark\u003eharbour\u003c/mark\u003e cranes …"
                 },
-                "details": {},
-                "message": {
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "titleHighlight": {
                     "type": "string",
-                    "example": "validation error"
+                    "example": "Watch the \u003cmark\u003eharbour\u003c/mark\u003e"
                 }
             }
         },
-        "dto.GetCatsResponse": {
+        "dto.SearchResponse": {
             "type": "object",
             "properties": {
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.CatResponse"
+                        "$ref": "#/definitions/dto.SearchHitResponse"
                     }
                 },
                 "limit": {
                     "type": "integer"
                 },
-                "next_offset": {
+                "offset": {
                     "type": "integer"
                 },
-                "offset": {
+                "query": {
+                    "type": "string"
+                },
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "dto.GetMissionsResponse": {
+        "dto.SetRateRequest": {
+            "type": "object",
+            "required": [
+                "rate"
+            ],
+            "properties": {
+                "rate": {
+                    "description": "Rate is how many units of the target currency one unit of the source buys.",
+                    "type": "number",
+                    "example": 1.0842
+                }
+            }
+        },
+        "dto.SetRiskLevelRequest": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.StatsResponse": {
             "type": "object",
             "properties": {
-                "items": {
+                "avgMissionDurationSeconds": {
+                    "type": "integer"
+                },
+                "cats": {
+                    "type": "integer"
+                },
+                "catsWithoutMissions": {
+                    "type": "integer"
+                },
+                "completionsPerWeek": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.MissionListItem"
+                        "$ref": "#/definitions/dto.WeekCountResponse"
                     }
                 },
-                "limit": {
-                    "type": "integer"
+                "generatedAt": {
+                    "type": "string"
                 },
-                "offset": {
-                    "type": "integer"
+                "goals": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 },
-                "total": {
-                    "type": "integer"
+                "missions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "salaryByBreed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BreedSalaryResponse"
+                    }
                 }
             }
         },
-        "dto.GoalResponse": {
+        "dto.TeamMemberResponse": {
             "type": "object",
             "properties": {
-                "country": {
-                    "type": "string"
-                },
-                "createdAt": {
+                "assignedAt": {
                     "type": "string"
                 },
-                "id": {
+                "catId": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "notes": {
+                "role": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.TemplateGoalRequest": {
+            "type": "object",
+            "required": [
+                "country",
+                "name"
+            ],
+            "properties": {
+                "country": {
+                    "type": "string",
+                    "maxLength": 64
                 },
-                "status": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 2
                 },
-                "updatedAt": {
-                    "type": "string"
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
                 }
             }
         },
-        "dto.MissionListItem": {
+        "dto.TemplateGoalResponse": {
             "type": "object",
             "properties": {
-                "catId": {
-                    "type": "integer"
-                },
-                "createdAt": {
+                "country": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "status": {
+                "name": {
                     "type": "string"
                 },
-                "title": {
+                "notes": {
                     "type": "string"
+                },
+                "position": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.MissionResponse": {
+        "dto.TemplateResponse": {
             "type": "object",
             "properties": {
-                "catId": {
-                    "type": "integer"
-                },
                 "createdAt": {
                     "type": "string"
                 },
@@ -902,15 +5328,24 @@ const docTemplate = `{
                 "goals": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.GoalResponse"
+                        "$ref": "#/definitions/dto.TemplateGoalResponse"
                     }
                 },
                 "id": {
                     "type": "integer"
                 },
-                "status": {
+                "name": {
                     "type": "string"
                 },
+                "placeholders": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "priority": {
+                    "type": "integer"
+                },
                 "title": {
                     "type": "string"
                 },
@@ -919,18 +5354,65 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TotalResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TransitionResponse": {
+            "type": "object",
+            "properties": {
+                "requiresReason": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateGoalStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "in_progress",
+                        "done",
+                        "failed",
+                        "skipped"
+                    ]
+                }
+            }
+        },
         "dto.UpdateMissionStatusRequest": {
             "type": "object",
             "required": [
                 "status"
             ],
             "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
                 "status": {
                     "type": "string",
                     "enum": [
                         "planned",
                         "active",
-                        "completed"
+                        "paused",
+                        "completed",
+                        "aborted",
+                        "failed"
                     ]
                 }
             }
@@ -941,10 +5423,48 @@ const docTemplate = `{
                 "salary"
             ],
             "properties": {
+                "actor": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "override": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                },
                 "salary": {
-                    "type": "number",
-                    "maximum": 1000000,
-                    "minimum": 0
+                    "type": "number"
+                }
+            }
+        },
+        "dto.UpdateScheduleRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.WeekCountResponse": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer"
+                },
+                "weekStart": {
+                    "type": "string",
+                    "example": "2026-03-02"
                 }
             }
         }
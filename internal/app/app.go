@@ -14,11 +14,24 @@ import (
 	postgres "github.com/DavydAbbasov/spy-cat/internal/lib/postgresql"
 	"github.com/joho/godotenv"
 
+	bonusrepository "github.com/DavydAbbasov/spy-cat/internal/repository/bonus_repo"
 	catrepository "github.com/DavydAbbasov/spy-cat/internal/repository/cat_repo"
+	currencyrepository "github.com/DavydAbbasov/spy-cat/internal/repository/currency_repo"
 	missionrepository "github.com/DavydAbbasov/spy-cat/internal/repository/mission_repo"
+	schedulerepository "github.com/DavydAbbasov/spy-cat/internal/repository/schedule_repo"
+	statsrepository "github.com/DavydAbbasov/spy-cat/internal/repository/stats_repo"
+	templaterepository "github.com/DavydAbbasov/spy-cat/internal/repository/template_repo"
 
+	assignmentservice "github.com/DavydAbbasov/spy-cat/internal/service/assignment_service"
+	bandservice "github.com/DavydAbbasov/spy-cat/internal/service/band_service"
+	bonusservice "github.com/DavydAbbasov/spy-cat/internal/service/bonus_service"
 	catservice "github.com/DavydAbbasov/spy-cat/internal/service/cat_service"
+	currencyservice "github.com/DavydAbbasov/spy-cat/internal/service/currency_service"
 	missionservice "github.com/DavydAbbasov/spy-cat/internal/service/mission_service"
+	recommendationservice "github.com/DavydAbbasov/spy-cat/internal/service/recommendation_service"
+	scheduleservice "github.com/DavydAbbasov/spy-cat/internal/service/schedule_service"
+	statsservice "github.com/DavydAbbasov/spy-cat/internal/service/stats_service"
+	templateservice "github.com/DavydAbbasov/spy-cat/internal/service/template_service"
 
 	log "github.com/rs/zerolog/log"
 )
@@ -41,17 +54,32 @@ func Run() error {
 		cfg.CatAPI.APIKey,
 		cfg.CatAPI.Timeout,
 	)
+	breeds := catapi.NewCachedValidator(breedClient, cfg.CatAPI.CacheTTL)
+	traits := catapi.NewCachedTraits(breedClient, cfg.CatAPI.CacheTTL)
 	// repository
 	catRepo := catrepository.NewCatRepository(db)
 	missionRepo := missionrepository.NewMissionRepository(db)
+	templateRepo := templaterepository.NewTemplateRepository(db)
+	scheduleRepo := schedulerepository.NewScheduleRepository(db)
+	statsRepo := statsrepository.NewStatsRepository(db)
+	currencyRepo := currencyrepository.NewCurrencyRepository(db)
+	bonusRepo := bonusrepository.NewBonusRepository(db)
 
 	// services
-	catSvc := catservice.NewCatService(catRepo, breedClient)
+	currencySvc := currencyservice.NewCurrencyService(currencyRepo)
+	catSvc := catservice.NewCatService(catRepo, breeds, currencySvc)
+	bandSvc := bandservice.NewBandService(catRepo)
+	bonusSvc := bonusservice.NewBonusService(bonusRepo)
 	missionSvc := missionservice.NewMissionService(missionRepo)
+	recommendationSvc := recommendationservice.NewRecommendationService(missionRepo, traits, currencySvc)
+	templateSvc := templateservice.NewTemplateService(templateRepo, missionSvc)
+	locker := postgres.NewAdvisoryLocker(db)
+	scheduleSvc := scheduleservice.NewScheduleService(scheduleRepo, locker, templateSvc, missionSvc)
+	statsSvc := statsservice.NewStatsService(statsRepo, cfg.Stats.CacheTTL, cfg.Stats.Weeks)
 
 	httpServer := &http.Server{
 		Addr:    cfg.HTTP.Addr,
-		Handler: NewRouter(catSvc, missionSvc),
+		Handler: NewRouter(catSvc, missionSvc, recommendationSvc, templateSvc, scheduleSvc, statsSvc, currencySvc, bandSvc, bonusSvc),
 
 		ReadTimeout:  cfg.HTTP.ReadTimeout,
 		WriteTimeout: cfg.HTTP.WriteTimeout,
@@ -61,6 +89,25 @@ func Run() error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	go runCatPurge(ctx, catSvc, cfg.Cats)
+	go runRecurringMissions(ctx, scheduleSvc, cfg.Recurrence.Interval)
+
+	if cfg.AutoAssign.Enabled {
+		policy, err := assignmentservice.NewPolicy(cfg.AutoAssign.Policy)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid auto assignment config")
+		}
+		assigner := assignmentservice.NewAutoAssigner(
+			missionRepo,
+			locker,
+			recommendationSvc,
+			missionSvc,
+			policy,
+			cfg.AutoAssign.BatchSize,
+		)
+		go runAutoAssign(ctx, assigner, cfg.AutoAssign.Interval)
+	}
+
 	go func() {
 		if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 			log.Fatal().Err(err).Msg("failed to start http server")
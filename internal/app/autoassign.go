@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	assignmentservice "github.com/DavydAbbasov/spy-cat/internal/service/assignment_service"
+
+	log "github.com/rs/zerolog/log"
+)
+
+// runAutoAssign periodically hands unassigned planned missions to free cats.
+// It stops when ctx is cancelled.
+func runAutoAssign(ctx context.Context, assigner assignmentservice.AutoAssigner, interval time.Duration) {
+	if interval <= 0 {
+		log.Info().Msg("auto assignment disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			done, err := assigner.RunOnce(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("auto assignment run failed")
+			}
+			for _, a := range done {
+				ev := log.Info()
+				if a.Outcome == domain.AutoFailed {
+					ev = log.Warn()
+				}
+				if a.CatID != nil {
+					ev = ev.Int64("cat_id", *a.CatID)
+				}
+				ev.Int64("mission_id", a.MissionID).
+					Str("policy", a.Policy).
+					Str("outcome", string(a.Outcome)).
+					Str("detail", a.Detail).
+					Msg("auto assignment")
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/config"
+	catservice "github.com/DavydAbbasov/spy-cat/internal/service/cat_service"
+
+	log "github.com/rs/zerolog/log"
+)
+
+// runCatPurge periodically anonymises cats that were soft deleted longer
+// than the retention window ago. The rows stay so mission history, the
+// salary ledger and bonuses keep pointing at them. It stops when ctx is
+// cancelled.
+func runCatPurge(ctx context.Context, catSvc catservice.CatService, cfg config.CatsConfig) {
+	if cfg.PurgeInterval <= 0 || cfg.PurgeRetention <= 0 {
+		log.Info().Msg("cat purge disabled")
+		return
+	}
+
+	ticker := time.NewTicker(cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := catSvc.PurgeDeletedCats(ctx, cfg.PurgeRetention)
+			if err != nil {
+				log.Error().Err(err).Msg("failed to purge deleted cats")
+				continue
+			}
+			if n > 0 {
+				log.Info().Int64("purged", n).Msg("purged deleted cats")
+			}
+		}
+	}
+}
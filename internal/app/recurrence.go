@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	scheduleservice "github.com/DavydAbbasov/spy-cat/internal/service/schedule_service"
+
+	log "github.com/rs/zerolog/log"
+)
+
+// runRecurringMissions periodically creates the missions of recurring
+// schedules ahead of their runs. It stops when ctx is cancelled.
+func runRecurringMissions(ctx context.Context, svc scheduleservice.ScheduleService, interval time.Duration) {
+	if interval <= 0 {
+		log.Info().Msg("recurring missions disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			created, err := svc.RunDue(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("recurring missions run failed")
+			}
+			for _, m := range created {
+				ev := log.Info().Int64("mission_id", m.ID)
+				if m.Occurrence != nil {
+					ev = ev.Int64("schedule_id", m.Occurrence.ScheduleID).Time("run", m.Occurrence.At)
+				}
+				ev.Msg("recurring mission created")
+			}
+		}
+	}
+}
@@ -4,20 +4,45 @@ import (
 	"net/http"
 
 	pinghandler "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers"
+	bonushandlers "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/bonus"
 	cathandlers "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/cat"
+	countryhandlers "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/country"
+	currencyhandlers "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/currency"
 	missionhandlers "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/mission"
+	recommendationhandlers "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/recommendation"
+	bandhandlers "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/salary_band"
+	schedulehandlers "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/schedule"
+	statshandlers "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/stats"
+	templatehandlers "github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/template"
 
 	"github.com/DavydAbbasov/spy-cat/internal/controllers/http/handlers/swagger"
 	logmiddleware "github.com/DavydAbbasov/spy-cat/internal/controllers/http/middleware"
 	validator "github.com/DavydAbbasov/spy-cat/internal/controllers/http/validator"
 
+	bandservice "github.com/DavydAbbasov/spy-cat/internal/service/band_service"
+	bonusservice "github.com/DavydAbbasov/spy-cat/internal/service/bonus_service"
 	catservice "github.com/DavydAbbasov/spy-cat/internal/service/cat_service"
+	currencyservice "github.com/DavydAbbasov/spy-cat/internal/service/currency_service"
 	missionservice "github.com/DavydAbbasov/spy-cat/internal/service/mission_service"
+	recommendationservice "github.com/DavydAbbasov/spy-cat/internal/service/recommendation_service"
+	scheduleservice "github.com/DavydAbbasov/spy-cat/internal/service/schedule_service"
+	statsservice "github.com/DavydAbbasov/spy-cat/internal/service/stats_service"
+	templateservice "github.com/DavydAbbasov/spy-cat/internal/service/template_service"
 
 	"github.com/gin-gonic/gin"
 )
 
-func NewRouter(catSvc catservice.CatService, missionSvc missionservice.MissionService) http.Handler {
+func NewRouter(
+	catSvc catservice.CatService,
+	missionSvc missionservice.MissionService,
+	recommendationSvc recommendationservice.RecommendationService,
+	templateSvc templateservice.TemplateService,
+	scheduleSvc scheduleservice.ScheduleService,
+	statsSvc statsservice.StatsService,
+	currencySvc currencyservice.CurrencyService,
+	bandSvc bandservice.BandService,
+	bonusSvc bonusservice.BonusService,
+) http.Handler {
 
 	router := gin.Default()
 	validator := validator.NewValidator()
@@ -28,21 +53,105 @@ func NewRouter(catSvc catservice.CatService, missionSvc missionservice.MissionSe
 	// handlers
 	catHandler := cathandlers.NewCatHandler(catSvc, validator)
 	missionHandler := missionhandlers.NewMissionHandler(missionSvc, validator)
+	recommendationHandler := recommendationhandlers.NewRecommendationHandler(recommendationSvc)
+	templateHandler := templatehandlers.NewTemplateHandler(templateSvc, validator)
+	scheduleHandler := schedulehandlers.NewScheduleHandler(scheduleSvc, validator)
+	countryHandler := countryhandlers.NewCountryHandler()
+	statsHandler := statshandlers.NewStatsHandler(statsSvc)
+	currencyHandler := currencyhandlers.NewCurrencyHandler(currencySvc, validator)
+	bandHandler := bandhandlers.NewBandHandler(bandSvc, validator)
+	bonusHandler := bonushandlers.NewBonusHandler(bonusSvc, validator)
 
 	// cats
 	router.POST("/cats/create", catHandler.CreateCat())
+	router.POST("/cats/import", catHandler.ImportCats())
 	router.GET("/cats/:id", catHandler.GetCat())
 	router.GET("/cats", catHandler.GetCats())
+	router.GET("/cats/export", catHandler.ExportCats())
 	router.DELETE("/cats/:id", catHandler.DeleteCat())
+	router.POST("/cats/:id/restore", catHandler.RestoreCat())
 	router.PATCH("/cats/:id/salary", catHandler.UpdateSalary())
+	router.GET("/cats/:id/salary-history", catHandler.GetSalaryHistory())
+	router.GET("/cats/:id/bonuses", bonusHandler.GetCatBonuses())
+	router.GET("/cats/:id/missions", missionHandler.GetCatMissions())
+	router.GET("/cats/:id/performance", statsHandler.GetCatPerformance())
+	router.GET("/cats/leaderboard", statsHandler.GetLeaderboard())
+	router.GET("/cats/out-of-band", catHandler.GetOutOfBand())
 
 	// missions
 	router.POST("/missions", missionHandler.CreateMission())
+	router.POST("/missions/bulk", missionHandler.CreateMissionsBulk())
+	router.POST("/missions/from-template/:id", templateHandler.CreateMissionFromTemplate())
+	router.POST("/missions/:id/clone", missionHandler.CloneMission())
 	router.PATCH("/missions/:id/assign", missionHandler.AssignMission())
+	router.GET("/missions/:id/handovers", missionHandler.GetHandovers())
 	router.GET("/mission/:id", missionHandler.GetMission())
 	router.GET("/missions", missionHandler.GetMissions())
+	router.GET("/missions/export", missionHandler.ExportMissions())
 	router.PATCH("/missions/:id/status", missionHandler.UpdateMissionStatus())
+	router.GET("/missions/:id/transitions", missionHandler.GetTransitions())
+	router.GET("/missions/:id/candidates", recommendationHandler.GetCandidates())
 	router.POST("/missions/:id/goals", missionHandler.AddGoal())
+	router.GET("/missions/:id/goals.geojson", missionHandler.GetGoalsGeoJSON())
+	router.PUT("/missions/:id/goals/order", missionHandler.ReorderGoals())
+	router.PATCH("/missions/:id/goals/:goalId/assignee", missionHandler.AssignGoal())
+	router.PATCH("/missions/:id/goals/:goalId/status", missionHandler.UpdateGoalStatus())
+	router.GET("/missions/:id/team", missionHandler.GetTeam())
+	router.POST("/missions/:id/team", missionHandler.AddTeamMember())
+	router.DELETE("/missions/:id/team/:catId", missionHandler.RemoveTeamMember())
+	router.GET("/missions/:id/dependencies", missionHandler.GetDependencies())
+	router.POST("/missions/:id/dependencies", missionHandler.AddDependency())
+	router.DELETE("/missions/:id/dependencies/:dependsOnId", missionHandler.RemoveDependency())
+
+	// search
+	router.GET("/search", missionHandler.Search())
+
+	// mission templates
+	router.POST("/mission-templates", templateHandler.CreateTemplate())
+	router.GET("/mission-templates", templateHandler.GetTemplates())
+	router.GET("/mission-templates/:id", templateHandler.GetTemplate())
+	router.PUT("/mission-templates/:id", templateHandler.UpdateTemplate())
+	router.DELETE("/mission-templates/:id", templateHandler.DeleteTemplate())
+
+	// recurring mission schedules
+	router.POST("/mission-schedules", scheduleHandler.CreateSchedule())
+	router.GET("/mission-schedules", scheduleHandler.GetSchedules())
+	router.GET("/mission-schedules/:id", scheduleHandler.GetSchedule())
+	router.PATCH("/mission-schedules/:id", scheduleHandler.UpdateSchedule())
+	router.DELETE("/mission-schedules/:id", scheduleHandler.DeleteSchedule())
+
+	// salary bands
+	router.POST("/salary-bands", bandHandler.CreateBand())
+	router.GET("/salary-bands", bandHandler.GetBands())
+	router.GET("/salary-bands/:id", bandHandler.GetBand())
+	router.PUT("/salary-bands/:id", bandHandler.UpdateBand())
+	router.DELETE("/salary-bands/:id", bandHandler.DeleteBand())
+
+	// mission bonuses
+	router.POST("/bonus-rules", bonusHandler.CreateRule())
+	router.GET("/bonus-rules", bonusHandler.GetRules())
+	router.GET("/bonus-rules/:id", bonusHandler.GetRule())
+	router.PUT("/bonus-rules/:id", bonusHandler.UpdateRule())
+	router.DELETE("/bonus-rules/:id", bonusHandler.DeleteRule())
+
+	// payroll
+	router.GET("/payroll", catHandler.GetPayroll())
+
+	// stats
+	router.GET("/stats", statsHandler.GetStats())
+
+	// exchange rates
+	router.GET("/admin/exchange-rates", currencyHandler.GetRates())
+	router.PUT("/admin/exchange-rates/:from/:to", currencyHandler.SetRate())
+	router.DELETE("/admin/exchange-rates/:from/:to", currencyHandler.DeleteRate())
+
+	// country risk levels
+	router.GET("/admin/country-risk-levels", bonusHandler.GetRiskLevels())
+	router.PUT("/admin/country-risk-levels/:country", bonusHandler.SetRiskLevel())
+	router.DELETE("/admin/country-risk-levels/:country", bonusHandler.DeleteRiskLevel())
+
+	// countries
+	router.GET("/countries", countryHandler.GetCountries())
 
 	// swagger
 	router.GET("/swagger/*any", swagger.Swagger())
@@ -8,10 +8,14 @@ import (
 )
 
 type Config struct {
-	Environment string         `env:"ENVIRONMENT" env-default:"dev"`
-	HTTP        HTTPConfig     `env-prefix:"HTTP_"`
-	Postgres    PostgresConfig `env-prefix:"PG_"`
-	CatAPI      CatAPIConfig   `env-prefix:"CAT_API_"`
+	Environment string           `env:"ENVIRONMENT" env-default:"dev"`
+	HTTP        HTTPConfig       `env-prefix:"HTTP_"`
+	Postgres    PostgresConfig   `env-prefix:"PG_"`
+	CatAPI      CatAPIConfig     `env-prefix:"CAT_API_"`
+	Cats        CatsConfig       `env-prefix:"CATS_"`
+	AutoAssign  AutoAssignConfig `env-prefix:"AUTO_ASSIGN_"`
+	Recurrence  RecurrenceConfig `env-prefix:"RECURRENCE_"`
+	Stats       StatsConfig      `env-prefix:"STATS_"`
 }
 
 type HTTPConfig struct {
@@ -34,9 +38,32 @@ type PostgresConfig struct {
 	ConnMaxLifetime time.Duration `env:"CONN_MAX_LIFETIME" env-default:"30m"`
 }
 type CatAPIConfig struct {
-	BaseURL string        `env:"CAT_API_BASE"    env-default:"https://api.thecatapi.com"`
-	APIKey  string        `env:"CAT_API_KEY"     env-default:""`
-	Timeout time.Duration `env:"CAT_API_TIMEOUT" env-default:"2s"`
+	BaseURL  string        `env:"CAT_API_BASE"      env-default:"https://api.thecatapi.com"`
+	APIKey   string        `env:"CAT_API_KEY"       env-default:""`
+	Timeout  time.Duration `env:"CAT_API_TIMEOUT"   env-default:"2s"`
+	CacheTTL time.Duration `env:"CAT_API_CACHE_TTL" env-default:"1h"`
+}
+type CatsConfig struct {
+	// cats soft deleted longer than PurgeRetention ago are anonymised, not
+	// deleted: mission history, the salary ledger and bonuses reference them
+	PurgeRetention time.Duration `env:"PURGE_RETENTION" env-default:"720h"`
+	PurgeInterval  time.Duration `env:"PURGE_INTERVAL"  env-default:"1h"`
+}
+
+type AutoAssignConfig struct {
+	Enabled   bool          `env:"ENABLED"    env-default:"false"`
+	Interval  time.Duration `env:"INTERVAL"   env-default:"1m"`
+	BatchSize int           `env:"BATCH_SIZE" env-default:"50"`
+	Policy    string        `env:"POLICY"     env-default:"best_score"`
+}
+
+type RecurrenceConfig struct {
+	Interval time.Duration `env:"INTERVAL" env-default:"1m"`
+}
+
+type StatsConfig struct {
+	CacheTTL time.Duration `env:"CACHE_TTL" env-default:"30s"`
+	Weeks    int           `env:"WEEKS"     env-default:"12"`
 }
 
 func (p *PostgresConfig) DSN() string {
@@ -0,0 +1,141 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+type SaveRuleRequest struct {
+	Name   string       `json:"name"   validate:"required,max=64"`
+	Kind   string       `json:"kind"   validate:"required,oneof=flat per_goal country_risk"`
+	Amount domain.Money `json:"amount" validate:"required" swaggertype:"number"`
+	// Currency defaults to USD.
+	Currency string `json:"currency" validate:"omitempty,len=3,alpha"`
+	// RiskLevel is required for country_risk rules and rejected otherwise.
+	RiskLevel *int `json:"risk_level" validate:"omitempty,min=1,max=5"`
+	// Active defaults to true.
+	Active *bool `json:"active"`
+}
+type RuleResponse struct {
+	ID        int64        `json:"id"`
+	Name      string       `json:"name"`
+	Kind      string       `json:"kind"`
+	Amount    domain.Money `json:"amount" swaggertype:"number"`
+	Currency  string       `json:"currency"`
+	RiskLevel *int         `json:"risk_level"`
+	Active    bool         `json:"active"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+type RulesResponse struct {
+	Items []RuleResponse `json:"items"`
+}
+
+type SetRiskLevelRequest struct {
+	Level int `json:"level" validate:"required,min=1,max=5"`
+}
+type RiskLevelResponse struct {
+	Country   string    `json:"country"`
+	Level     int       `json:"level"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+type RiskLevelsResponse struct {
+	Items []RiskLevelResponse `json:"items"`
+}
+
+type BonusResponse struct {
+	ID        int64        `json:"id"`
+	MissionID int64        `json:"mission_id"`
+	RuleID    *int64       `json:"rule_id"`
+	RuleName  string       `json:"rule_name"`
+	Kind      string       `json:"kind"`
+	Quantity  int          `json:"quantity"`
+	Amount    domain.Money `json:"amount" swaggertype:"number"`
+	Currency  string       `json:"currency"`
+	AwardedAt time.Time    `json:"awarded_at"`
+}
+type TotalResponse struct {
+	Amount   domain.Money `json:"amount" swaggertype:"number"`
+	Currency string       `json:"currency"`
+}
+type CatBonusesResponse struct {
+	CatID int64           `json:"cat_id"`
+	Items []BonusResponse `json:"items"`
+	// Totals has one entry per currency.
+	Totals []TotalResponse `json:"totals"`
+}
+
+// mapping
+
+func ToBonusRule(req SaveRuleRequest) domain.BonusRule {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+	amount := req.Amount
+	amount.Currency = req.Currency
+	return domain.BonusRule{
+		Name:      req.Name,
+		Kind:      domain.BonusKind(req.Kind),
+		Amount:    amount,
+		RiskLevel: req.RiskLevel,
+		Active:    active,
+	}
+}
+
+func ToRuleResponse(r domain.BonusRule) RuleResponse {
+	return RuleResponse{
+		ID:        r.ID,
+		Name:      r.Name,
+		Kind:      string(r.Kind),
+		Amount:    r.Amount,
+		Currency:  r.Amount.Currency,
+		RiskLevel: r.RiskLevel,
+		Active:    r.Active,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func ToRulesResponse(items []domain.BonusRule) RulesResponse {
+	out := make([]RuleResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, ToRuleResponse(it))
+	}
+	return RulesResponse{Items: out}
+}
+
+func ToRiskLevelResponse(c domain.CountryRisk) RiskLevelResponse {
+	return RiskLevelResponse{Country: c.Country, Level: c.Level, UpdatedAt: c.UpdatedAt}
+}
+
+func ToRiskLevelsResponse(items []domain.CountryRisk) RiskLevelsResponse {
+	out := make([]RiskLevelResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, ToRiskLevelResponse(it))
+	}
+	return RiskLevelsResponse{Items: out}
+}
+
+func ToCatBonusesResponse(catID int64, items []domain.Bonus) CatBonusesResponse {
+	out := make([]BonusResponse, 0, len(items))
+	for _, b := range items {
+		out = append(out, BonusResponse{
+			ID:        b.ID,
+			MissionID: b.MissionID,
+			RuleID:    b.RuleID,
+			RuleName:  b.RuleName,
+			Kind:      string(b.Kind),
+			Quantity:  b.Quantity,
+			Amount:    b.Amount,
+			Currency:  b.Amount.Currency,
+			AwardedAt: b.AwardedAt,
+		})
+	}
+	totals := make([]TotalResponse, 0)
+	for _, t := range domain.BonusTotals(items) {
+		totals = append(totals, TotalResponse{Amount: t, Currency: t.Currency})
+	}
+	return CatBonusesResponse{CatID: catID, Items: out, Totals: totals}
+}
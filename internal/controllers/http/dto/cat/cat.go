@@ -1,35 +1,74 @@
 package dto
 
-import "github.com/DavydAbbasov/spy-cat/internal/domain"
+import (
+	"errors"
+	"strconv"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+var ErrInvalidSort = errors.New("sort must list up to 4 of id, name, years_experience, breed, salary, created_at, each optionally prefixed with -")
+
+func ToCatSort(s string) ([]domain.SortField, error) {
+	sort, ok := domain.ParseSort(s, domain.CatSortFields)
+	if !ok {
+		return nil, ErrInvalidSort
+	}
+	return sort, nil
+}
 
 type CatResponse struct {
-	ID              int64   `json:"id"`
-	Name            string  `json:"name"`
-	YearsExperience int64   `json:"years_experience"`
-	Breed           string  `json:"breed"`
-	Salary          float64 `json:"salary"`
+	ID              int64        `json:"id"`
+	Name            string       `json:"name"`
+	YearsExperience int64        `json:"years_experience"`
+	Breed           string       `json:"breed"`
+	Salary          domain.Money `json:"salary" swaggertype:"number"`
+	Currency        string       `json:"currency"`
 }
 
 type CreateCatRequest struct {
-	Name            string  `json:"name"              validate:"required,min=2,max=64"`
-	YearsExperience int64   `json:"years_experience"  validate:"gte=0,lte=60"`
-	Breed           string  `json:"breed"             validate:"required,min=2,max=64"`
-	Salary          float64 `json:"salary"            validate:"gte=0,lte=1000000"`
+	Name            string       `json:"name"              validate:"required,min=2,max=64"`
+	YearsExperience int64        `json:"years_experience"  validate:"gte=0,lte=60"`
+	Breed           string       `json:"breed"             validate:"required,min=2,max=64"`
+	Salary          domain.Money `json:"salary" swaggertype:"number"`
+	Currency        string       `json:"currency"          validate:"omitempty,len=3,alpha"`
+	SalaryOverride  bool         `json:"salary_override"`
+	OverrideReason  string       `json:"override_reason"   validate:"omitempty,max=500"`
 }
 
 type CreateCatResponse struct {
 	ID int64 `json:"id"`
 }
 type UpdateSalaryRequest struct {
-	Salary float64 `json:"salary" validate:"required,gte=0,lte=1000000"`
+	Salary        domain.Money `json:"salary"         validate:"required" swaggertype:"number"`
+	Currency      string       `json:"currency"       validate:"omitempty,len=3,alpha"`
+	EffectiveFrom string       `json:"effective_from" validate:"omitempty,datetime=2006-01-02"`
+	Reason        string       `json:"reason"         validate:"omitempty,max=500"`
+	Actor         string       `json:"actor"          validate:"omitempty,max=64"`
+	Override      bool         `json:"override"`
 }
 type GetCatsQuery struct {
 	Name     *string `form:"name"       binding:"omitempty,min=1"`
 	Breed    *string `form:"breed"      binding:"omitempty,min=1"`
 	MinYears *int    `form:"min_years"  binding:"omitempty,min=0"`
 	MaxYears *int    `form:"max_years"  binding:"omitempty,min=0,gtefield=MinYears"`
+	Sort     string  `form:"sort"       binding:"omitempty,max=128"`
 	Limit    int     `form:"limit,default=10"  binding:"omitempty,min=1,max=200"`
 	Offset   int     `form:"offset,default=0"  binding:"omitempty,min=0"`
+	Currency string  `form:"currency"   binding:"omitempty,len=3,alpha"`
+}
+type ExportCatsQuery struct {
+	Name     *string `form:"name"       binding:"omitempty,min=1"`
+	Breed    *string `form:"breed"      binding:"omitempty,min=1"`
+	MinYears *int    `form:"min_years"  binding:"omitempty,min=0"`
+	MaxYears *int    `form:"max_years"  binding:"omitempty,min=0,gtefield=MinYears"`
+	Format   string  `form:"format"     binding:"omitempty,oneof=csv ndjson"`
+	Currency string  `form:"currency"   binding:"omitempty,len=3,alpha"`
+}
+
+// CurrencyQuery converts the amounts of a response for reporting.
+type CurrencyQuery struct {
+	Currency string `form:"currency" binding:"omitempty,len=3,alpha"`
 }
 type GetCatsResponse struct {
 	Items      []CatResponse `json:"items"`
@@ -38,7 +77,6 @@ type GetCatsResponse struct {
 	NextOffset int           `json:"next_offset"`
 }
 
-
 type DeleteCatResponse struct {
 	Deleted bool  `json:"deleted"`
 	ID      int64 `json:"id"`
@@ -46,13 +84,18 @@ type DeleteCatResponse struct {
 
 // mapping
 func ToNewCatDomain(req CreateCatRequest) domain.Cat {
+	salary := req.Salary
+	salary.Currency = req.Currency
 	return domain.Cat{
 		Name:            req.Name,
 		YearsExperience: req.YearsExperience,
 		Breed:           req.Breed,
-		Salary:          req.Salary,
+		Salary:          salary,
 	}
 }
+func ToSalaryOverride(req CreateCatRequest) domain.SalaryOverride {
+	return domain.SalaryOverride{Override: req.SalaryOverride, Reason: req.OverrideReason}
+}
 func ToCatResponse(c domain.Cat) CatResponse {
 	return CatResponse{
 		ID:              c.ID,
@@ -60,6 +103,7 @@ func ToCatResponse(c domain.Cat) CatResponse {
 		YearsExperience: c.YearsExperience,
 		Breed:           c.Breed,
 		Salary:          c.Salary,
+		Currency:        c.Salary.Currency,
 	}
 }
 
@@ -70,3 +114,16 @@ func ToCatResponses(items []domain.Cat) []CatResponse {
 	}
 	return out
 }
+
+var CatExportHeader = []string{"id", "name", "years_experience", "breed", "salary", "currency"}
+
+func ToCatExportRow(c domain.Cat) []string {
+	return []string{
+		strconv.FormatInt(c.ID, 10),
+		c.Name,
+		strconv.FormatInt(c.YearsExperience, 10),
+		c.Breed,
+		c.Salary.String(),
+		c.Salary.Currency,
+	}
+}
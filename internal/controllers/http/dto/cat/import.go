@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+var ErrMissingColumns = errors.New("csv header must contain name, years_experience, breed and salary")
+
+type ImportCatsQuery struct {
+	DryRun bool `form:"dry_run"`
+}
+type ImportCatRow struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	ID     *int64 `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+type ImportCatsResponse struct {
+	DryRun   bool           `json:"dry_run"`
+	Accepted int            `json:"accepted"`
+	Rejected int            `json:"rejected"`
+	Rows     []ImportCatRow `json:"rows"`
+}
+
+// CatCSVRow is one parsed data line; Err is set when the line could not be parsed.
+type CatCSVRow struct {
+	Line int
+	Req  CreateCatRequest
+	Err  error
+}
+
+// ParseCatsCSV reads a CSV with a header row. Columns are matched by name,
+// so their order does not matter and unknown columns are ignored.
+func ParseCatsCSV(r io.Reader, maxRows int) ([]CatCSVRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, name := range []string{"name", "years_experience", "breed", "salary"} {
+		if _, ok := cols[name]; !ok {
+			return nil, ErrMissingColumns
+		}
+	}
+
+	var out []CatCSVRow
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				out = append(out, CatCSVRow{Line: perr.StartLine, Err: err})
+				continue
+			}
+			return nil, err
+		}
+		if len(out) >= maxRows {
+			return nil, fmt.Errorf("too many rows, max %d", maxRows)
+		}
+
+		line, _ := cr.FieldPos(0)
+		out = append(out, parseCatRecord(line, rec, cols))
+	}
+	return out, nil
+}
+
+func parseCatRecord(line int, rec []string, cols map[string]int) CatCSVRow {
+	row := CatCSVRow{Line: line}
+	field := func(name string) string {
+		i := cols[name]
+		if i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	row.Req.Name = field("name")
+	row.Req.Breed = field("breed")
+
+	years, err := strconv.ParseInt(field("years_experience"), 10, 64)
+	if err != nil {
+		row.Err = fmt.Errorf("years_experience must be an integer")
+		return row
+	}
+	row.Req.YearsExperience = years
+
+	salary, ok := domain.ParseMoney(field("salary"))
+	if !ok {
+		row.Err = fmt.Errorf("salary must be a number with at most two decimal places")
+		return row
+	}
+	row.Req.Salary = salary
+	// currency is optional, cats without one are paid in the default currency
+	if _, ok := cols["currency"]; ok {
+		row.Req.Currency = field("currency")
+	}
+
+	return row
+}
@@ -0,0 +1,171 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+const (
+	dateLayout  = "2006-01-02"
+	monthLayout = "2006-01"
+)
+
+type SalaryChangeResponse struct {
+	ID            int64         `json:"id"`
+	OldSalary     *domain.Money `json:"old_salary" swaggertype:"number"`
+	OldCurrency   string        `json:"old_currency,omitempty"`
+	NewSalary     domain.Money  `json:"new_salary" swaggertype:"number"`
+	Currency      string        `json:"currency"`
+	EffectiveFrom string        `json:"effective_from"`
+	Reason        string        `json:"reason,omitempty"`
+	Actor         string        `json:"actor,omitempty"`
+	Overridden    bool          `json:"overridden"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+type SalaryHistoryResponse struct {
+	CatID int64                  `json:"cat_id"`
+	Items []SalaryChangeResponse `json:"items"`
+}
+
+type PayrollQuery struct {
+	Month    string `form:"month"    binding:"required,datetime=2006-01"`
+	Currency string `form:"currency" binding:"omitempty,len=3,alpha"`
+}
+type PayrollLineResponse struct {
+	CatID    int64        `json:"cat_id"`
+	Name     string       `json:"name"`
+	Breed    string       `json:"breed"`
+	DaysPaid int          `json:"days_paid"`
+	Salary   domain.Money `json:"salary" swaggertype:"number"`
+	Bonus    domain.Money `json:"bonus" swaggertype:"number"`
+	// Amount is salary plus bonus.
+	Amount   domain.Money `json:"amount" swaggertype:"number"`
+	Currency string       `json:"currency"`
+}
+type MoneyResponse struct {
+	Amount   domain.Money `json:"amount" swaggertype:"number"`
+	Currency string       `json:"currency"`
+}
+type PayrollResponse struct {
+	Month       string                `json:"month"`
+	DaysInMonth int                   `json:"days_in_month"`
+	Items       []PayrollLineResponse `json:"items"`
+	// Totals has one entry per currency, or one in total when converted.
+	Totals []MoneyResponse `json:"totals"`
+}
+
+// mapping
+
+func ToUpdateSalaryParams(id int64, req UpdateSalaryRequest) domain.UpdateSalaryParams {
+	p := domain.UpdateSalaryParams{
+		ID:       id,
+		Salary:   domain.Money{Amount: req.Salary.Amount, Currency: req.Currency},
+		Reason:   req.Reason,
+		Actor:    req.Actor,
+		Override: req.Override,
+	}
+	// the format is checked by the validator
+	if t, err := time.Parse(dateLayout, req.EffectiveFrom); err == nil {
+		p.EffectiveFrom = &t
+	}
+	return p
+}
+
+func ToSalaryHistoryResponse(catID int64, items []domain.SalaryChange) SalaryHistoryResponse {
+	out := make([]SalaryChangeResponse, 0, len(items))
+	for _, it := range items {
+		r := SalaryChangeResponse{
+			ID:            it.ID,
+			OldSalary:     it.OldSalary,
+			NewSalary:     it.NewSalary,
+			Currency:      it.NewSalary.Currency,
+			EffectiveFrom: it.EffectiveFrom.Format(dateLayout),
+			Reason:        it.Reason,
+			Actor:         it.Actor,
+			Overridden:    it.Overridden,
+			CreatedAt:     it.CreatedAt,
+		}
+		if it.OldSalary != nil {
+			r.OldCurrency = it.OldSalary.Currency
+		}
+		out = append(out, r)
+	}
+	return SalaryHistoryResponse{CatID: catID, Items: out}
+}
+
+func ToPayrollMonth(q PayrollQuery) (time.Time, error) {
+	return time.Parse(monthLayout, q.Month)
+}
+
+func ToPayrollResponse(p domain.Payroll) PayrollResponse {
+	items := make([]PayrollLineResponse, 0, len(p.Lines))
+	for _, l := range p.Lines {
+		items = append(items, PayrollLineResponse{
+			CatID:    l.CatID,
+			Name:     l.Name,
+			Breed:    l.Breed,
+			DaysPaid: l.DaysPaid,
+			Salary:   l.Salary,
+			Bonus:    l.Bonus,
+			Amount:   l.Amount,
+			Currency: l.Amount.Currency,
+		})
+	}
+	totals := make([]MoneyResponse, 0, len(p.Totals))
+	for _, t := range p.Totals {
+		totals = append(totals, MoneyResponse{Amount: t, Currency: t.Currency})
+	}
+	return PayrollResponse{
+		Month:       p.Month.Format(monthLayout),
+		DaysInMonth: p.DaysInMonth,
+		Items:       items,
+		Totals:      totals,
+	}
+}
+
+type OutOfBandResponse struct {
+	CatID           int64        `json:"cat_id"`
+	Name            string       `json:"name"`
+	Breed           string       `json:"breed"`
+	YearsExperience int64        `json:"years_experience"`
+	Salary          domain.Money `json:"salary" swaggertype:"number"`
+	Currency        string       `json:"currency"`
+	BandID          int64        `json:"band_id"`
+	BandMin         domain.Money `json:"band_min" swaggertype:"number"`
+	BandMax         domain.Money `json:"band_max" swaggertype:"number"`
+	BandCurrency    string       `json:"band_currency"`
+	// SalaryInBand is the salary in the band's currency.
+	SalaryInBand domain.Money `json:"salary_in_band" swaggertype:"number"`
+	Position     string       `json:"position" enums:"below,above"`
+	Gap          domain.Money `json:"gap" swaggertype:"number"`
+}
+type OutOfBandListResponse struct {
+	Items []OutOfBandResponse `json:"items"`
+}
+
+func ToOutOfBandResponse(items []domain.OutOfBand) OutOfBandListResponse {
+	out := make([]OutOfBandResponse, 0, len(items))
+	for _, it := range items {
+		position := "above"
+		if it.Below() {
+			position = "below"
+		}
+		out = append(out, OutOfBandResponse{
+			CatID:           it.Cat.ID,
+			Name:            it.Cat.Name,
+			Breed:           it.Cat.Breed,
+			YearsExperience: it.Cat.YearsExperience,
+			Salary:          it.Cat.Salary,
+			Currency:        it.Cat.Salary.Currency,
+			BandID:          it.Band.ID,
+			BandMin:         it.Band.Min,
+			BandMax:         it.Band.Max,
+			BandCurrency:    it.Band.Min.Currency,
+			SalaryInBand:    it.Salary,
+			Position:        position,
+			Gap:             it.Gap(),
+		})
+	}
+	return OutOfBandListResponse{Items: out}
+}
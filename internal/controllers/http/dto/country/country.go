@@ -0,0 +1,36 @@
+package dto
+
+import "github.com/DavydAbbasov/spy-cat/internal/lib/countries"
+
+type GetCountriesQuery struct {
+	Region string `form:"region" binding:"omitempty,oneof=Africa Americas Asia Europe Oceania Antarctica"`
+}
+type CountryResponse struct {
+	Alpha2  string `json:"alpha2"  example:"UA"`
+	Alpha3  string `json:"alpha3"  example:"UKR"`
+	Numeric string `json:"numeric" example:"804"`
+	Name    string `json:"name"    example:"Ukraine"`
+	Region  string `json:"region"  example:"Europe"`
+}
+type CountriesResponse struct {
+	Items []CountryResponse `json:"items"`
+	Total int               `json:"total"`
+}
+
+// mapping
+func ToCountriesResponse(list []countries.Country, region string) CountriesResponse {
+	out := make([]CountryResponse, 0, len(list))
+	for _, c := range list {
+		if region != "" && c.Region != region {
+			continue
+		}
+		out = append(out, CountryResponse{
+			Alpha2:  c.Alpha2,
+			Alpha3:  c.Alpha3,
+			Numeric: c.Numeric,
+			Name:    c.Name,
+			Region:  c.Region,
+		})
+	}
+	return CountriesResponse{Items: out, Total: len(out)}
+}
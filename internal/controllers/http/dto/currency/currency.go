@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+var ErrInvalidRate = errors.New("rate must be a positive decimal with at most 8 decimal places")
+
+type SetRateRequest struct {
+	// Rate is how many units of the target currency one unit of the source buys.
+	Rate json.Number `json:"rate" validate:"required" swaggertype:"number" example:"1.0842"`
+}
+type ExchangeRateResponse struct {
+	From      string      `json:"from"      example:"EUR"`
+	To        string      `json:"to"        example:"USD"`
+	Rate      json.Number `json:"rate"      swaggertype:"number" example:"1.0842"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+type ExchangeRatesResponse struct {
+	Items []ExchangeRateResponse `json:"items"`
+}
+
+// mapping
+
+func ToRate(req SetRateRequest) (*big.Rat, error) {
+	r, ok := domain.ParseRate(req.Rate.String())
+	if !ok {
+		return nil, ErrInvalidRate
+	}
+	return r, nil
+}
+
+func ToExchangeRateResponse(r domain.ExchangeRate) ExchangeRateResponse {
+	return ExchangeRateResponse{
+		From:      r.From,
+		To:        r.To,
+		Rate:      json.Number(domain.FormatRate(r.Rate)),
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func ToExchangeRatesResponse(items []domain.ExchangeRate) ExchangeRatesResponse {
+	out := make([]ExchangeRateResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, ToExchangeRateResponse(it))
+	}
+	return ExchangeRatesResponse{Items: out}
+}
@@ -0,0 +1,55 @@
+package dto
+
+import "github.com/DavydAbbasov/spy-cat/internal/domain"
+
+type CandidatesQuery struct {
+	Limit int `form:"limit,default=10" binding:"min=1,max=100"`
+}
+type ScoreBreakdownResponse struct {
+	Experience     float64 `json:"experience"`
+	BreedTraits    float64 `json:"breedTraits"`
+	Workload       float64 `json:"workload"`
+	CountrySuccess float64 `json:"countrySuccess"`
+	SalaryCost     float64 `json:"salaryCost"`
+}
+type CandidateResponse struct {
+	CatID           int64                  `json:"catId"`
+	Name            string                 `json:"name"`
+	Breed           string                 `json:"breed"`
+	YearsExperience int64                  `json:"yearsExperience"`
+	Salary          domain.Money           `json:"salary" swaggertype:"number"`
+	Currency        string                 `json:"currency"`
+	Busy            bool                   `json:"busy"`
+	SuccessRate     *float64               `json:"successRate,omitempty"`
+	Score           float64                `json:"score"`
+	Breakdown       ScoreBreakdownResponse `json:"breakdown"`
+}
+type CandidatesResponse struct {
+	MissionID int64               `json:"missionId"`
+	Items     []CandidateResponse `json:"items"`
+}
+
+func ToCandidatesResponse(missionID int64, items []domain.Candidate) CandidatesResponse {
+	out := make([]CandidateResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, CandidateResponse{
+			CatID:           it.Cat.ID,
+			Name:            it.Cat.Name,
+			Breed:           it.Cat.Breed,
+			YearsExperience: it.Cat.YearsExperience,
+			Salary:          it.Cat.Salary,
+			Currency:        it.Cat.Salary.Currency,
+			Busy:            it.Busy,
+			SuccessRate:     it.SuccessRate,
+			Score:           it.Score,
+			Breakdown: ScoreBreakdownResponse{
+				Experience:     it.Breakdown.Experience,
+				BreedTraits:    it.Breakdown.BreedTraits,
+				Workload:       it.Breakdown.Workload,
+				CountrySuccess: it.Breakdown.CountrySuccess,
+				SalaryCost:     it.Breakdown.SalaryCost,
+			},
+		})
+	}
+	return CandidatesResponse{MissionID: missionID, Items: out}
+}
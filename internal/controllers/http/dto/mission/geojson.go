@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+var ErrInvalidBBox = errors.New("bbox must be minLon,minLat,maxLon,maxLat in degrees")
+
+// GeoJSON output follows RFC 7946: coordinates are [longitude, latitude].
+type FeatureCollection struct {
+	Type     string    `json:"type" example:"FeatureCollection"`
+	Features []Feature `json:"features"`
+}
+type Feature struct {
+	Type       string           `json:"type" example:"Feature"`
+	ID         int64            `json:"id"`
+	Geometry   PointGeometry    `json:"geometry"`
+	Properties GoalFeatureProps `json:"properties"`
+}
+type PointGeometry struct {
+	Type        string     `json:"type" example:"Point"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+type GoalFeatureProps struct {
+	MissionID int64  `json:"missionId"`
+	Name      string `json:"name"`
+	Country   string `json:"country"`
+	Status    string `json:"status"`
+	Position  int    `json:"position"`
+	RadiusM   *int   `json:"radiusM,omitempty"`
+}
+
+// ToGoalsFeatureCollection renders located goals as points; goals without a
+// location are left out.
+func ToGoalsFeatureCollection(goals []domain.MissionGoal) FeatureCollection {
+	fc := FeatureCollection{Type: "FeatureCollection", Features: make([]Feature, 0, len(goals))}
+	for _, g := range goals {
+		if g.Location == nil {
+			continue
+		}
+		fc.Features = append(fc.Features, Feature{
+			Type: "Feature",
+			ID:   g.ID,
+			Geometry: PointGeometry{
+				Type:        "Point",
+				Coordinates: [2]float64{g.Location.Lon, g.Location.Lat},
+			},
+			Properties: GoalFeatureProps{
+				MissionID: g.MissionID,
+				Name:      g.Name,
+				Country:   g.Country,
+				Status:    string(g.Status),
+				Position:  g.Position,
+				RadiusM:   g.Location.Radius,
+			},
+		})
+	}
+	return fc
+}
@@ -1,55 +1,185 @@
 package dto
 
 import (
+	"errors"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/countries"
 )
 
 type MissionResponse struct {
-	ID          int64          `json:"id"`
-	Title       string         `json:"title"`
-	Description string         `json:"description"`
-	Status      string         `json:"status"`
-	CatID       *int64         `json:"catId,omitempty"`
-	Goals       []GoalResponse `json:"goals,omitempty"`
-	CreatedAt   string         `json:"createdAt"`
-	UpdatedAt   string         `json:"updatedAt"`
+	ID           int64             `json:"id"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	Status       string            `json:"status"`
+	Reason       string            `json:"statusReason,omitempty"`
+	CatID        *int64            `json:"catId,omitempty"`
+	Priority     int               `json:"priority"`
+	DueAt        *string           `json:"dueAt,omitempty"`
+	StartedAt    *string           `json:"startedAt,omitempty"`
+	CompletedAt  *string           `json:"completedAt,omitempty"`
+	ScheduleID   *int64            `json:"scheduleId,omitempty"`
+	ScheduledFor *string           `json:"scheduledFor,omitempty"`
+	Progress     *ProgressResponse `json:"progress,omitempty"`
+	Goals        []GoalResponse    `json:"goals,omitempty"`
+	CreatedAt    string            `json:"createdAt"`
+	UpdatedAt    string            `json:"updatedAt"`
+}
+
+// ProgressResponse counts the goals per state. Percent is the share of goals
+// that are done, failed or skipped.
+type ProgressResponse struct {
+	Percent    int `json:"percent"`
+	Total      int `json:"total"`
+	Todo       int `json:"todo"`
+	InProgress int `json:"inProgress"`
+	Done       int `json:"done"`
+	Failed     int `json:"failed"`
+	Skipped    int `json:"skipped"`
 }
 type GoalResponse struct {
-	ID        int64  `json:"id"`
-	Name      string `json:"name"`
-	Country   string `json:"country"`
-	Notes     string `json:"notes"`
-	Status    string `json:"status"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
+	ID          int64                 `json:"id"`
+	Position    int                   `json:"position"`
+	Name        string                `json:"name"`
+	Country     string                `json:"country"`
+	CountryName string                `json:"countryName,omitempty"`
+	Region      string                `json:"region,omitempty"`
+	Location    *GoalLocationResponse `json:"location,omitempty"`
+	Notes       string                `json:"notes"`
+	Status      string                `json:"status"`
+	DoneByCatID *int64                `json:"doneByCatId,omitempty"`
+	AssigneeID  *int64                `json:"assigneeCatId,omitempty"`
+	StartedAt   *string               `json:"startedAt,omitempty"`
+	FinishedAt  *string               `json:"finishedAt,omitempty"`
+	CreatedAt   string                `json:"createdAt"`
+	UpdatedAt   string                `json:"updatedAt"`
+}
+type UpdateGoalStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=todo in_progress done failed skipped"`
+}
+type ReorderGoalsRequest struct {
+	GoalIDs []int64 `json:"goalIds" validate:"required,min=1,max=1000,dive,gt=0"`
+}
+type MissionGoalsResponse struct {
+	MissionID int64          `json:"missionId"`
+	Items     []GoalResponse `json:"items"`
 }
 type CreateMissionRequest struct {
 	Title       string              `json:"title" validate:"required,min=3,max=128"`
 	Description string              `json:"description"`
-	Status      string              `json:"status" validate:"omitempty,oneof=planned active completed"`
+	Status      string              `json:"status" validate:"omitempty,oneof=planned active paused completed aborted failed"`
+	DueAt       *time.Time          `json:"dueAt"`
+	Priority    *int                `json:"priority" validate:"omitempty,min=1,max=5"`
 	Goals       []CreateGoalRequest `json:"goals" validate:"dive"`
 }
 
 type CreateGoalRequest struct {
-	Name    string `json:"name"    validate:"required,min=2,max=64"`
-	Country string `json:"country" validate:"required,len=2"`
-	Notes   string `json:"notes"   validate:"max=1000"`
+	Name     string               `json:"name"     validate:"required,min=2,max=64"`
+	Country  string               `json:"country"  validate:"required,max=64"`
+	Notes    string               `json:"notes"    validate:"max=1000"`
+	Location *GoalLocationRequest `json:"location"`
+}
+
+// GoalLocationRequest pins a goal to WGS84 coordinates. The tags are duplicated
+// because it is bound both by the handler validator and by gin.
+type GoalLocationRequest struct {
+	Lat     *float64 `json:"lat"     validate:"required,min=-90,max=90"           binding:"required,min=-90,max=90"           example:"50.4501"`
+	Lon     *float64 `json:"lon"     validate:"required,min=-180,max=180"         binding:"required,min=-180,max=180"         example:"30.5234"`
+	RadiusM *int     `json:"radiusM" validate:"omitempty,min=1,max=1000000" binding:"omitempty,min=1,max=1000000" example:"500"`
+}
+type GoalLocationResponse struct {
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	RadiusM *int    `json:"radiusM,omitempty"`
 }
 type AssignMissionRequest struct {
 	CatID *int64 `json:"catId" validate:"omitempty,gt=0"`
+	Note  string `json:"note"  validate:"max=1000"`
+}
+type AddTeamMemberRequest struct {
+	CatID int64  `json:"catId" validate:"required,gt=0"`
+	Role  string `json:"role"  validate:"required,oneof=support lookout"`
+}
+type TeamMemberResponse struct {
+	CatID      int64  `json:"catId"`
+	Role       string `json:"role"`
+	AssignedAt string `json:"assignedAt"`
+}
+type MissionTeamResponse struct {
+	MissionID int64                `json:"missionId"`
+	Members   []TeamMemberResponse `json:"members"`
+}
+type AssignGoalRequest struct {
+	CatID *int64 `json:"catId" validate:"omitempty,gt=0"`
+}
+type CatMissionResponse struct {
+	MissionListItem
+	Role string `json:"role"`
+}
+type CatMissionsResponse struct {
+	CatID int64                `json:"catId"`
+	Items []CatMissionResponse `json:"items"`
+}
+type HandoverResponse struct {
+	ID        int64  `json:"id"`
+	FromCatID *int64 `json:"fromCatId,omitempty"`
+	ToCatID   *int64 `json:"toCatId,omitempty"`
+	Note      string `json:"note"`
+	CreatedAt string `json:"createdAt"`
+}
+type MissionHandoversResponse struct {
+	MissionID int64              `json:"missionId"`
+	Items     []HandoverResponse `json:"items"`
+}
+type CloneMissionRequest struct {
+	Title *string    `json:"title" validate:"omitempty,min=3,max=128"`
+	DueAt *time.Time `json:"dueAt"`
 }
 type CreateMissionResponse struct {
 	ID int64 `json:"id"`
 }
+
+// BulkCreateMissionsRequest is capped by mission_service.MaxBulkMissions.
+type BulkCreateMissionsRequest struct {
+	Missions []CreateMissionRequest `json:"missions" validate:"required,min=1"`
+}
+type BulkItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+type BulkItemResult struct {
+	Index int            `json:"index"`
+	ID    *int64         `json:"id,omitempty"`
+	Error *BulkItemError `json:"error,omitempty"`
+}
+type BulkCreateMissionsResponse struct {
+	Items []BulkItemResult `json:"items"`
+}
+
+// MissionFilterQuery holds the filters shared by the list and export endpoints.
+type MissionFilterQuery struct {
+	Status    *string    `form:"status"    binding:"omitempty,oneof=planned active paused completed aborted failed"`
+	CatID     *int64     `form:"catId"     binding:"omitempty,gt=0"`
+	MemberID  *int64     `form:"memberCatId" binding:"omitempty,gt=0"`
+	Q         *string    `form:"q"         binding:"omitempty,min=1,max=128"`
+	Priority  *int       `form:"priority"  binding:"omitempty,min=1,max=5"`
+	DueBefore *time.Time `form:"dueBefore" time_format:"2006-01-02T15:04:05Z07:00"`
+	DueAfter  *time.Time `form:"dueAfter"  time_format:"2006-01-02T15:04:05Z07:00"`
+	Overdue   bool       `form:"overdue"`
+	BBox      string     `form:"bbox"      binding:"omitempty,max=128"`
+}
 type GetMissionsQuery struct {
-	Status *string `form:"status" binding:"omitempty,oneof=planned active completed"`
-	CatID  *int64  `form:"catId"  binding:"omitempty,gt=0"`
-	Q      *string `form:"q"      binding:"omitempty,min=1,max=128"`
-	Limit  int     `form:"limit,default=10"  binding:"min=1,max=200"`
-	Offset int     `form:"offset,default=0"  binding:"min=0"`
+	MissionFilterQuery
+	Sort   string `form:"sort"   binding:"omitempty,max=128"`
+	Limit  int    `form:"limit,default=10"  binding:"min=1,max=200"`
+	Offset int    `form:"offset,default=0"  binding:"min=0"`
+}
+type ExportMissionsQuery struct {
+	MissionFilterQuery
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
 }
 type GetMissionsResponse struct {
 	Items  []MissionListItem `json:"items"`
@@ -58,19 +188,33 @@ type GetMissionsResponse struct {
 	Total  int               `json:"total"`
 }
 type MissionListItem struct {
-	ID        int64  `json:"id"`
-	Title     string `json:"title"`
-	Status    string `json:"status"`
-	CatID     *int64 `json:"catId,omitempty"`
-	CreatedAt string `json:"createdAt"`
+	ID        int64   `json:"id"`
+	Title     string  `json:"title"`
+	Status    string  `json:"status"`
+	CatID     *int64  `json:"catId,omitempty"`
+	Priority  int     `json:"priority"`
+	DueAt     *string `json:"dueAt,omitempty"`
+	CreatedAt string  `json:"createdAt"`
 }
 type UpdateMissionStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=planned active completed"`
+	Status string `json:"status" binding:"required,oneof=planned active paused completed aborted failed"`
+	Reason string `json:"reason" binding:"max=1000"`
+}
+type TransitionResponse struct {
+	Status         string `json:"status"`
+	RequiresReason bool   `json:"requiresReason"`
+}
+type MissionTransitionsResponse struct {
+	MissionID int64                `json:"missionId"`
+	Status    string               `json:"status"`
+	Terminal  bool                 `json:"terminal"`
+	Allowed   []TransitionResponse `json:"allowed"`
 }
 type AddGoalRequest struct {
-	Name    string `json:"name"    binding:"required,min=2,max=64"`
-	Country string `json:"country" binding:"required,len=2"`
-	Notes   string `json:"notes"   binding:"max=1000"`
+	Name     string               `json:"name"     binding:"required,min=2,max=64"`
+	Country  string               `json:"country"  binding:"required,max=64"`
+	Notes    string               `json:"notes"    binding:"max=1000"`
+	Location *GoalLocationRequest `json:"location"`
 }
 
 // mapping
@@ -84,10 +228,50 @@ func ToCreateMissionParams(req CreateMissionRequest) domain.CreateMissionParams
 		Title:       strings.TrimSpace(req.Title),
 		Description: strings.TrimSpace(req.Description),
 		Status:      domain.MissionStatus(status),
+		DueAt:       req.DueAt,
+		Priority:    req.Priority,
 		Goals:       toCreateGoalParams(req.Goals),
 	}
 }
 
+var ErrInvalidSort = errors.New("sort must list up to 4 of created_at, updated_at, due_at, priority, started_at, completed_at, title, status, each optionally prefixed with -")
+
+func ToMissionSort(s string) ([]domain.SortField, error) {
+	sort, ok := domain.ParseSort(s, domain.MissionSortFields)
+	if !ok {
+		return nil, ErrInvalidSort
+	}
+	return sort, nil
+}
+
+// ToMissionFilter fails only when bbox is not "minLon,minLat,maxLon,maxLat".
+func ToMissionFilter(q MissionFilterQuery) (domain.MissionFilter, error) {
+	var f domain.MissionFilter
+	if q.Status != nil && *q.Status != "" {
+		st := domain.MissionStatus(*q.Status)
+		f.Status = &st
+	}
+	f.CatID = q.CatID
+	f.MemberCatID = q.MemberID
+	if q.Q != nil {
+		if t := strings.TrimSpace(*q.Q); t != "" {
+			f.Q = &t
+		}
+	}
+	f.Priority = q.Priority
+	f.DueBefore = q.DueBefore
+	f.DueAfter = q.DueAfter
+	f.Overdue = q.Overdue
+	if q.BBox != "" {
+		b, ok := domain.ParseBBox(q.BBox)
+		if !ok {
+			return domain.MissionFilter{}, ErrInvalidBBox
+		}
+		f.BBox = &b
+	}
+	return f, nil
+}
+
 func toCreateGoalParams(in []CreateGoalRequest) []domain.CreateGoalParams {
 	if len(in) == 0 {
 		return nil
@@ -107,48 +291,113 @@ func toCreateGoalParams(in []CreateGoalRequest) []domain.CreateGoalParams {
 
 		seen[name] = struct{}{}
 		out = append(out, domain.CreateGoalParams{
-			Name:    name,
-			Country: strings.ToUpper(strings.TrimSpace(g.Country)),
-			Notes:   strings.TrimSpace(g.Notes),
+			Name:     name,
+			Country:  strings.ToUpper(strings.TrimSpace(g.Country)),
+			Notes:    strings.TrimSpace(g.Notes),
+			Location: ToGoalLocation(g.Location),
 		})
 	}
 	return out
 }
+
+func ToGoalLocation(in *GoalLocationRequest) *domain.GoalLocation {
+	if in == nil || in.Lat == nil || in.Lon == nil {
+		return nil
+	}
+	return &domain.GoalLocation{Lat: *in.Lat, Lon: *in.Lon, Radius: in.RadiusM}
+}
+
+func toGoalLocationResponse(l *domain.GoalLocation) *GoalLocationResponse {
+	if l == nil {
+		return nil
+	}
+	return &GoalLocationResponse{Lat: l.Lat, Lon: l.Lon, RadiusM: l.Radius}
+}
 func ToMissionResponse(m domain.Mission, goals []domain.MissionGoal) MissionResponse {
 	resp := MissionResponse{
 		ID:          m.ID,
 		Title:       m.Title,
 		Description: m.Description,
 		Status:      string(m.Status),
+		Reason:      m.StatusReason,
 		CatID:       m.CatID,
+		Priority:    m.Priority,
+		DueAt:       formatTime(m.DueAt),
+		StartedAt:   formatTime(m.StartedAt),
+		CompletedAt: formatTime(m.CompletedAt),
 		CreatedAt:   m.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   m.UpdatedAt.Format(time.RFC3339),
 	}
+	if m.Occurrence != nil {
+		resp.ScheduleID = &m.Occurrence.ScheduleID
+		resp.ScheduledFor = formatTime(&m.Occurrence.At)
+	}
 	resp.Goals = make([]GoalResponse, 0, len(goals))
 	for _, g := range goals {
-		resp.Goals = append(resp.Goals, GoalResponse{
-			ID:        g.ID,
-			Name:      g.Name,
-			Status:    string(g.Status),
-			Country:   g.Country,
-			Notes:     g.Notes,
-			CreatedAt: g.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: g.UpdatedAt.Format(time.RFC3339),
-		})
+		resp.Goals = append(resp.Goals, ToGoalResponse(g))
+	}
+
+	p := domain.NewGoalProgress(goals)
+	resp.Progress = &ProgressResponse{
+		Percent:    p.Percent(),
+		Total:      p.Total,
+		Todo:       p.Todo,
+		InProgress: p.InProgress,
+		Done:       p.Done,
+		Failed:     p.Failed,
+		Skipped:    p.Skipped,
 	}
 	return resp
 }
+func ToGoalResponse(g domain.MissionGoal) GoalResponse {
+	country, _ := countries.Lookup(g.Country)
+	return GoalResponse{
+		ID:          g.ID,
+		Position:    g.Position,
+		Name:        g.Name,
+		Status:      string(g.Status),
+		Country:     g.Country,
+		CountryName: country.Name,
+		Region:      country.Region,
+		Location:    toGoalLocationResponse(g.Location),
+		Notes:       g.Notes,
+		DoneByCatID: g.DoneByCatID,
+		AssigneeID:  g.AssigneeCatID,
+		StartedAt:   formatTime(g.StartedAt),
+		FinishedAt:  formatTime(g.FinishedAt),
+		CreatedAt:   g.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   g.UpdatedAt.Format(time.RFC3339),
+	}
+}
+func ToMissionHandoversResponse(missionID int64, hs []domain.MissionHandover) MissionHandoversResponse {
+	out := make([]HandoverResponse, 0, len(hs))
+	for _, h := range hs {
+		out = append(out, HandoverResponse{
+			ID:        h.ID,
+			FromCatID: h.FromCatID,
+			ToCatID:   h.ToCatID,
+			Note:      h.Note,
+			CreatedAt: h.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return MissionHandoversResponse{MissionID: missionID, Items: out}
+}
+func toMissionListItem(it domain.MissionListItem) MissionListItem {
+	return MissionListItem{
+		ID:        it.ID,
+		Title:     it.Title,
+		Status:    string(it.Status),
+		CatID:     it.CatID,
+		Priority:  it.Priority,
+		DueAt:     formatTime(it.DueAt),
+		CreatedAt: it.CreatedAt.Format(time.RFC3339),
+	}
+}
 func ToGetMissionsResponse(items []domain.MissionListItem, limit, offset, total int) GetMissionsResponse {
 	out := make([]MissionListItem, 0, len(items))
 
 	for _, it := range items {
-		out = append(out, MissionListItem{
-			ID:        it.ID,
-			Title:     it.Title,
-			Status:    string(it.Status),
-			CatID:     it.CatID,
-			CreatedAt: it.CreatedAt.Format(time.RFC3339),
-		})
+		out = append(out, toMissionListItem(it))
 	}
 
 	return GetMissionsResponse{
@@ -158,3 +407,149 @@ func ToGetMissionsResponse(items []domain.MissionListItem, limit, offset, total
 		Total:  total,
 	}
 }
+
+var MissionExportHeader = []string{
+	"id", "title", "description", "status", "cat_id", "priority",
+	"due_at", "started_at", "completed_at", "created_at", "updated_at",
+}
+
+func ToMissionExportRow(m domain.Mission) []string {
+	catID := ""
+	if m.CatID != nil {
+		catID = strconv.FormatInt(*m.CatID, 10)
+	}
+
+	return []string{
+		strconv.FormatInt(m.ID, 10),
+		m.Title,
+		m.Description,
+		string(m.Status),
+		catID,
+		strconv.Itoa(m.Priority),
+		deref(formatTime(m.DueAt)),
+		deref(formatTime(m.StartedAt)),
+		deref(formatTime(m.CompletedAt)),
+		m.CreatedAt.Format(time.RFC3339),
+		m.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToMissionExportRecord is the NDJSON export record. Missions are streamed
+// without their goals, so the record carries no goal progress.
+func ToMissionExportRecord(m domain.Mission) MissionResponse {
+	resp := ToMissionResponse(m, nil)
+	resp.Progress = nil
+	return resp
+}
+
+func ToMissionTransitionsResponse(m domain.Mission, next []domain.MissionStatus) MissionTransitionsResponse {
+	resp := MissionTransitionsResponse{
+		MissionID: m.ID,
+		Status:    string(m.Status),
+		Terminal:  m.Status.IsTerminal(),
+		Allowed:   make([]TransitionResponse, 0, len(next)),
+	}
+	for _, st := range next {
+		resp.Allowed = append(resp.Allowed, TransitionResponse{
+			Status:         string(st),
+			RequiresReason: st.RequiresReason(),
+		})
+	}
+	return resp
+}
+
+func ToTeamMemberResponse(a domain.MissionAssignment) TeamMemberResponse {
+	return TeamMemberResponse{
+		CatID:      a.CatID,
+		Role:       string(a.Role),
+		AssignedAt: a.AssignedAt.Format(time.RFC3339),
+	}
+}
+func ToMissionTeamResponse(missionID int64, team []domain.MissionAssignment) MissionTeamResponse {
+	out := make([]TeamMemberResponse, 0, len(team))
+	for _, a := range team {
+		out = append(out, ToTeamMemberResponse(a))
+	}
+	return MissionTeamResponse{MissionID: missionID, Members: out}
+}
+func ToCatMissionsResponse(catID int64, items []domain.CatMission) CatMissionsResponse {
+	out := make([]CatMissionResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, CatMissionResponse{
+			MissionListItem: toMissionListItem(it.Mission),
+			Role:            string(it.Role),
+		})
+	}
+	return CatMissionsResponse{CatID: catID, Items: out}
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	v := t.Format(time.RFC3339)
+	return &v
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+type AddDependencyRequest struct {
+	DependsOnID int64 `json:"dependsOnId" validate:"required,gt=0"`
+}
+type DependencyResponse struct {
+	MissionID   int64  `json:"missionId"`
+	DependsOnID int64  `json:"dependsOnId"`
+	CreatedAt   string `json:"createdAt"`
+}
+type DependencyNodeResponse struct {
+	ID     int64  `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+// DependencyGraphResponse lists the missions upstream and downstream of MissionID.
+// An edge reads "missionId depends on dependsOnId".
+type DependencyGraphResponse struct {
+	MissionID int64                    `json:"missionId"`
+	BlockedBy []int64                  `json:"blockedBy"`
+	Nodes     []DependencyNodeResponse `json:"nodes"`
+	Edges     []DependencyResponse     `json:"edges"`
+}
+
+func ToDependencyResponse(d domain.MissionDependency) DependencyResponse {
+	return DependencyResponse{
+		MissionID:   d.MissionID,
+		DependsOnID: d.DependsOnID,
+		CreatedAt:   d.CreatedAt.Format(time.RFC3339),
+	}
+}
+func ToDependencyGraphResponse(g domain.DependencyGraph) DependencyGraphResponse {
+	resp := DependencyGraphResponse{
+		MissionID: g.MissionID,
+		BlockedBy: g.Blocking(),
+		Nodes:     make([]DependencyNodeResponse, 0, len(g.Nodes)),
+		Edges:     make([]DependencyResponse, 0, len(g.Edges)),
+	}
+	if resp.BlockedBy == nil {
+		resp.BlockedBy = []int64{}
+	}
+	for _, n := range g.Nodes {
+		resp.Nodes = append(resp.Nodes, DependencyNodeResponse{ID: n.ID, Title: n.Title, Status: string(n.Status)})
+	}
+	for _, e := range g.Edges {
+		resp.Edges = append(resp.Edges, ToDependencyResponse(e))
+	}
+	return resp
+}
+func ToMissionGoalsResponse(missionID int64, goals []domain.MissionGoal) MissionGoalsResponse {
+	out := make([]GoalResponse, 0, len(goals))
+	for _, g := range goals {
+		out = append(out, ToGoalResponse(g))
+	}
+	return MissionGoalsResponse{MissionID: missionID, Items: out}
+}
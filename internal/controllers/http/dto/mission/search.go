@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+type SearchQuery struct {
+	Q      string  `form:"q"      binding:"required,min=1,max=256"`
+	Status *string `form:"status" binding:"omitempty,oneof=planned active paused completed aborted failed"`
+	Limit  int     `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int     `form:"offset,default=0" binding:"min=0"`
+}
+type SearchHitResponse struct {
+	ID             int64   `json:"id"`
+	Title          string  `json:"title"`
+	Status         string  `json:"status"`
+	CatID          *int64  `json:"catId,omitempty"`
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"titleHighlight" example:"Watch the <mark>harbour</mark>"`
+	Snippet        string  `json:"snippet"        example:"… cats near the <mark>harbour</mark> cranes …"`
+	CreatedAt      string  `json:"createdAt"`
+}
+type SearchResponse struct {
+	Query  string              `json:"query"`
+	Items  []SearchHitResponse `json:"items"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+	Total  int                 `json:"total"`
+}
+
+// mapping
+func ToSearchParams(q SearchQuery) domain.SearchParams {
+	p := domain.SearchParams{Q: q.Q, Limit: q.Limit, Offset: q.Offset}
+	if q.Status != nil && *q.Status != "" {
+		st := domain.MissionStatus(*q.Status)
+		p.Status = &st
+	}
+	return p
+}
+func ToSearchResponse(q SearchQuery, hits []domain.SearchHit, total int) SearchResponse {
+	items := make([]SearchHitResponse, 0, len(hits))
+	for _, h := range hits {
+		items = append(items, SearchHitResponse{
+			ID:             h.ID,
+			Title:          h.Title,
+			Status:         string(h.Status),
+			CatID:          h.CatID,
+			Rank:           h.Rank,
+			TitleHighlight: h.TitleHighlight,
+			Snippet:        h.Snippet,
+			CreatedAt:      h.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return SearchResponse{Query: q.Q, Items: items, Limit: q.Limit, Offset: q.Offset, Total: total}
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+type SaveBandRequest struct {
+	MinYears int64 `json:"min_years" validate:"gte=0,lte=60"`
+	// MaxYears is open ended when omitted.
+	MaxYears  *int64       `json:"max_years"  validate:"omitempty,gte=0,lte=60"`
+	Breed     string       `json:"breed"      validate:"omitempty,max=64"`
+	MinSalary domain.Money `json:"min_salary" swaggertype:"number"`
+	MaxSalary domain.Money `json:"max_salary" validate:"required" swaggertype:"number"`
+	Currency  string       `json:"currency"   validate:"omitempty,len=3,alpha"`
+}
+type BandResponse struct {
+	ID        int64        `json:"id"`
+	MinYears  int64        `json:"min_years"`
+	MaxYears  *int64       `json:"max_years"`
+	Breed     string       `json:"breed"`
+	MinSalary domain.Money `json:"min_salary" swaggertype:"number"`
+	MaxSalary domain.Money `json:"max_salary" swaggertype:"number"`
+	Currency  string       `json:"currency"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+type BandsResponse struct {
+	Items []BandResponse `json:"items"`
+}
+
+// mapping
+
+func ToSalaryBand(req SaveBandRequest) domain.SalaryBand {
+	b := domain.SalaryBand{
+		MinYears: req.MinYears,
+		MaxYears: req.MaxYears,
+		Breed:    req.Breed,
+		Min:      req.MinSalary,
+		Max:      req.MaxSalary,
+	}
+	b.Min.Currency, b.Max.Currency = req.Currency, req.Currency
+	return b
+}
+
+func ToBandResponse(b domain.SalaryBand) BandResponse {
+	return BandResponse{
+		ID:        b.ID,
+		MinYears:  b.MinYears,
+		MaxYears:  b.MaxYears,
+		Breed:     b.Breed,
+		MinSalary: b.Min,
+		MaxSalary: b.Max,
+		Currency:  b.Min.Currency,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
+
+func ToBandsResponse(items []domain.SalaryBand) BandsResponse {
+	out := make([]BandResponse, 0, len(items))
+	for _, it := range items {
+		out = append(out, ToBandResponse(it))
+	}
+	return BandsResponse{Items: out}
+}
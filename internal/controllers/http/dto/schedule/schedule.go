@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+// CreateScheduleRequest repeats a template or an existing mission on a cron schedule.
+type CreateScheduleRequest struct {
+	TemplateID *int64            `json:"templateId" validate:"omitempty,gt=0"`
+	MissionID  *int64            `json:"missionId"  validate:"omitempty,gt=0"`
+	Cron       string            `json:"cron"       validate:"required,max=128" example:"0 9 * * 1"`
+	Timezone   string            `json:"timezone"   validate:"omitempty,max=64"  example:"Europe/Kyiv"`
+	LeadTime   string            `json:"leadTime"   validate:"omitempty,max=32"  example:"48h"`
+	Vars       map[string]string `json:"vars"`
+}
+type UpdateScheduleRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+type ScheduleResponse struct {
+	ID         int64             `json:"id"`
+	TemplateID *int64            `json:"templateId,omitempty"`
+	MissionID  *int64            `json:"missionId,omitempty"`
+	Cron       string            `json:"cron"`
+	Timezone   string            `json:"timezone"`
+	LeadTime   string            `json:"leadTime"`
+	Vars       map[string]string `json:"vars,omitempty"`
+	Enabled    bool              `json:"enabled"`
+	NextRunAt  string            `json:"nextRunAt"`
+	LastRunAt  *string           `json:"lastRunAt,omitempty"`
+	CreatedAt  string            `json:"createdAt"`
+	UpdatedAt  string            `json:"updatedAt"`
+}
+type GetSchedulesQuery struct {
+	Limit  int `form:"limit,default=50" binding:"min=1,max=200"`
+	Offset int `form:"offset,default=0" binding:"min=0"`
+}
+type GetSchedulesResponse struct {
+	Items  []ScheduleResponse `json:"items"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+	Total  int                `json:"total"`
+}
+
+// ToCreateScheduleParams converts the request; leadTime uses Go duration syntax.
+func ToCreateScheduleParams(req CreateScheduleRequest) (domain.CreateScheduleParams, error) {
+	p := domain.CreateScheduleParams{
+		TemplateID: req.TemplateID,
+		MissionID:  req.MissionID,
+		Cron:       req.Cron,
+		Timezone:   req.Timezone,
+		Vars:       req.Vars,
+	}
+	if req.LeadTime != "" {
+		d, err := time.ParseDuration(req.LeadTime)
+		if err != nil {
+			return domain.CreateScheduleParams{}, err
+		}
+		p.LeadTime = d
+	}
+	return p, nil
+}
+func ToScheduleResponse(s domain.MissionSchedule) ScheduleResponse {
+	resp := ScheduleResponse{
+		ID:         s.ID,
+		TemplateID: s.TemplateID,
+		MissionID:  s.MissionID,
+		Cron:       s.Cron,
+		Timezone:   s.Timezone,
+		LeadTime:   s.LeadTime.String(),
+		Vars:       s.Vars,
+		Enabled:    s.Enabled,
+		NextRunAt:  s.NextRunAt.Format(time.RFC3339),
+		CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  s.UpdatedAt.Format(time.RFC3339),
+	}
+	if s.LastRunAt != nil {
+		v := s.LastRunAt.Format(time.RFC3339)
+		resp.LastRunAt = &v
+	}
+	return resp
+}
+func ToGetSchedulesResponse(items []domain.MissionSchedule, limit, offset, total int) GetSchedulesResponse {
+	out := make([]ScheduleResponse, 0, len(items))
+	for _, s := range items {
+		out = append(out, ToScheduleResponse(s))
+	}
+	return GetSchedulesResponse{
+		Items:  out,
+		Limit:  limit,
+		Offset: offset,
+		Total:  total,
+	}
+}
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/countries"
+)
+
+type CatPerformanceQuery struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=3650"`
+}
+type LeaderboardQuery struct {
+	Days   int    `form:"days,default=30"  binding:"min=1,max=3650"`
+	Metric string `form:"metric,default=missions" binding:"oneof=missions goals"`
+	Limit  int    `form:"limit,default=10" binding:"min=1,max=100"`
+}
+type CatPerformanceResponse struct {
+	CatID                int64                     `json:"catId"`
+	Name                 string                    `json:"name"`
+	Since                *string                   `json:"since,omitempty"`
+	MissionsCompleted    int                       `json:"missionsCompleted"`
+	MissionsFailed       int                       `json:"missionsFailed"`
+	GoalsDone            int                       `json:"goalsDone"`
+	AvgCompletionSeconds int64                     `json:"avgCompletionSeconds"`
+	Countries            []CountryActivityResponse `json:"countries"`
+}
+type CountryActivityResponse struct {
+	Country   string `json:"country"`
+	Name      string `json:"name,omitempty"`
+	GoalsDone int    `json:"goalsDone"`
+}
+type LeaderboardResponse struct {
+	Days   int                        `json:"days"`
+	Metric string                     `json:"metric"`
+	Items  []LeaderboardEntryResponse `json:"items"`
+}
+type LeaderboardEntryResponse struct {
+	Rank                 int    `json:"rank"`
+	CatID                int64  `json:"catId"`
+	Name                 string `json:"name"`
+	Breed                string `json:"breed"`
+	MissionsCompleted    int    `json:"missionsCompleted"`
+	GoalsDone            int    `json:"goalsDone"`
+	AvgCompletionSeconds int64  `json:"avgCompletionSeconds"`
+}
+
+// mapping
+func Days(n int) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
+func ToCatPerformanceResponse(p domain.CatPerformance) CatPerformanceResponse {
+	resp := CatPerformanceResponse{
+		CatID:                p.CatID,
+		Name:                 p.Name,
+		MissionsCompleted:    p.MissionsCompleted,
+		MissionsFailed:       p.MissionsFailed,
+		GoalsDone:            p.GoalsDone,
+		AvgCompletionSeconds: int64(p.AvgCompletionTime / time.Second),
+		Countries:            make([]CountryActivityResponse, 0, len(p.Countries)),
+	}
+	if p.Since != nil {
+		v := p.Since.Format(time.RFC3339)
+		resp.Since = &v
+	}
+	for _, c := range p.Countries {
+		info, _ := countries.Lookup(c.Country)
+		resp.Countries = append(resp.Countries, CountryActivityResponse{
+			Country:   c.Country,
+			Name:      info.Name,
+			GoalsDone: c.GoalsDone,
+		})
+	}
+	return resp
+}
+func ToLeaderboardResponse(q LeaderboardQuery, entries []domain.LeaderboardEntry) LeaderboardResponse {
+	items := make([]LeaderboardEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, LeaderboardEntryResponse{
+			Rank:                 e.Rank,
+			CatID:                e.CatID,
+			Name:                 e.Name,
+			Breed:                e.Breed,
+			MissionsCompleted:    e.MissionsCompleted,
+			GoalsDone:            e.GoalsDone,
+			AvgCompletionSeconds: int64(e.AvgCompletionTime / time.Second),
+		})
+	}
+	return LeaderboardResponse{Days: q.Days, Metric: q.Metric, Items: items}
+}
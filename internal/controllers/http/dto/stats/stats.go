@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+type StatsResponse struct {
+	Missions                  map[string]int        `json:"missions"`
+	Goals                     map[string]int        `json:"goals"`
+	Cats                      int                   `json:"cats"`
+	CatsWithoutMissions       int                   `json:"catsWithoutMissions"`
+	AvgMissionDurationSeconds int64                 `json:"avgMissionDurationSeconds"`
+	SalaryByBreed             []BreedSalaryResponse `json:"salaryByBreed"`
+	CompletionsPerWeek        []WeekCountResponse   `json:"completionsPerWeek"`
+	GeneratedAt               string                `json:"generatedAt"`
+}
+type BreedSalaryResponse struct {
+	Breed    string       `json:"breed"`
+	Cats     int          `json:"cats"`
+	Total    domain.Money `json:"total" swaggertype:"number"`
+	Currency string       `json:"currency"`
+}
+type WeekCountResponse struct {
+	WeekStart string `json:"weekStart" example:"2026-03-02"`
+	Completed int    `json:"completed"`
+}
+
+// every known status is listed, so clients don't have to treat a missing key as zero
+var (
+	missionStatuses = []domain.MissionStatus{
+		domain.StatusPlanned, domain.StatusActive, domain.StatusPaused,
+		domain.StatusCompleted, domain.StatusAborted, domain.StatusFailed,
+	}
+	goalStatuses = []domain.MissionGoalStatus{
+		domain.GoalTodo, domain.GoalInProgress, domain.GoalDone, domain.GoalFailed, domain.GoalSkipped,
+	}
+)
+
+// mapping
+func ToStatsResponse(s domain.Stats) StatsResponse {
+	resp := StatsResponse{
+		Missions:                  make(map[string]int, len(missionStatuses)),
+		Goals:                     make(map[string]int, len(goalStatuses)),
+		Cats:                      s.Cats,
+		CatsWithoutMissions:       s.IdleCats,
+		AvgMissionDurationSeconds: int64(s.AvgMissionDuration / time.Second),
+		SalaryByBreed:             make([]BreedSalaryResponse, 0, len(s.SalaryByBreed)),
+		CompletionsPerWeek:        make([]WeekCountResponse, 0, len(s.CompletionsPerWeek)),
+		GeneratedAt:               s.GeneratedAt.Format(time.RFC3339),
+	}
+
+	for _, st := range missionStatuses {
+		resp.Missions[string(st)] = s.MissionsByStatus[st]
+	}
+	for _, st := range goalStatuses {
+		resp.Goals[string(st)] = s.GoalsByStatus[st]
+	}
+
+	for _, b := range s.SalaryByBreed {
+		resp.SalaryByBreed = append(resp.SalaryByBreed, BreedSalaryResponse{Breed: b.Breed, Cats: b.Cats, Total: b.Total, Currency: b.Total.Currency})
+	}
+	for _, w := range s.CompletionsPerWeek {
+		resp.CompletionsPerWeek = append(resp.CompletionsPerWeek, WeekCountResponse{
+			WeekStart: w.WeekStart.Format(time.DateOnly),
+			Completed: w.Count,
+		})
+	}
+	return resp
+}
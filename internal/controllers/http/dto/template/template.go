@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+// SaveTemplateRequest creates or replaces a template. Texts may contain
+// {{name}} placeholders that are filled when the template is instantiated.
+type SaveTemplateRequest struct {
+	Name        string                `json:"name"        validate:"required,min=2,max=64"`
+	Title       string                `json:"title"       validate:"required,min=3,max=128"`
+	Description string                `json:"description" validate:"max=2000"`
+	Priority    *int                  `json:"priority"    validate:"omitempty,min=1,max=5"`
+	Goals       []TemplateGoalRequest `json:"goals"       validate:"max=100,dive"`
+}
+type TemplateGoalRequest struct {
+	Name    string `json:"name"    validate:"required,min=2,max=64"`
+	Country string `json:"country" validate:"required,max=64"`
+	Notes   string `json:"notes"   validate:"max=1000"`
+}
+type TemplateGoalResponse struct {
+	Position int    `json:"position"`
+	Name     string `json:"name"`
+	Country  string `json:"country"`
+	Notes    string `json:"notes"`
+}
+type TemplateResponse struct {
+	ID           int64                  `json:"id"`
+	Name         string                 `json:"name"`
+	Title        string                 `json:"title"`
+	Description  string                 `json:"description"`
+	Priority     int                    `json:"priority"`
+	Placeholders []string               `json:"placeholders"`
+	Goals        []TemplateGoalResponse `json:"goals,omitempty"`
+	CreatedAt    string                 `json:"createdAt"`
+	UpdatedAt    string                 `json:"updatedAt"`
+}
+type GetTemplatesQuery struct {
+	Limit  int `form:"limit,default=50" binding:"min=1,max=200"`
+	Offset int `form:"offset,default=0" binding:"min=0"`
+}
+type GetTemplatesResponse struct {
+	Items  []TemplateResponse `json:"items"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+	Total  int                `json:"total"`
+}
+type InstantiateTemplateRequest struct {
+	Vars     map[string]string `json:"vars"`
+	Title    *string           `json:"title"    validate:"omitempty,min=3,max=128"`
+	DueAt    *time.Time        `json:"dueAt"`
+	Priority *int              `json:"priority" validate:"omitempty,min=1,max=5"`
+}
+
+func ToSaveTemplateParams(req SaveTemplateRequest) domain.SaveTemplateParams {
+	p := domain.SaveTemplateParams{
+		Name:        strings.TrimSpace(req.Name),
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		Priority:    req.Priority,
+		Goals:       make([]domain.CreateGoalParams, 0, len(req.Goals)),
+	}
+	for _, g := range req.Goals {
+		p.Goals = append(p.Goals, domain.CreateGoalParams{
+			Name:    strings.TrimSpace(g.Name),
+			Country: strings.TrimSpace(g.Country),
+			Notes:   strings.TrimSpace(g.Notes),
+		})
+	}
+	return p
+}
+func ToInstantiateTemplateParams(id int64, req InstantiateTemplateRequest) domain.InstantiateTemplateParams {
+	return domain.InstantiateTemplateParams{
+		TemplateID: id,
+		Vars:       req.Vars,
+		Title:      req.Title,
+		DueAt:      req.DueAt,
+		Priority:   req.Priority,
+	}
+}
+func ToTemplateResponse(t domain.MissionTemplate) TemplateResponse {
+	resp := TemplateResponse{
+		ID:           t.ID,
+		Name:         t.Name,
+		Title:        t.Title,
+		Description:  t.Description,
+		Priority:     t.Priority,
+		Placeholders: t.Placeholders(),
+		CreatedAt:    t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    t.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, g := range t.Goals {
+		resp.Goals = append(resp.Goals, TemplateGoalResponse{
+			Position: g.Position,
+			Name:     g.Name,
+			Country:  g.Country,
+			Notes:    g.Notes,
+		})
+	}
+	return resp
+}
+func ToGetTemplatesResponse(items []domain.MissionTemplate, limit, offset, total int) GetTemplatesResponse {
+	out := make([]TemplateResponse, 0, len(items))
+	for _, t := range items {
+		out = append(out, ToTemplateResponse(t))
+	}
+	return GetTemplatesResponse{
+		Items:  out,
+		Limit:  limit,
+		Offset: offset,
+		Total:  total,
+	}
+}
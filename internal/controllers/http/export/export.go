@@ -0,0 +1,106 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// flushEvery controls how often buffered rows are pushed to the client.
+const flushEvery = 100
+
+// NegotiateFormat picks the export format from the format= query parameter,
+// falling back to the Accept header and then to CSV.
+func NegotiateFormat(c *gin.Context) (Format, error) {
+	if f := strings.ToLower(strings.TrimSpace(c.Query("format"))); f != "" {
+		switch Format(f) {
+		case FormatCSV, FormatNDJSON:
+			return Format(f), nil
+		default:
+			return "", ErrUnknownFormat
+		}
+	}
+
+	accept := c.GetHeader("Accept")
+	switch {
+	case strings.Contains(accept, "application/x-ndjson"), strings.Contains(accept, "application/ndjson"):
+		return FormatNDJSON, nil
+	default:
+		return FormatCSV, nil
+	}
+}
+
+// Writer streams rows to the response in the negotiated format.
+type Writer struct {
+	c      *gin.Context
+	format Format
+	csv    *csv.Writer
+	json   *json.Encoder
+	rows   int
+}
+
+// NewWriter sets the response headers and, for CSV, writes the header row.
+func NewWriter(c *gin.Context, format Format, name string, header []string) (*Writer, error) {
+	w := &Writer{c: c, format: format}
+
+	switch format {
+	case FormatNDJSON:
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".ndjson"))
+		w.json = json.NewEncoder(c.Writer)
+	case FormatCSV:
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".csv"))
+		w.csv = csv.NewWriter(c.Writer)
+		if err := w.csv.Write(header); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	return w, nil
+}
+
+// Write emits one row: record is used for CSV, v is encoded for NDJSON.
+func (w *Writer) Write(record []string, v any) error {
+	var err error
+	if w.format == FormatNDJSON {
+		err = w.json.Encode(v)
+	} else {
+		err = w.csv.Write(record)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%flushEvery == 0 {
+		return w.Flush()
+	}
+	return nil
+}
+
+func (w *Writer) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	w.c.Writer.Flush()
+	return nil
+}
@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	dto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/bonus"
+	httperror "github.com/DavydAbbasov/spy-cat/internal/controllers/http/helpers"
+	"github.com/DavydAbbasov/spy-cat/internal/controllers/http/validator"
+	bonusservice "github.com/DavydAbbasov/spy-cat/internal/service/bonus_service"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/rs/zerolog/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BonusHandler struct {
+	svc       bonusservice.BonusService
+	validator *validator.Validator
+}
+
+func NewBonusHandler(svc bonusservice.BonusService, v *validator.Validator) *BonusHandler {
+	return &BonusHandler{
+		svc:       svc,
+		validator: v,
+	}
+}
+
+// @Summary Create a bonus rule
+// @Tags bonus-rules
+// @Description When a mission completes, every active rule awards a bonus to the assigned cat (the lead;
+// @Description other team members earn no bonuses):
+// @Description flat pays amount once, per_goal pays amount per done goal and country_risk pays
+// @Description amount per done goal in a country of risk_level.
+// @Accept json
+// @Produce json
+// @Param SaveRuleRequest body dto.SaveRuleRequest true "Rule"
+// @Success 201 {object} dto.RuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /bonus-rules [post]
+func (h *BonusHandler) CreateRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, ok := h.decodeRule(c)
+		if !ok {
+			return
+		}
+
+		r, err := h.svc.CreateRule(c.Request.Context(), dto.ToBonusRule(*req))
+		if err != nil {
+			respondBonusError(c, err, "failed to create bonus rule")
+			return
+		}
+
+		c.Header("Location", fmt.Sprintf("/bonus-rules/%d", r.ID))
+		c.JSON(http.StatusCreated, dto.ToRuleResponse(r))
+	}
+}
+
+// @Summary List bonus rules
+// @Tags bonus-rules
+// @Produce json
+// @Success 200 {object} dto.RulesResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /bonus-rules [get]
+func (h *BonusHandler) GetRules() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, err := h.svc.ListRules(c.Request.Context())
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list bonus rules")
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToRulesResponse(items))
+	}
+}
+
+// @Summary Get a bonus rule
+// @Tags bonus-rules
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Success 200 {object} dto.RuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /bonus-rules/{id} [get]
+func (h *BonusHandler) GetRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseRuleID(c)
+		if !ok {
+			return
+		}
+
+		r, err := h.svc.GetRule(c.Request.Context(), id)
+		if err != nil {
+			respondBonusError(c, err, "failed to get bonus rule")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToRuleResponse(r))
+	}
+}
+
+// @Summary Replace a bonus rule
+// @Tags bonus-rules
+// @Description Bonuses already awarded keep their amounts.
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param SaveRuleRequest body dto.SaveRuleRequest true "Rule"
+// @Success 200 {object} dto.RuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /bonus-rules/{id} [put]
+func (h *BonusHandler) UpdateRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseRuleID(c)
+		if !ok {
+			return
+		}
+		req, ok := h.decodeRule(c)
+		if !ok {
+			return
+		}
+
+		r, err := h.svc.UpdateRule(c.Request.Context(), id, dto.ToBonusRule(*req))
+		if err != nil {
+			respondBonusError(c, err, "failed to update bonus rule")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToRuleResponse(r))
+	}
+}
+
+// @Summary Delete a bonus rule
+// @Tags bonus-rules
+// @Description Bonuses already awarded are kept.
+// @Param id path int true "Rule ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /bonus-rules/{id} [delete]
+func (h *BonusHandler) DeleteRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseRuleID(c)
+		if !ok {
+			return
+		}
+
+		if err := h.svc.DeleteRule(c.Request.Context(), id); err != nil {
+			respondBonusError(c, err, "failed to delete bonus rule")
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// @Summary List country risk levels
+// @Tags admin
+// @Description Countries without a level earn no country_risk bonuses.
+// @Produce json
+// @Success 200 {object} dto.RiskLevelsResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/country-risk-levels [get]
+func (h *BonusHandler) GetRiskLevels() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, err := h.svc.ListRiskLevels(c.Request.Context())
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list country risk levels")
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToRiskLevelsResponse(items))
+	}
+}
+
+// @Summary Set country risk level
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param country path string true "ISO 3166-1 alpha-2, alpha-3 code or English name"
+// @Param body body dto.SetRiskLevelRequest true "Risk level"
+// @Success 200 {object} dto.RiskLevelResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/country-risk-levels/{country} [put]
+func (h *BonusHandler) SetRiskLevel() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, err := validator.DecodeJSON[dto.SetRiskLevelRequest](h.validator, c.Request)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+			return
+		}
+
+		out, err := h.svc.SetRiskLevel(c.Request.Context(), c.Param("country"), req.Level)
+		if err != nil {
+			respondBonusError(c, err, "failed to set country risk level")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToRiskLevelResponse(out))
+	}
+}
+
+// @Summary Delete country risk level
+// @Tags admin
+// @Param country path string true "ISO 3166-1 alpha-2, alpha-3 code or English name"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/country-risk-levels/{country} [delete]
+func (h *BonusHandler) DeleteRiskLevel() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := h.svc.DeleteRiskLevel(c.Request.Context(), c.Param("country")); err != nil {
+			respondBonusError(c, err, "failed to delete country risk level")
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// @Summary Cat bonuses
+// @Tags cats
+// @Description Bonuses awarded to the cat for completed missions it led, newest first, with totals per currency.
+// @Produce json
+// @Param id path int true "Cat ID"
+// @Success 200 {object} dto.CatBonusesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /cats/{id}/bonuses [get]
+func (h *BonusHandler) GetCatBonuses() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "cat id must be positive integer")
+			return
+		}
+
+		items, err := h.svc.CatBonuses(c.Request.Context(), id)
+		if err != nil {
+			respondBonusError(c, err, "failed to list cat bonuses")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToCatBonusesResponse(id, items))
+	}
+}
+
+func (h *BonusHandler) decodeRule(c *gin.Context) (*dto.SaveRuleRequest, bool) {
+	req, err := validator.DecodeJSON[dto.SaveRuleRequest](h.validator, c.Request)
+	if err != nil {
+		if errors.Is(err, validator.ErrHandlerValidationFailed) {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+			return nil, false
+		}
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+		return nil, false
+	}
+	return req, true
+}
+
+func parseRuleID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "rule id must be positive integer")
+		return 0, false
+	}
+	return id, true
+}
+
+func respondBonusError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, serviceerrors.ErrBonusRuleNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "not_found", "bonus rule not found")
+	case errors.Is(err, serviceerrors.ErrCountryRiskNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "not_found", "country risk level not found")
+	case errors.Is(err, serviceerrors.ErrCatNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "not_found", "cat not found")
+	case errors.Is(err, serviceerrors.ErrInvalidBonusRule):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_rule", "amount must be between 0 and 1000000; risk_level is required for country_risk rules only")
+	case errors.Is(err, serviceerrors.ErrInvalidRiskLevel):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_risk_level", err.Error())
+	case errors.Is(err, serviceerrors.ErrInvalidCountry):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_country", "country must be an ISO 3166-1 alpha-2, alpha-3 code or English name")
+	case errors.Is(err, serviceerrors.ErrInvalidCurrency):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_currency", err.Error())
+	default:
+		log.Error().Err(err).Msg(msg)
+		httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+	}
+}
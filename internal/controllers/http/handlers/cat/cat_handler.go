@@ -3,14 +3,18 @@ package handler
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/DavydAbbasov/spy-cat/internal/domain"
 	serviceserrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
 
 	dto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/cat"
+	"github.com/DavydAbbasov/spy-cat/internal/controllers/http/export"
 	httperror "github.com/DavydAbbasov/spy-cat/internal/controllers/http/helpers"
 	catservice "github.com/DavydAbbasov/spy-cat/internal/service/cat_service"
 
@@ -56,12 +60,27 @@ func (h *CatHandler) CreateCat() gin.HandlerFunc {
 
 		cat := dto.ToNewCatDomain(*req)
 
-		id, err := h.svc.CreateCat(ctx, &cat)
+		id, err := h.svc.CreateCat(ctx, &cat, dto.ToSalaryOverride(*req))
 		if err != nil {
 			switch {
 			case errors.Is(err, serviceserrors.ErrBreedInvalid):
 				httperror.RespondError(c, http.StatusBadRequest, "invalid_breed", "breed is not allowed")
 				return
+			case errors.Is(err, serviceserrors.ErrInvalidSalary):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_salary", "salary must be between 0 and 1000000 USD or the equivalent")
+				return
+			case errors.Is(err, serviceserrors.ErrInvalidCurrency):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_currency", err.Error())
+				return
+			case errors.Is(err, serviceserrors.ErrSalaryOutOfBand):
+				httperror.RespondError(c, http.StatusBadRequest, "salary_out_of_band", err.Error())
+				return
+			case errors.Is(err, serviceserrors.ErrOverrideReasonRequired):
+				httperror.RespondError(c, http.StatusBadRequest, "override_reason_required", "override_reason is required to override the salary band")
+				return
+			case errors.Is(err, serviceserrors.ErrExchangeRateNotFound):
+				httperror.RespondError(c, http.StatusBadRequest, "unknown_exchange_rate", err.Error())
+				return
 			case errors.Is(err, serviceserrors.ErrExternalService):
 				httperror.RespondError(c, http.StatusBadGateway, "external_unavailable", "breed validation service unavailable")
 				return
@@ -75,11 +94,129 @@ func (h *CatHandler) CreateCat() gin.HandlerFunc {
 	}
 }
 
+const (
+	maxImportBytes = 5 << 20
+	maxImportRows  = 5000
+)
+
+// Import spy cats
+// @Summary      Import spy cats from CSV
+// @Description  Accepts a CSV upload (multipart field "file" or a text/csv body) with the columns
+// @Description  name, years_experience, breed and salary. Every row is validated and the accepted
+// @Description  ones are stored; dry_run only validates. Returns a per-row report.
+// @Tags         cats
+// @Accept       multipart/form-data
+// @Accept       text/csv
+// @Produce      json
+// @Param        file     formData file false "CSV file"
+// @Param        dry_run  query    bool false "Validate only, do not store"
+// @Success      200 {object} dto.ImportCatsResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      502 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /cats/import [post]
+func (h *CatHandler) ImportCats() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var q dto.ImportCatsQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+
+		var body io.Reader = c.Request.Body
+		if strings.HasPrefix(c.ContentType(), "multipart/") {
+			fh, err := c.FormFile("file")
+			if err != nil {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_file", "multipart field \"file\" is required")
+				return
+			}
+			f, err := fh.Open()
+			if err != nil {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_file", "cannot read uploaded file")
+				return
+			}
+			defer f.Close()
+			body = f
+		}
+
+		parsed, err := dto.ParseCatsCSV(body, maxImportRows)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_csv", err.Error())
+			return
+		}
+
+		report := make([]dto.ImportCatRow, len(parsed))
+		rows := make([]domain.CatImportRow, 0, len(parsed))
+		pos := make([]int, 0, len(parsed))
+
+		for i, p := range parsed {
+			report[i] = dto.ImportCatRow{Line: p.Line, Name: p.Req.Name, Status: "rejected"}
+			if p.Err != nil {
+				report[i].Error = p.Err.Error()
+				continue
+			}
+			if err := h.validator.Validate(p.Req); err != nil {
+				report[i].Error = err.Error()
+				continue
+			}
+			rows = append(rows, domain.CatImportRow{Line: p.Line, Cat: dto.ToNewCatDomain(p.Req)})
+			pos = append(pos, i)
+		}
+
+		results, err := h.svc.ImportCats(ctx, rows, q.DryRun)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceserrors.ErrExternalService):
+				httperror.RespondError(c, http.StatusBadGateway, "external_unavailable", "breed validation service unavailable")
+			default:
+				log.Error().Err(err).Msg("import cats failed")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		for j, r := range results {
+			row := &report[pos[j]]
+			switch {
+			case errors.Is(r.Err, serviceserrors.ErrBreedInvalid):
+				row.Error = "breed is not allowed"
+			case errors.Is(r.Err, serviceserrors.ErrInvalidName):
+				row.Error = "name is required"
+			case errors.Is(r.Err, serviceserrors.ErrInvalidSalary):
+				row.Error = "salary must be between 0 and 1000000 USD or the equivalent"
+			case r.Err != nil:
+				row.Error = r.Err.Error()
+			default:
+				row.Status = "accepted"
+				if !q.DryRun {
+					id := r.ID
+					row.ID = &id
+				}
+			}
+		}
+
+		resp := dto.ImportCatsResponse{DryRun: q.DryRun, Rows: report}
+		for _, r := range report {
+			if r.Status == "accepted" {
+				resp.Accepted++
+			} else {
+				resp.Rejected++
+			}
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
 // Get a single spy cat
 // @Summary      Get a single spy cat
 // @Description  The ability to receive information about a single cat
 // @Tags         cats
 // @Param        id   path int true "ID Cat"
+// @Param        currency query string false "Convert the salary to this ISO 4217 currency"
 // @Produce      json
 // @Success      200 {object} dto.CatResponse
 // @Failure      400 {object} dto.ErrorResponse
@@ -101,11 +238,21 @@ func (h *CatHandler) GetCat() gin.HandlerFunc {
 			return
 		}
 
-		cat, err := h.svc.GetCat(ctx, id)
+		var q dto.CurrencyQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		cat, err := h.svc.GetCat(ctx, id, q.Currency)
 		if err != nil {
 			switch {
 			case errors.Is(err, serviceserrors.ErrCatNotFound):
 				httperror.RespondError(c, http.StatusNotFound, "not_found", "cat not found")
+			case errors.Is(err, serviceserrors.ErrInvalidCurrency):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_currency", err.Error())
+			case errors.Is(err, serviceserrors.ErrExchangeRateNotFound):
+				httperror.RespondError(c, http.StatusBadRequest, "unknown_exchange_rate", err.Error())
 			default:
 				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
 			}
@@ -126,6 +273,8 @@ func (h *CatHandler) GetCat() gin.HandlerFunc {
 // @Param        breed      query string false "Filter by breed"
 // @Param        min_years  query int    false "Min experience"
 // @Param        max_years  query int    false "Max experience"
+// @Param        sort       query string false "comma separated keys from id|name|years_experience|breed|salary|created_at, prefix with - for descending, e.g. -salary,name; salary sorts by currency, then amount"
+// @Param        currency   query string false "Convert salaries to this ISO 4217 currency"
 // @Produce      json
 // @Success      200 {object} dto.GetCatsResponse
 // @Failure      400 {object} dto.ErrorResponse
@@ -146,18 +295,33 @@ func (h *CatHandler) GetCats() gin.HandlerFunc {
 			return
 		}
 
+		sort, err := dto.ToCatSort(q.Sort)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_sort", err.Error())
+			return
+		}
+
 		params := domain.ListCatsParams{
 			Name:     q.Name,
 			Breed:    q.Breed,
 			MinYears: q.MinYears,
 			MaxYears: q.MaxYears,
+			Sort:     sort,
 			Limit:    q.Limit,
 			Offset:   q.Offset,
+			Currency: q.Currency,
 		}
 
 		items, err := h.svc.ListCats(ctx, params)
 		if err != nil {
-			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			switch {
+			case errors.Is(err, serviceserrors.ErrInvalidCurrency):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_currency", err.Error())
+			case errors.Is(err, serviceserrors.ErrExchangeRateNotFound):
+				httperror.RespondError(c, http.StatusBadRequest, "unknown_exchange_rate", err.Error())
+			default:
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
 			return
 		}
 
@@ -175,15 +339,75 @@ func (h *CatHandler) GetCats() gin.HandlerFunc {
 	}
 }
 
+// Export spy cats
+// @Summary      Export spy cats
+// @Description  Streams cats as CSV or NDJSON, chosen by the format parameter or the Accept header
+// @Tags         cats
+// @Param        name       query string false "Filter by name"
+// @Param        breed      query string false "Filter by breed"
+// @Param        min_years  query int    false "Min experience"
+// @Param        max_years  query int    false "Max experience"
+// @Param        format     query string false "csv|ndjson"
+// @Param        currency   query string false "Convert salaries to this ISO 4217 currency"
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Success      200 {string} string "exported rows"
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /cats/export [get]
+func (h *CatHandler) ExportCats() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var q dto.ExportCatsQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		format, err := export.NegotiateFormat(c)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_format", "format must be csv or ndjson")
+			return
+		}
+
+		params := domain.ListCatsParams{
+			Name:     q.Name,
+			Breed:    q.Breed,
+			MinYears: q.MinYears,
+			MaxYears: q.MaxYears,
+			Currency: q.Currency,
+		}
+
+		w, err := export.NewWriter(c, format, "cats", dto.CatExportHeader)
+		if err != nil {
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		err = h.svc.ExportCats(ctx, params, func(cat domain.Cat) error {
+			return w.Write(dto.ToCatExportRow(cat), dto.ToCatResponse(cat))
+		})
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			// headers are already sent, the client sees a truncated body
+			log.Error().Err(err).Msg("export cats failed")
+		}
+	}
+}
+
 // DeleteCat godoc
 // @Summary      Delete cat
-// @Description  Deletes a cat by id
+// @Description  Soft deletes a cat by id. A cat on an active mission cannot be deleted.
 // @Tags         cats
 // @Produce      json
 // @Param        id   path      int  true  "Cat ID"
 // @Success      200  {object}  dto.DeleteCatResponse "OK"
 // @Failure      400  {object}  dto.ErrorResponse
 // @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
 // @Failure      500  {object}  dto.ErrorResponse
 // @Router       /cats/{id} [delete]
 func (h *CatHandler) DeleteCat() gin.HandlerFunc {
@@ -206,6 +430,8 @@ func (h *CatHandler) DeleteCat() gin.HandlerFunc {
 			switch {
 			case errors.Is(err, serviceserrors.ErrCatNotFound):
 				httperror.RespondError(c, http.StatusNotFound, "not found", "cat not found")
+			case errors.Is(err, serviceserrors.ErrCatHasActiveMission):
+				httperror.RespondError(c, http.StatusConflict, "cat_on_mission", "cat has an active mission")
 			default:
 				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
 
@@ -217,9 +443,49 @@ func (h *CatHandler) DeleteCat() gin.HandlerFunc {
 
 }
 
+// RestoreCat godoc
+// @Summary      Restore cat
+// @Description  Restores a soft deleted cat. A cat already anonymised by the purge job can no longer be restored and returns 404
+// @Tags         cats
+// @Produce      json
+// @Param        id   path      int  true  "Cat ID"
+// @Success      200  {object}  dto.CatResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /cats/{id}/restore [post]
+func (h *CatHandler) RestoreCat() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_path", "id must be a positive integer")
+			return
+		}
+
+		cat, err := h.svc.RestoreCat(ctx, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceserrors.ErrCatNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "cat not found")
+			case errors.Is(err, serviceserrors.ErrCatNotDeleted):
+				httperror.RespondError(c, http.StatusConflict, "not_deleted", "cat is not deleted")
+			default:
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+		c.JSON(http.StatusOK, dto.ToCatResponse(cat))
+	}
+}
+
 // Update salary
 // @Summary      Update cat salary
-// @Description  Updates salary for a specific cat
+// @Description  Updates salary for a specific cat and records the change in the salary ledger.
+// @Description  effective_from (YYYY-MM-DD) defaults to today; it may be backdated but not before the latest change.
+// @Description  currency switches the currency the cat is paid in; it defaults to the current one.
 // @Tags         cats
 // @Accept       json
 // @Produce      json
@@ -250,17 +516,24 @@ func (h *CatHandler) UpdateSalary() gin.HandlerFunc {
 			return
 		}
 
-		cat, err := h.svc.UpdateSalary(ctx, domain.UpdateSalaryParams{
-			ID:     id,
-			Salary: req.Salary,
-		})
+		cat, err := h.svc.UpdateSalary(ctx, dto.ToUpdateSalaryParams(id, *req))
 
 		if err != nil {
 			switch {
 			case errors.Is(err, serviceserrors.ErrCatNotFound):
 				httperror.RespondError(c, http.StatusNotFound, "not_found", "cat not found")
 			case errors.Is(err, serviceserrors.ErrInvalidSalary):
-				httperror.RespondError(c, http.StatusBadRequest, "invalid_salary", "salary must be >= 0")
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_salary", "salary must be between 0 and 1000000 USD or the equivalent")
+			case errors.Is(err, serviceserrors.ErrInvalidEffectiveDate):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_effective_date", err.Error())
+			case errors.Is(err, serviceserrors.ErrInvalidCurrency):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_currency", err.Error())
+			case errors.Is(err, serviceserrors.ErrSalaryOutOfBand):
+				httperror.RespondError(c, http.StatusBadRequest, "salary_out_of_band", err.Error())
+			case errors.Is(err, serviceserrors.ErrOverrideReasonRequired):
+				httperror.RespondError(c, http.StatusBadRequest, "override_reason_required", "reason is required to override the salary band")
+			case errors.Is(err, serviceserrors.ErrExchangeRateNotFound):
+				httperror.RespondError(c, http.StatusBadRequest, "unknown_exchange_rate", err.Error())
 			default:
 				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
 			}
@@ -270,3 +543,110 @@ func (h *CatHandler) UpdateSalary() gin.HandlerFunc {
 		c.JSON(http.StatusOK, dto.ToCatResponse(cat))
 	}
 }
+
+// Salary history
+// @Summary      Get cat salary history
+// @Description  Returns the salary ledger of a cat, newest change first
+// @Tags         cats
+// @Produce      json
+// @Param        id   path  int true "Cat ID"
+// @Success      200  {object} dto.SalaryHistoryResponse
+// @Failure      400  {object} dto.ErrorResponse
+// @Failure      404  {object} dto.ErrorResponse
+// @Failure      500  {object} dto.ErrorResponse
+// @Router       /cats/{id}/salary-history [get]
+func (h *CatHandler) GetSalaryHistory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_path", "id must be a positive integer")
+			return
+		}
+
+		items, err := h.svc.SalaryHistory(ctx, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceserrors.ErrCatNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "cat not found")
+			default:
+				log.Error().Err(err).Int64("cat_id", id).Msg("salary history failed")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToSalaryHistoryResponse(id, items))
+	}
+}
+
+// Payroll
+// @Summary      Monthly payroll report
+// @Description  Returns what every cat earned in a month. Salary changes inside the month are prorated by day;
+// @Description  mission bonuses are paid in the month they were awarded.
+// @Tags         cats
+// @Produce      json
+// @Param        month query string true "Month, YYYY-MM"
+// @Param        currency query string false "Convert every amount to this ISO 4217 currency"
+// @Success      200  {object} dto.PayrollResponse
+// @Failure      400  {object} dto.ErrorResponse
+// @Failure      500  {object} dto.ErrorResponse
+// @Router       /payroll [get]
+func (h *CatHandler) GetPayroll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var q dto.PayrollQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+		month, err := dto.ToPayrollMonth(q)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", "month must be YYYY-MM")
+			return
+		}
+
+		p, err := h.svc.Payroll(ctx, month, q.Currency)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceserrors.ErrInvalidMonth):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_month", "month must not be in the future")
+			case errors.Is(err, serviceserrors.ErrInvalidCurrency):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_currency", err.Error())
+			case errors.Is(err, serviceserrors.ErrExchangeRateNotFound):
+				httperror.RespondError(c, http.StatusBadRequest, "unknown_exchange_rate", err.Error())
+			default:
+				log.Error().Err(err).Str("month", q.Month).Msg("payroll failed")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToPayrollResponse(p))
+	}
+}
+
+// Out of band
+// @Summary      Cats outside their salary band
+// @Description  Lists cats whose current salary is outside the band for their experience and breed,
+// @Description  including salaries accepted with an override. Cats without a band are not listed,
+// @Description  nor are cats paid in a currency without an exchange rate to their band's currency.
+// @Tags         cats
+// @Produce      json
+// @Success      200  {object} dto.OutOfBandListResponse
+// @Failure      500  {object} dto.ErrorResponse
+// @Router       /cats/out-of-band [get]
+func (h *CatHandler) GetOutOfBand() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, err := h.svc.OutOfBandCats(c.Request.Context())
+		if err != nil {
+			log.Error().Err(err).Msg("out of band report failed")
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToOutOfBandResponse(items))
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	dto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/country"
+	httperror "github.com/DavydAbbasov/spy-cat/internal/controllers/http/helpers"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/countries"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CountryHandler struct{}
+
+func NewCountryHandler() *CountryHandler {
+	return &CountryHandler{}
+}
+
+// @Summary List ISO 3166-1 countries
+// @Tags countries
+// @Description Countries accepted as goal targets. Goals may reference a country by
+// @Description alpha-2, alpha-3 or English name; it is stored as alpha-2.
+// @Produce json
+// @Param region query string false "Africa | Americas | Asia | Europe | Oceania | Antarctica"
+// @Success 200 {object} dto.CountriesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /countries [get]
+func (h *CountryHandler) GetCountries() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var q dto.GetCountriesQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToCountriesResponse(countries.All(), q.Region))
+	}
+}
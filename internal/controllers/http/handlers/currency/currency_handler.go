@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	dto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/currency"
+	httperror "github.com/DavydAbbasov/spy-cat/internal/controllers/http/helpers"
+	"github.com/DavydAbbasov/spy-cat/internal/controllers/http/validator"
+	currencyservice "github.com/DavydAbbasov/spy-cat/internal/service/currency_service"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/rs/zerolog/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CurrencyHandler struct {
+	svc       currencyservice.CurrencyService
+	validator *validator.Validator
+}
+
+func NewCurrencyHandler(svc currencyservice.CurrencyService, v *validator.Validator) *CurrencyHandler {
+	return &CurrencyHandler{
+		svc:       svc,
+		validator: v,
+	}
+}
+
+// @Summary List exchange rates
+// @Tags admin
+// @Description Every stored rate. A pair without a rate is converted with the
+// @Description inverse rate or through USD when possible.
+// @Produce json
+// @Success 200 {object} dto.ExchangeRatesResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/exchange-rates [get]
+func (h *CurrencyHandler) GetRates() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, err := h.svc.ListRates(c.Request.Context())
+		if err != nil {
+			log.Error().Err(err).Msg("list exchange rates failed")
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToExchangeRatesResponse(items))
+	}
+}
+
+// @Summary Set exchange rate
+// @Tags admin
+// @Description Creates or replaces the rate of a currency pair: 1 {from} = rate {to}.
+// @Accept json
+// @Produce json
+// @Param from path string true "Source ISO 4217 currency"
+// @Param to   path string true "Target ISO 4217 currency"
+// @Param body body dto.SetRateRequest true "Rate"
+// @Success 200 {object} dto.ExchangeRateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/exchange-rates/{from}/{to} [put]
+func (h *CurrencyHandler) SetRate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, err := validator.DecodeJSON[dto.SetRateRequest](h.validator, c.Request)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+			return
+		}
+		rate, err := dto.ToRate(*req)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_rate", err.Error())
+			return
+		}
+
+		out, err := h.svc.SetRate(c.Request.Context(), c.Param("from"), c.Param("to"), rate)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrInvalidCurrency):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_currency", err.Error())
+			case errors.Is(err, serviceerrors.ErrInvalidExchangeRate):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_rate", "rate must be positive and between two different currencies")
+			default:
+				log.Error().Err(err).Msg("set exchange rate failed")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToExchangeRateResponse(out))
+	}
+}
+
+// @Summary Delete exchange rate
+// @Tags admin
+// @Param from path string true "Source ISO 4217 currency"
+// @Param to   path string true "Target ISO 4217 currency"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/exchange-rates/{from}/{to} [delete]
+func (h *CurrencyHandler) DeleteRate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.svc.DeleteRate(c.Request.Context(), c.Param("from"), c.Param("to"))
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrInvalidCurrency), errors.Is(err, serviceerrors.ErrInvalidExchangeRate):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_currency", "from and to must be two different ISO 4217 codes")
+			case errors.Is(err, serviceerrors.ErrExchangeRateNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "exchange rate not found")
+			default:
+				log.Error().Err(err).Msg("delete exchange rate failed")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -6,9 +6,9 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	dto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/mission"
+	"github.com/DavydAbbasov/spy-cat/internal/controllers/http/export"
 	httperror "github.com/DavydAbbasov/spy-cat/internal/controllers/http/helpers"
 	"github.com/DavydAbbasov/spy-cat/internal/controllers/http/validator"
 	"github.com/DavydAbbasov/spy-cat/internal/domain"
@@ -66,6 +66,15 @@ func (h *MissionHandler) CreateMission() gin.HandlerFunc {
 			case errors.Is(err, serviceerrors.ErrInvalidCreateMission):
 				httperror.RespondError(c, http.StatusBadRequest, "invalid_mission", "mission fields are invalid")
 				return
+			case errors.Is(err, serviceerrors.ErrInvalidGoalName),
+				errors.Is(err, serviceerrors.ErrInvalidCountry),
+				errors.Is(err, serviceerrors.ErrInvalidLocation):
+				item := bulkItemError(err)
+				httperror.RespondError(c, http.StatusBadRequest, item.Code, item.Message)
+				return
+			case errors.Is(err, serviceerrors.ErrInvalidPriority):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_priority", "priority must be between 1 and 5")
+				return
 			case errors.Is(err, serviceerrors.ErrMissionAlreadyExists):
 				httperror.RespondError(c, http.StatusConflict, "already_exists", "mission with same title already exists")
 				return
@@ -84,9 +93,98 @@ func (h *MissionHandler) CreateMission() gin.HandlerFunc {
 	}
 }
 
+// @Summary Create many missions at once
+// @Tags missions
+// @Description Validates every mission up front and creates all of them in a single transaction.
+// @Description If any item is invalid nothing is created and per-item errors are returned.
+// @Accept json
+// @Produce json
+// @Param BulkCreateMissionsRequest body dto.BulkCreateMissionsRequest true "Missions to create"
+// @Success 201 {object} dto.BulkCreateMissionsResponse
+// @Failure 400 {object} dto.BulkCreateMissionsResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/bulk [post]
+func (h *MissionHandler) CreateMissionsBulk() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		req, err := validator.DecodeJSON[dto.BulkCreateMissionsRequest](h.validator, c.Request)
+		if err != nil {
+			if errors.Is(err, validator.ErrHandlerValidationFailed) {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+				return
+			}
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+			return
+		}
+
+		items := make([]dto.BulkItemResult, len(req.Missions))
+		params := make([]domain.CreateMissionParams, len(req.Missions))
+		invalid := false
+
+		for i, m := range req.Missions {
+			items[i].Index = i
+			if err := h.validator.Validate(m); err != nil {
+				items[i].Error = &dto.BulkItemError{Code: "invalid_body", Message: err.Error()}
+				invalid = true
+				continue
+			}
+			params[i] = dto.ToCreateMissionParams(m)
+		}
+		if invalid {
+			c.JSON(http.StatusBadRequest, dto.BulkCreateMissionsResponse{Items: items})
+			return
+		}
+
+		missions, err := h.missionSvc.CreateMissions(ctx, params)
+		if err != nil {
+			var itemErrs serviceerrors.ItemErrors
+			if errors.As(err, &itemErrs) {
+				for i, itemErr := range itemErrs {
+					items[i].Error = bulkItemError(itemErr)
+				}
+				c.JSON(http.StatusBadRequest, dto.BulkCreateMissionsResponse{Items: items})
+				return
+			}
+			if errors.Is(err, serviceerrors.ErrInvalidCreateMission) {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+				return
+			}
+
+			log.Error().Err(err).Msg("failed to create missions in bulk")
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		for i, m := range missions {
+			id := m.ID
+			items[i].ID = &id
+		}
+		c.JSON(http.StatusCreated, dto.BulkCreateMissionsResponse{Items: items})
+	}
+}
+
+func bulkItemError(err error) *dto.BulkItemError {
+	switch {
+	case errors.Is(err, serviceerrors.ErrInvalidCreateMission):
+		return &dto.BulkItemError{Code: "invalid_mission", Message: "mission fields are invalid"}
+	case errors.Is(err, serviceerrors.ErrInvalidGoalName):
+		return &dto.BulkItemError{Code: "invalid_name", Message: "invalid goal name"}
+	case errors.Is(err, serviceerrors.ErrInvalidCountry):
+		return &dto.BulkItemError{Code: "invalid_country", Message: "country must be an ISO 3166-1 alpha-2, alpha-3 code or English name"}
+	case errors.Is(err, serviceerrors.ErrInvalidLocation):
+		return &dto.BulkItemError{Code: "invalid_location", Message: "location must have lat -90..90, lon -180..180 and radius 1..1000000 m"}
+	case errors.Is(err, serviceerrors.ErrInvalidPriority):
+		return &dto.BulkItemError{Code: "invalid_priority", Message: "priority must be between 1 and 5"}
+	default:
+		return &dto.BulkItemError{Code: "invalid", Message: err.Error()}
+	}
+}
+
 // @Summary Assign mission to a cat (or unassign with null)
 // @Tags missions
-// @Description Used to link or unlink a mission with a cat
+// @Description Used to link or unlink a mission with a cat.
+// @Description Reassigning an active or paused mission is a handover and requires a note.
 // @Accept json
 // @Produce json
 // @Param id path int true "Mission ID"
@@ -94,6 +192,7 @@ func (h *MissionHandler) CreateMission() gin.HandlerFunc {
 // @Success 204
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /missions/{id}/assign [patch]
 func (h *MissionHandler) AssignMission() gin.HandlerFunc {
@@ -116,7 +215,11 @@ func (h *MissionHandler) AssignMission() gin.HandlerFunc {
 			return
 		}
 
-		err = h.missionSvc.AssignCat(ctx, missionID, req.CatID)
+		err = h.missionSvc.AssignCat(ctx, domain.AssignMissionParams{
+			MissionID: missionID,
+			CatID:     req.CatID,
+			Note:      req.Note,
+		})
 		if err != nil {
 			switch {
 			case errors.Is(err, serviceerrors.ErrMissionNotFound):
@@ -125,6 +228,12 @@ func (h *MissionHandler) AssignMission() gin.HandlerFunc {
 			case errors.Is(err, serviceerrors.ErrCatNotFound):
 				httperror.RespondError(c, http.StatusNotFound, "cat_not_found", "cat not found")
 				return
+			case errors.Is(err, serviceerrors.ErrMissionAlreadyCompleted):
+				httperror.RespondError(c, http.StatusConflict, "mission_completed", "mission is already finished")
+				return
+			case errors.Is(err, serviceerrors.ErrHandoverNoteRequired):
+				httperror.RespondError(c, http.StatusBadRequest, "note_required", "a handover note is required to reassign a mission in progress")
+				return
 			default:
 				log.Error().Err(err).Msg("assign mission failed")
 				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
@@ -132,60 +241,446 @@ func (h *MissionHandler) AssignMission() gin.HandlerFunc {
 			}
 		}
 
-		c.Status(http.StatusNoContent)
-
+		c.Status(http.StatusNoContent)
+
+	}
+}
+
+// @Summary Clone a mission
+// @Tags missions
+// @Description Creates a new planned mission with the same title, description, priority and goals.
+// @Description The copy has no cat and all of its goals start as todo.
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param CloneMissionRequest body dto.CloneMissionRequest false "Overrides for the copy"
+// @Success 201 {object} dto.CreateMissionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/clone [post]
+func (h *MissionHandler) CloneMission() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		missionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || missionID <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+
+		req := &dto.CloneMissionRequest{}
+		if c.Request.ContentLength != 0 {
+			req, err = validator.DecodeJSON[dto.CloneMissionRequest](h.validator, c.Request)
+			if err != nil {
+				if errors.Is(err, validator.ErrHandlerValidationFailed) {
+					httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+					return
+				}
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+				return
+			}
+		}
+
+		m, err := h.missionSvc.CloneMission(ctx, domain.CloneMissionParams{
+			ID:    missionID,
+			Title: req.Title,
+			DueAt: req.DueAt,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrMissionNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found")
+			case errors.Is(err, serviceerrors.ErrInvalidCreateMission):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_mission", "mission fields are invalid")
+			default:
+				log.Error().Err(err).Msg("failed to clone mission")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.Header("Location", fmt.Sprintf("/missions/%d", m.ID))
+		c.JSON(http.StatusCreated, dto.CreateMissionResponse{ID: m.ID})
+	}
+}
+
+// @Summary List mission handovers
+// @Tags missions
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Success 200 {object} dto.MissionHandoversResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/handovers [get]
+func (h *MissionHandler) GetHandovers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+
+		hs, err := h.missionSvc.Handovers(c.Request.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrMissionNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found")
+			default:
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToMissionHandoversResponse(id, hs))
+	}
+}
+
+// @Summary Get mission team
+// @Tags missions
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Success 200 {object} dto.MissionTeamResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/team [get]
+func (h *MissionHandler) GetTeam() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+
+		team, err := h.missionSvc.Team(c.Request.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrMissionNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found")
+			default:
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToMissionTeamResponse(id, team))
+	}
+}
+
+// @Summary Add a cat to the mission team
+// @Tags missions
+// @Description Adds a support or lookout member. The lead is set with /missions/{id}/assign.
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param body body dto.AddTeamMemberRequest true "Team member"
+// @Success 201 {object} dto.TeamMemberResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/team [post]
+func (h *MissionHandler) AddTeamMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+
+		req, err := validator.DecodeJSON[dto.AddTeamMemberRequest](h.validator, c.Request)
+		if err != nil {
+			if errors.Is(err, validator.ErrHandlerValidationFailed) {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+				return
+			}
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+			return
+		}
+
+		a, err := h.missionSvc.AddTeamMember(ctx, domain.MissionAssignment{
+			MissionID: id,
+			CatID:     req.CatID,
+			Role:      domain.TeamRole(req.Role),
+		})
+		if err != nil {
+			h.respondTeamError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.ToTeamMemberResponse(a))
+	}
+}
+
+// @Summary Remove a cat from the mission team
+// @Tags missions
+// @Param id    path int true "Mission ID"
+// @Param catId path int true "Cat ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/team/{catId} [delete]
+func (h *MissionHandler) RemoveTeamMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+		catID, err := strconv.ParseInt(c.Param("catId"), 10, 64)
+		if err != nil || catID <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "cat id must be positive integer")
+			return
+		}
+
+		if err := h.missionSvc.RemoveTeamMember(c.Request.Context(), id, catID); err != nil {
+			h.respondTeamError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// @Summary Assign a goal to a team member
+// @Tags missions
+// @Description Sets the cat responsible for a goal; the cat must be on the mission team. Null clears it.
+// @Accept json
+// @Produce json
+// @Param id     path int true "Mission ID"
+// @Param goalId path int true "Goal ID"
+// @Param body body dto.AssignGoalRequest true "Assignee"
+// @Success 200 {object} dto.GoalResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/goals/{goalId}/assignee [patch]
+func (h *MissionHandler) AssignGoal() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+		goalID, err := strconv.ParseInt(c.Param("goalId"), 10, 64)
+		if err != nil || goalID <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "goal id must be positive integer")
+			return
+		}
+
+		req, err := validator.DecodeJSON[dto.AssignGoalRequest](h.validator, c.Request)
+		if err != nil {
+			if errors.Is(err, validator.ErrHandlerValidationFailed) {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+				return
+			}
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+			return
+		}
+
+		g, err := h.missionSvc.AssignGoal(ctx, id, goalID, req.CatID)
+		if err != nil {
+			h.respondTeamError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToGoalResponse(g))
+	}
+}
+
+// @Summary List missions of a cat
+// @Tags cats
+// @Description Missions where the cat is on the team, in any role
+// @Produce json
+// @Param id path int true "Cat ID"
+// @Success 200 {object} dto.CatMissionsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /cats/{id}/missions [get]
+func (h *MissionHandler) GetCatMissions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		catID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || catID <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_path", "id must be a positive integer")
+			return
+		}
+
+		items, err := h.missionSvc.CatMissions(c.Request.Context(), catID)
+		if err != nil {
+			if errors.Is(err, serviceerrors.ErrCatNotFound) {
+				httperror.RespondError(c, http.StatusNotFound, "cat_not_found", "cat not found")
+				return
+			}
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToCatMissionsResponse(catID, items))
+	}
+}
+
+func (h *MissionHandler) respondTeamError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, serviceerrors.ErrMissionNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "mission_not_found", "mission not found")
+	case errors.Is(err, serviceerrors.ErrCatNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "cat_not_found", "cat not found")
+	case errors.Is(err, serviceerrors.ErrGoalNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "goal_not_found", "goal not found")
+	case errors.Is(err, serviceerrors.ErrInvalidRole):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_role", "the lead is managed through /missions/{id}/assign")
+	case errors.Is(err, serviceerrors.ErrAlreadyTeamMember):
+		httperror.RespondError(c, http.StatusConflict, "already_member", "cat is already on the mission team")
+	case errors.Is(err, serviceerrors.ErrNotTeamMember):
+		httperror.RespondError(c, http.StatusConflict, "not_member", "cat is not on the mission team")
+	case errors.Is(err, serviceerrors.ErrMissionAlreadyCompleted):
+		httperror.RespondError(c, http.StatusConflict, "mission_completed", "mission is already finished")
+	default:
+		log.Error().Err(err).Msg("mission team operation failed")
+		httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+	}
+}
+
+// Get a single mission
+// @Summary      Get a single mission
+// @Description  The ability to receive information about a single mission
+// @Tags         missions
+// @Param        id   path int true "ID Mission"
+// @Produce      json
+// @Success      200 {object} dto.MissionResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /mission/{id} [get]
+func (h *MissionHandler) GetMission() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		idStr := c.Param("id")
+		if idStr == "" {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_path", "id is required")
+			return
+		}
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_path", "id must be a positive integer")
+			return
+		}
+
+		mission, goals, err := h.missionSvc.GetMission(ctx, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrMissionNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found ")
+			default:
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+		c.JSON(http.StatusOK, dto.ToMissionResponse(mission, goals))
+	}
+
+}
+
+// @Summary Full-text search over missions
+// @Tags missions
+// @Description Matches title, description, goal names and goal notes, ranked by relevance.
+// @Description q accepts web search syntax: "quoted phrase", or, -excluded.
+// @Description Matched terms are wrapped in <mark> tags; all other text is HTML-escaped.
+// @Produce json
+// @Param q      query string true  "search query"
+// @Param status query string false "planned|active|paused|completed|aborted|failed"
+// @Param limit  query int    false "limit (1..100)"
+// @Param offset query int    false "offset (>=0)"
+// @Success 200 {object} dto.SearchResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /search [get]
+func (h *MissionHandler) Search() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var q dto.SearchQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		hits, total, err := h.missionSvc.Search(c.Request.Context(), dto.ToSearchParams(q))
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrInvalidFilter):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_query", "q must not be blank")
+			default:
+				log.Error().Err(err).Msg("search missions failed")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToSearchResponse(q, hits, total))
 	}
 }
 
-// Get a single mission
-// @Summary      Get a single mission
-// @Description  The ability to receive information about a single mission
-// @Tags         missions
-// @Param        id   path int true "ID Mission"
-// @Produce      json
-// @Success      200 {object} dto.MissionResponse
-// @Failure      400 {object} dto.ErrorResponse
-// @Failure      404 {object} dto.ErrorResponse
-// @Failure      500 {object} dto.ErrorResponse
-// @Router       /mission/{id} [get]
-func (h *MissionHandler) GetMission() gin.HandlerFunc {
+// @Summary Mission goals as GeoJSON
+// @Tags missions
+// @Description Located goals as a FeatureCollection of points, ready for map clients.
+// @Description Goals without coordinates are omitted.
+// @Produce application/geo+json
+// @Param id path int true "Mission ID"
+// @Success 200 {object} dto.FeatureCollection
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/goals.geojson [get]
+func (h *MissionHandler) GetGoalsGeoJSON() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx := c.Request.Context()
-
-		idStr := c.Param("id")
-		if idStr == "" {
-			httperror.RespondError(c, http.StatusBadRequest, "invalid_path", "id is required")
-			return
-		}
-		id, err := strconv.ParseInt(idStr, 10, 64)
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 		if err != nil || id <= 0 {
-			httperror.RespondError(c, http.StatusBadRequest, "invalid_path", "id must be a positive integer")
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
 			return
 		}
 
-		mission, goals, err := h.missionSvc.GetMission(ctx, id)
+		_, goals, err := h.missionSvc.GetMission(c.Request.Context(), id)
 		if err != nil {
 			switch {
 			case errors.Is(err, serviceerrors.ErrMissionNotFound):
-				httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found ")
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found")
 			default:
+				log.Error().Err(err).Msg("get mission goals failed")
 				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
 			}
 			return
 		}
-		c.JSON(http.StatusOK, dto.ToMissionResponse(mission, goals))
-	}
 
+		c.Header("Content-Type", "application/geo+json")
+		c.JSON(http.StatusOK, dto.ToGoalsFeatureCollection(goals))
+	}
 }
 
 // @Summary List missions
 // @Tags missions
 // @Produce json
-// @Param status query string false "planned|active|completed"
-// @Param catId  query int    false "Cat ID"
-// @Param q      query string false "search by title"
-// @Param limit  query int    false "limit (1..200)"
-// @Param offset query int    false "offset"
+// @Param status      query string false "planned|active|paused|completed|aborted|failed"
+// @Param catId       query int    false "Lead cat ID"
+// @Param memberCatId query int    false "Any team member cat ID"
+// @Param q           query string false "full-text search over title, description, goal names and notes"
+// @Param priority    query int    false "priority (1 highest..5 lowest)"
+// @Param dueBefore   query string false "deadline before (RFC3339)"
+// @Param dueAfter    query string false "deadline at or after (RFC3339)"
+// @Param overdue     query bool   false "only active missions past their deadline"
+// @Param bbox        query string false "missions with a goal inside minLon,minLat,maxLon,maxLat"
+// @Param sort        query string false "comma separated keys from created_at|updated_at|due_at|priority|started_at|completed_at|title|status, prefix with - for descending, e.g. -updated_at,title"
+// @Param limit       query int    false "limit (1..200)"
+// @Param offset      query int    false "offset"
 // @Success 200 {object} dto.GetMissionsResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
@@ -199,22 +694,25 @@ func (h *MissionHandler) GetMissions() gin.HandlerFunc {
 			return
 		}
 
-		var f domain.MissionFilter
-		if q.Status != nil && *q.Status != "" {
-			st := domain.MissionStatus(*q.Status)
-			f.Status = &st
+		f, err := dto.ToMissionFilter(q.MissionFilterQuery)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
 		}
-		f.CatID = q.CatID
-		if q.Q != nil {
-			if t := strings.TrimSpace(*q.Q); t != "" {
-				f.Q = &t
-			}
+		f.Sort, err = dto.ToMissionSort(q.Sort)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_sort", err.Error())
+			return
 		}
 		f.Limit = q.Limit
 		f.Offset = q.Offset
 
 		items, total, err := h.missionSvc.List(c.Request.Context(), f)
 		if err != nil {
+			if errors.Is(err, serviceerrors.ErrInvalidFilter) {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_query", "dueAfter must not be later than dueBefore")
+				return
+			}
 			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
 			return
 		}
@@ -223,8 +721,70 @@ func (h *MissionHandler) GetMissions() gin.HandlerFunc {
 	}
 }
 
+// @Summary Export missions
+// @Tags missions
+// @Description Streams missions as CSV or NDJSON, chosen by the format parameter or the Accept header
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param status      query string false "planned|active|paused|completed|aborted|failed"
+// @Param catId       query int    false "Lead cat ID"
+// @Param memberCatId query int    false "Any team member cat ID"
+// @Param q           query string false "full-text search over title, description, goal names and notes"
+// @Param priority    query int    false "priority (1 highest..5 lowest)"
+// @Param dueBefore   query string false "deadline before (RFC3339)"
+// @Param dueAfter    query string false "deadline at or after (RFC3339)"
+// @Param overdue     query bool   false "only active missions past their deadline"
+// @Param bbox        query string false "missions with a goal inside minLon,minLat,maxLon,maxLat"
+// @Param format      query string false "csv|ndjson"
+// @Success 200 {string} string "exported rows"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/export [get]
+func (h *MissionHandler) ExportMissions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var q dto.ExportMissionsQuery
+
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		format, err := export.NegotiateFormat(c)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_format", "format must be csv or ndjson")
+			return
+		}
+
+		f, err := dto.ToMissionFilter(q.MissionFilterQuery)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		w, err := export.NewWriter(c, format, "missions", dto.MissionExportHeader)
+		if err != nil {
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		err = h.missionSvc.Export(c.Request.Context(), f, func(m domain.Mission) error {
+			return w.Write(dto.ToMissionExportRow(m), dto.ToMissionExportRecord(m))
+		})
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			// headers are already sent, the client sees a truncated body
+			log.Error().Err(err).Msg("export missions failed")
+		}
+	}
+}
+
 // @Summary Update mission status
 // @Tags missions
+// @Description Moves the mission along the status graph, see /missions/{id}/transitions.
+// @Description A reason is required when aborting or failing a mission.
+// @Description A mission cannot become active until all of its dependencies are completed.
 // @Accept json
 // @Produce json
 // @Param id path int true "Mission ID"
@@ -252,7 +812,7 @@ func (h *MissionHandler) UpdateMissionStatus() gin.HandlerFunc {
 
 		st := domain.MissionStatus(req.Status)
 		m, err := h.missionSvc.UpdateStatus(c.Request.Context(), domain.UpdateMissionStatusParams{
-			ID: id, Status: st,
+			ID: id, Status: st, Reason: req.Reason,
 		})
 
 		if err != nil {
@@ -263,6 +823,10 @@ func (h *MissionHandler) UpdateMissionStatus() gin.HandlerFunc {
 				httperror.RespondError(c, http.StatusBadRequest, "invalid_status", "unknown status")
 			case errors.Is(err, serviceerrors.ErrInvalidTransition):
 				httperror.RespondError(c, http.StatusConflict, "invalid_transition", "status transition is not allowed")
+			case errors.Is(err, serviceerrors.ErrReasonRequired):
+				httperror.RespondError(c, http.StatusBadRequest, "reason_required", "reason is required for aborted and failed missions")
+			case errors.Is(err, serviceerrors.ErrMissionBlocked):
+				httperror.RespondError(c, http.StatusConflict, "mission_blocked", err.Error())
 			case errors.Is(err, serviceerrors.ErrConflict):
 				httperror.RespondError(c, http.StatusConflict, "conflict", "status was changed concurrently")
 			default:
@@ -272,7 +836,44 @@ func (h *MissionHandler) UpdateMissionStatus() gin.HandlerFunc {
 			return
 		}
 
-		c.JSON(http.StatusOK, dto.ToMissionResponse(m, nil))
+		m, goals, err := h.missionSvc.GetMission(c.Request.Context(), m.ID)
+		if err != nil {
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+		c.JSON(http.StatusOK, dto.ToMissionResponse(m, goals))
+	}
+}
+
+// @Summary List allowed status transitions
+// @Tags missions
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Success 200 {object} dto.MissionTransitionsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/transitions [get]
+func (h *MissionHandler) GetTransitions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "id must be positive integer")
+			return
+		}
+
+		m, next, err := h.missionSvc.Transitions(c.Request.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrMissionNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found")
+			default:
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToMissionTransitionsResponse(m, next))
 	}
 }
 
@@ -306,9 +907,10 @@ func (h *MissionHandler) AddGoal() gin.HandlerFunc {
 		notes := strings.TrimSpace(req.Notes)
 
 		g, err := h.missionSvc.AddGoal(c.Request.Context(), missionID, domain.CreateGoalParams{
-			Name:    name,
-			Country: country,
-			Notes:   notes,
+			Name:     name,
+			Country:  country,
+			Notes:    notes,
+			Location: dto.ToGoalLocation(req.Location),
 		})
 
 		if err != nil {
@@ -320,22 +922,255 @@ func (h *MissionHandler) AddGoal() gin.HandlerFunc {
 			case errors.Is(err, serviceerrors.ErrInvalidGoalName):
 				httperror.RespondError(c, http.StatusBadRequest, "invalid_name", "invalid goal name")
 			case errors.Is(err, serviceerrors.ErrInvalidCountry):
-				httperror.RespondError(c, http.StatusBadRequest, "invalid_country", "country must be ISO-3166-1 alpha-2")
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_country", "country must be an ISO 3166-1 alpha-2, alpha-3 code or English name")
+			case errors.Is(err, serviceerrors.ErrInvalidLocation):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_location", "location must have lat -90..90, lon -180..180 and radius 1..1000000 m")
+			default:
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.ToGoalResponse(g))
+	}
+}
+
+// @Summary Get the dependency graph of a mission
+// @Tags missions
+// @Description Returns every mission the mission depends on, directly or not, every mission
+// @Description that depends on it, and the edges between them. blockedBy lists the direct
+// @Description dependencies that still keep the mission from becoming active.
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Success 200 {object} dto.DependencyGraphResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/dependencies [get]
+func (h *MissionHandler) GetDependencies() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+
+		g, err := h.missionSvc.Dependencies(c.Request.Context(), id)
+		if err != nil {
+			h.respondDependencyError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToDependencyGraphResponse(g))
+	}
+}
+
+// @Summary Make a mission depend on another one
+// @Tags missions
+// @Description The mission cannot become active until the other one is completed.
+// @Description Only planned missions can gain dependencies, and cycles are rejected.
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param body body dto.AddDependencyRequest true "Dependency"
+// @Success 201 {object} dto.DependencyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/dependencies [post]
+func (h *MissionHandler) AddDependency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+
+		req, err := validator.DecodeJSON[dto.AddDependencyRequest](h.validator, c.Request)
+		if err != nil {
+			if errors.Is(err, validator.ErrHandlerValidationFailed) {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+				return
+			}
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+			return
+		}
+
+		d, err := h.missionSvc.AddDependency(c.Request.Context(), id, req.DependsOnID)
+		if err != nil {
+			h.respondDependencyError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.ToDependencyResponse(d))
+	}
+}
+
+// @Summary Remove a mission dependency
+// @Tags missions
+// @Param id          path int true "Mission ID"
+// @Param dependsOnId path int true "ID of the mission depended on"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/dependencies/{dependsOnId} [delete]
+func (h *MissionHandler) RemoveDependency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+		dependsOnID, err := strconv.ParseInt(c.Param("dependsOnId"), 10, 64)
+		if err != nil || dependsOnID <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "dependency id must be positive integer")
+			return
+		}
+
+		if err := h.missionSvc.RemoveDependency(c.Request.Context(), id, dependsOnID); err != nil {
+			h.respondDependencyError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func (h *MissionHandler) respondDependencyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, serviceerrors.ErrMissionNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found")
+	case errors.Is(err, serviceerrors.ErrDependencyNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "dependency_not_found", "dependency not found")
+	case errors.Is(err, serviceerrors.ErrMissionNotPlanned):
+		httperror.RespondError(c, http.StatusConflict, "mission_not_planned", "only planned missions can gain dependencies")
+	case errors.Is(err, serviceerrors.ErrDependencyCycle):
+		httperror.RespondError(c, http.StatusConflict, "dependency_cycle", "dependency would create a cycle")
+	case errors.Is(err, serviceerrors.ErrDependencyExists):
+		httperror.RespondError(c, http.StatusConflict, "already_exists", "dependency already exists")
+	default:
+		log.Error().Err(err).Msg("mission dependency operation failed")
+		httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+	}
+}
+
+// @Summary Change the status of a goal
+// @Tags missions
+// @Description Goals move todo -> in_progress -> done, failed or skipped; failed and skipped
+// @Description goals can be reopened to todo. Starting, finishing or failing a goal needs an
+// @Description active mission. A done goal is credited to its assignee or the mission lead.
+// @Accept json
+// @Produce json
+// @Param id     path int true "Mission ID"
+// @Param goalId path int true "Goal ID"
+// @Param body body dto.UpdateGoalStatusRequest true "New status"
+// @Success 200 {object} dto.GoalResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/goals/{goalId}/status [patch]
+func (h *MissionHandler) UpdateGoalStatus() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+		goalID, err := strconv.ParseInt(c.Param("goalId"), 10, 64)
+		if err != nil || goalID <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "goal id must be positive integer")
+			return
+		}
+
+		req, err := validator.DecodeJSON[dto.UpdateGoalStatusRequest](h.validator, c.Request)
+		if err != nil {
+			if errors.Is(err, validator.ErrHandlerValidationFailed) {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+				return
+			}
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+			return
+		}
+
+		g, err := h.missionSvc.UpdateGoalStatus(c.Request.Context(), domain.UpdateGoalStatusParams{
+			MissionID: id,
+			GoalID:    goalID,
+			Status:    domain.MissionGoalStatus(req.Status),
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrMissionNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found")
+			case errors.Is(err, serviceerrors.ErrGoalNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "goal_not_found", "goal not found")
+			case errors.Is(err, serviceerrors.ErrInvalidGoalStatus):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_status", "unknown goal status")
+			case errors.Is(err, serviceerrors.ErrInvalidGoalTransition):
+				httperror.RespondError(c, http.StatusConflict, "invalid_transition", "goal status transition is not allowed")
+			case errors.Is(err, serviceerrors.ErrMissionNotActive):
+				httperror.RespondError(c, http.StatusConflict, "mission_not_active", "mission must be active to work on goals")
+			case errors.Is(err, serviceerrors.ErrMissionCompleted):
+				httperror.RespondError(c, http.StatusConflict, "mission_completed", "mission is already finished")
+			case errors.Is(err, serviceerrors.ErrConflict):
+				httperror.RespondError(c, http.StatusConflict, "conflict", "goal was changed concurrently")
 			default:
+				log.Error().Err(err).Msg("failed to update goal status")
 				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
 			}
 			return
 		}
 
-		resp := dto.GoalResponse{
-			ID:        g.ID,
-			Name:      g.Name,
-			Status:    string(g.Status),
-			Country:   g.Country,
-			Notes:     g.Notes,
-			CreatedAt: g.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: g.UpdatedAt.Format(time.RFC3339),
+		c.JSON(http.StatusOK, dto.ToGoalResponse(g))
+	}
+}
+
+// @Summary Reorder the goals of a mission
+// @Tags missions
+// @Description goalIds must list every goal of the mission exactly once, in the new order.
+// @Accept json
+// @Produce json
+// @Param id path int true "Mission ID"
+// @Param body body dto.ReorderGoalsRequest true "Goal ids in order"
+// @Success 200 {object} dto.MissionGoalsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/goals/order [put]
+func (h *MissionHandler) ReorderGoals() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+
+		req, err := validator.DecodeJSON[dto.ReorderGoalsRequest](h.validator, c.Request)
+		if err != nil {
+			if errors.Is(err, validator.ErrHandlerValidationFailed) {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+				return
+			}
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+			return
+		}
+
+		goals, err := h.missionSvc.ReorderGoals(c.Request.Context(), id, req.GoalIDs)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrMissionNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found")
+			case errors.Is(err, serviceerrors.ErrInvalidGoalOrder):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_order", "goalIds must list every goal of the mission once")
+			default:
+				log.Error().Err(err).Msg("failed to reorder goals")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
 		}
-		c.JSON(http.StatusCreated, resp)
+
+		c.JSON(http.StatusOK, dto.ToMissionGoalsResponse(id, goals))
 	}
 }
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	dto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/mission"
+	httperror "github.com/DavydAbbasov/spy-cat/internal/controllers/http/helpers"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/rs/zerolog/log"
+
+	recommendationservice "github.com/DavydAbbasov/spy-cat/internal/service/recommendation_service"
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationHandler struct {
+	svc recommendationservice.RecommendationService
+}
+
+func NewRecommendationHandler(svc recommendationservice.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{
+		svc: svc,
+	}
+}
+
+// @Summary Rank candidate cats for a mission
+// @Tags missions
+// @Description Scores every cat not yet on the team by experience, breed traits, workload,
+// @Description success in the mission's countries and salary cost. Each factor is returned
+// @Description separately so the ranking can be explained.
+// @Produce json
+// @Param id    path  int true  "Mission ID"
+// @Param limit query int false "limit (1..100)"
+// @Success 200 {object} dto.CandidatesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/{id}/candidates [get]
+func (h *RecommendationHandler) GetCandidates() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "mission id must be positive integer")
+			return
+		}
+
+		var q dto.CandidatesQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		items, err := h.svc.Candidates(c.Request.Context(), id, q.Limit)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrMissionNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "mission not found")
+			case errors.Is(err, serviceerrors.ErrMissionAlreadyCompleted):
+				httperror.RespondError(c, http.StatusConflict, "mission_completed", "mission is already finished")
+			default:
+				log.Error().Err(err).Msg("rank candidates failed")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToCandidatesResponse(id, items))
+	}
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	dto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/salary_band"
+	httperror "github.com/DavydAbbasov/spy-cat/internal/controllers/http/helpers"
+	"github.com/DavydAbbasov/spy-cat/internal/controllers/http/validator"
+	bandservice "github.com/DavydAbbasov/spy-cat/internal/service/band_service"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/rs/zerolog/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BandHandler struct {
+	svc       bandservice.BandService
+	validator *validator.Validator
+}
+
+func NewBandHandler(svc bandservice.BandService, v *validator.Validator) *BandHandler {
+	return &BandHandler{
+		svc:       svc,
+		validator: v,
+	}
+}
+
+// @Summary Create a salary band
+// @Tags salary-bands
+// @Description A band limits the salary of cats with min_years..max_years of experience.
+// @Description Without a breed it applies to every breed; a breed band wins over it.
+// @Description Bands of the same breed may not overlap.
+// @Accept json
+// @Produce json
+// @Param SaveBandRequest body dto.SaveBandRequest true "Band"
+// @Success 201 {object} dto.BandResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /salary-bands [post]
+func (h *BandHandler) CreateBand() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, ok := h.decodeSave(c)
+		if !ok {
+			return
+		}
+
+		b, err := h.svc.CreateBand(c.Request.Context(), dto.ToSalaryBand(*req))
+		if err != nil {
+			respondBandError(c, err, "failed to create salary band")
+			return
+		}
+
+		c.Header("Location", fmt.Sprintf("/salary-bands/%d", b.ID))
+		c.JSON(http.StatusCreated, dto.ToBandResponse(b))
+	}
+}
+
+// @Summary List salary bands
+// @Tags salary-bands
+// @Produce json
+// @Success 200 {object} dto.BandsResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /salary-bands [get]
+func (h *BandHandler) GetBands() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, err := h.svc.ListBands(c.Request.Context())
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list salary bands")
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToBandsResponse(items))
+	}
+}
+
+// @Summary Get a salary band
+// @Tags salary-bands
+// @Produce json
+// @Param id path int true "Band ID"
+// @Success 200 {object} dto.BandResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /salary-bands/{id} [get]
+func (h *BandHandler) GetBand() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseBandID(c)
+		if !ok {
+			return
+		}
+
+		b, err := h.svc.GetBand(c.Request.Context(), id)
+		if err != nil {
+			respondBandError(c, err, "failed to get salary band")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToBandResponse(b))
+	}
+}
+
+// @Summary Replace a salary band
+// @Tags salary-bands
+// @Description Salaries already outside the new band are kept; see /cats/out-of-band.
+// @Accept json
+// @Produce json
+// @Param id path int true "Band ID"
+// @Param SaveBandRequest body dto.SaveBandRequest true "Band"
+// @Success 200 {object} dto.BandResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /salary-bands/{id} [put]
+func (h *BandHandler) UpdateBand() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseBandID(c)
+		if !ok {
+			return
+		}
+		req, ok := h.decodeSave(c)
+		if !ok {
+			return
+		}
+
+		b, err := h.svc.UpdateBand(c.Request.Context(), id, dto.ToSalaryBand(*req))
+		if err != nil {
+			respondBandError(c, err, "failed to update salary band")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToBandResponse(b))
+	}
+}
+
+// @Summary Delete a salary band
+// @Tags salary-bands
+// @Param id path int true "Band ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /salary-bands/{id} [delete]
+func (h *BandHandler) DeleteBand() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseBandID(c)
+		if !ok {
+			return
+		}
+
+		if err := h.svc.DeleteBand(c.Request.Context(), id); err != nil {
+			respondBandError(c, err, "failed to delete salary band")
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func (h *BandHandler) decodeSave(c *gin.Context) (*dto.SaveBandRequest, bool) {
+	req, err := validator.DecodeJSON[dto.SaveBandRequest](h.validator, c.Request)
+	if err != nil {
+		if errors.Is(err, validator.ErrHandlerValidationFailed) {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+			return nil, false
+		}
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+		return nil, false
+	}
+	return req, true
+}
+
+func parseBandID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "band id must be positive integer")
+		return 0, false
+	}
+	return id, true
+}
+
+func respondBandError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, serviceerrors.ErrSalaryBandNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "not_found", "salary band not found")
+	case errors.Is(err, serviceerrors.ErrSalaryBandOverlap):
+		httperror.RespondError(c, http.StatusConflict, "band_overlap", "salary band overlaps an existing band of the same breed")
+	case errors.Is(err, serviceerrors.ErrInvalidSalaryBand):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_band", "years and salaries must satisfy 0 <= min <= max, salaries at most 1000000")
+	case errors.Is(err, serviceerrors.ErrInvalidCurrency):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_currency", err.Error())
+	default:
+		log.Error().Err(err).Msg(msg)
+		httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+	}
+}
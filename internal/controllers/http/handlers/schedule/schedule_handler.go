@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	dto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/schedule"
+	httperror "github.com/DavydAbbasov/spy-cat/internal/controllers/http/helpers"
+	"github.com/DavydAbbasov/spy-cat/internal/controllers/http/validator"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/rs/zerolog/log"
+
+	scheduleservice "github.com/DavydAbbasov/spy-cat/internal/service/schedule_service"
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleHandler struct {
+	svc       scheduleservice.ScheduleService
+	validator *validator.Validator
+}
+
+func NewScheduleHandler(svc scheduleservice.ScheduleService, validator *validator.Validator) *ScheduleHandler {
+	return &ScheduleHandler{
+		svc:       svc,
+		validator: validator,
+	}
+}
+
+// @Summary Create a recurring mission schedule
+// @Tags mission-schedules
+// @Description Repeats a template (templateId) or an existing mission (missionId) on a
+// @Description five-field cron expression evaluated in the given time zone. The mission of
+// @Description each run is created leadTime ahead of it, at most once per run.
+// @Accept json
+// @Produce json
+// @Param CreateScheduleRequest body dto.CreateScheduleRequest true "Schedule"
+// @Success 201 {object} dto.ScheduleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mission-schedules [post]
+func (h *ScheduleHandler) CreateSchedule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, err := validator.DecodeJSON[dto.CreateScheduleRequest](h.validator, c.Request)
+		if err != nil {
+			if errors.Is(err, validator.ErrHandlerValidationFailed) {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+				return
+			}
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+			return
+		}
+
+		p, err := dto.ToCreateScheduleParams(*req)
+		if err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_body", "leadTime must be a duration such as 48h")
+			return
+		}
+
+		sc, err := h.svc.CreateSchedule(c.Request.Context(), p)
+		if err != nil {
+			respondScheduleError(c, err, "failed to create mission schedule")
+			return
+		}
+
+		c.Header("Location", fmt.Sprintf("/mission-schedules/%d", sc.ID))
+		c.JSON(http.StatusCreated, dto.ToScheduleResponse(sc))
+	}
+}
+
+// @Summary List recurring mission schedules
+// @Tags mission-schedules
+// @Produce json
+// @Param limit  query int false "limit (1..200)"
+// @Param offset query int false "offset"
+// @Success 200 {object} dto.GetSchedulesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mission-schedules [get]
+func (h *ScheduleHandler) GetSchedules() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var q dto.GetSchedulesQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		items, total, err := h.svc.ListSchedules(c.Request.Context(), q.Limit, q.Offset)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list mission schedules")
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToGetSchedulesResponse(items, q.Limit, q.Offset, total))
+	}
+}
+
+// @Summary Get a recurring mission schedule
+// @Tags mission-schedules
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} dto.ScheduleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mission-schedules/{id} [get]
+func (h *ScheduleHandler) GetSchedule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseScheduleID(c)
+		if !ok {
+			return
+		}
+
+		sc, err := h.svc.GetSchedule(c.Request.Context(), id)
+		if err != nil {
+			respondScheduleError(c, err, "failed to get mission schedule")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToScheduleResponse(sc))
+	}
+}
+
+// @Summary Pause or resume a recurring mission schedule
+// @Tags mission-schedules
+// @Description Runs missed while a schedule was paused are skipped.
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param UpdateScheduleRequest body dto.UpdateScheduleRequest true "Schedule state"
+// @Success 200 {object} dto.ScheduleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mission-schedules/{id} [patch]
+func (h *ScheduleHandler) UpdateSchedule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseScheduleID(c)
+		if !ok {
+			return
+		}
+
+		req, err := validator.DecodeJSON[dto.UpdateScheduleRequest](h.validator, c.Request)
+		if err != nil {
+			if errors.Is(err, validator.ErrHandlerValidationFailed) {
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+				return
+			}
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+			return
+		}
+
+		sc, err := h.svc.SetEnabled(c.Request.Context(), id, *req.Enabled)
+		if err != nil {
+			respondScheduleError(c, err, "failed to update mission schedule")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToScheduleResponse(sc))
+	}
+}
+
+// @Summary Delete a recurring mission schedule
+// @Tags mission-schedules
+// @Description Missions already created by the schedule are kept.
+// @Param id path int true "Schedule ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mission-schedules/{id} [delete]
+func (h *ScheduleHandler) DeleteSchedule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseScheduleID(c)
+		if !ok {
+			return
+		}
+
+		if err := h.svc.DeleteSchedule(c.Request.Context(), id); err != nil {
+			respondScheduleError(c, err, "failed to delete mission schedule")
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func parseScheduleID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "schedule id must be positive integer")
+		return 0, false
+	}
+	return id, true
+}
+
+func respondScheduleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, serviceerrors.ErrScheduleNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "not_found", "mission schedule not found")
+	case errors.Is(err, serviceerrors.ErrTemplateNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "template_not_found", "mission template not found")
+	case errors.Is(err, serviceerrors.ErrMissionNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "mission_not_found", "mission not found")
+	case errors.Is(err, serviceerrors.ErrInvalidSchedule):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_schedule", err.Error())
+	default:
+		log.Error().Err(err).Msg(msg)
+		httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+	}
+}
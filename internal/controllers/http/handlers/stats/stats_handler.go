@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	dto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/stats"
+	httperror "github.com/DavydAbbasov/spy-cat/internal/controllers/http/helpers"
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	statsservice "github.com/DavydAbbasov/spy-cat/internal/service/stats_service"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/rs/zerolog/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatsHandler struct {
+	svc statsservice.StatsService
+}
+
+func NewStatsHandler(svc statsservice.StatsService) *StatsHandler {
+	return &StatsHandler{
+		svc: svc,
+	}
+}
+
+// @Summary Dashboard statistics
+// @Tags stats
+// @Description Missions and goals by status, cats without missions, average mission
+// @Description duration, salary totals per breed and completions per week.
+// @Description The snapshot is cached for a short time; generatedAt tells its age.
+// @Produce json
+// @Success 200 {object} dto.StatsResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /stats [get]
+func (h *StatsHandler) GetStats() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		st, err := h.svc.Stats(c.Request.Context())
+		if err != nil {
+			log.Error().Err(err).Msg("compute stats failed")
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToStatsResponse(st))
+	}
+}
+
+// @Summary Cat performance profile
+// @Tags cats
+// @Description Missions completed and failed (any team role), goals done, average time
+// @Description from start to completion and countries where the cat finished goals.
+// @Produce json
+// @Param id   path  int true  "Cat ID"
+// @Param days query int false "only the last N days (1..3650); whole history when omitted"
+// @Success 200 {object} dto.CatPerformanceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /cats/{id}/performance [get]
+func (h *StatsHandler) GetCatPerformance() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || id <= 0 {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "cat id must be positive integer")
+			return
+		}
+
+		var q dto.CatPerformanceQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		p, err := h.svc.CatPerformance(c.Request.Context(), id, dto.Days(q.Days))
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrCatNotFound):
+				httperror.RespondError(c, http.StatusNotFound, "not_found", "cat not found")
+			default:
+				log.Error().Err(err).Msg("cat performance failed")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToCatPerformanceResponse(p))
+	}
+}
+
+// @Summary Cat leaderboard
+// @Tags cats
+// @Description Ranks cats by completed missions or done goals over the last N days.
+// @Description Ties fall back to the other metric, then to the faster average completion.
+// @Produce json
+// @Param days   query int    false "window in days (1..3650), default 30"
+// @Param metric query string false "missions|goals, default missions"
+// @Param limit  query int    false "limit (1..100), default 10"
+// @Success 200 {object} dto.LeaderboardResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /cats/leaderboard [get]
+func (h *StatsHandler) GetLeaderboard() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var q dto.LeaderboardQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		entries, err := h.svc.Leaderboard(c.Request.Context(), dto.Days(q.Days), domain.LeaderboardMetric(q.Metric), q.Limit)
+		if err != nil {
+			switch {
+			case errors.Is(err, serviceerrors.ErrInvalidFilter):
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_query", "invalid leaderboard window or metric")
+			default:
+				log.Error().Err(err).Msg("leaderboard failed")
+				httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToLeaderboardResponse(q, entries))
+	}
+}
@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	missiondto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/mission"
+	dto "github.com/DavydAbbasov/spy-cat/internal/controllers/http/dto/template"
+	httperror "github.com/DavydAbbasov/spy-cat/internal/controllers/http/helpers"
+	"github.com/DavydAbbasov/spy-cat/internal/controllers/http/validator"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/rs/zerolog/log"
+
+	templateservice "github.com/DavydAbbasov/spy-cat/internal/service/template_service"
+	"github.com/gin-gonic/gin"
+)
+
+type TemplateHandler struct {
+	svc       templateservice.TemplateService
+	validator *validator.Validator
+}
+
+func NewTemplateHandler(svc templateservice.TemplateService, validator *validator.Validator) *TemplateHandler {
+	return &TemplateHandler{
+		svc:       svc,
+		validator: validator,
+	}
+}
+
+// @Summary Create a mission template
+// @Tags mission-templates
+// @Description Title, description and goals may contain {{name}} placeholders,
+// @Description a goal country may be a placeholder too.
+// @Accept json
+// @Produce json
+// @Param SaveTemplateRequest body dto.SaveTemplateRequest true "Template"
+// @Success 201 {object} dto.TemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mission-templates [post]
+func (h *TemplateHandler) CreateTemplate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, ok := h.decodeSave(c)
+		if !ok {
+			return
+		}
+
+		t, err := h.svc.CreateTemplate(c.Request.Context(), dto.ToSaveTemplateParams(*req))
+		if err != nil {
+			respondTemplateError(c, err, "failed to create mission template")
+			return
+		}
+
+		c.Header("Location", fmt.Sprintf("/mission-templates/%d", t.ID))
+		c.JSON(http.StatusCreated, dto.ToTemplateResponse(t))
+	}
+}
+
+// @Summary List mission templates
+// @Tags mission-templates
+// @Produce json
+// @Param limit  query int false "limit (1..200)"
+// @Param offset query int false "offset"
+// @Success 200 {object} dto.GetTemplatesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mission-templates [get]
+func (h *TemplateHandler) GetTemplates() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var q dto.GetTemplatesQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+
+		items, total, err := h.svc.ListTemplates(c.Request.Context(), q.Limit, q.Offset)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list mission templates")
+			httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToGetTemplatesResponse(items, q.Limit, q.Offset, total))
+	}
+}
+
+// @Summary Get a mission template with its goals
+// @Tags mission-templates
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} dto.TemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mission-templates/{id} [get]
+func (h *TemplateHandler) GetTemplate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseTemplateID(c)
+		if !ok {
+			return
+		}
+
+		t, err := h.svc.GetTemplate(c.Request.Context(), id)
+		if err != nil {
+			respondTemplateError(c, err, "failed to get mission template")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToTemplateResponse(t))
+	}
+}
+
+// @Summary Replace a mission template
+// @Tags mission-templates
+// @Description Replaces every field and the whole goal list of the template.
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param SaveTemplateRequest body dto.SaveTemplateRequest true "Template"
+// @Success 200 {object} dto.TemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mission-templates/{id} [put]
+func (h *TemplateHandler) UpdateTemplate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseTemplateID(c)
+		if !ok {
+			return
+		}
+		req, ok := h.decodeSave(c)
+		if !ok {
+			return
+		}
+
+		t, err := h.svc.UpdateTemplate(c.Request.Context(), id, dto.ToSaveTemplateParams(*req))
+		if err != nil {
+			respondTemplateError(c, err, "failed to update mission template")
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToTemplateResponse(t))
+	}
+}
+
+// @Summary Delete a mission template
+// @Tags mission-templates
+// @Description Missions created from the template are kept.
+// @Param id path int true "Template ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /mission-templates/{id} [delete]
+func (h *TemplateHandler) DeleteTemplate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseTemplateID(c)
+		if !ok {
+			return
+		}
+
+		if err := h.svc.DeleteTemplate(c.Request.Context(), id); err != nil {
+			respondTemplateError(c, err, "failed to delete mission template")
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// @Summary Create a mission from a template
+// @Tags missions
+// @Description Fills the template placeholders from vars and creates a planned mission.
+// @Description The body is optional when the template has no placeholders.
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param InstantiateTemplateRequest body dto.InstantiateTemplateRequest false "Placeholder values and overrides"
+// @Success 201 {object} missiondto.CreateMissionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /missions/from-template/{id} [post]
+func (h *TemplateHandler) CreateMissionFromTemplate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseTemplateID(c)
+		if !ok {
+			return
+		}
+
+		req := &dto.InstantiateTemplateRequest{}
+		if c.Request.ContentLength != 0 {
+			var err error
+			req, err = validator.DecodeJSON[dto.InstantiateTemplateRequest](h.validator, c.Request)
+			if err != nil {
+				if errors.Is(err, validator.ErrHandlerValidationFailed) {
+					httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+					return
+				}
+				httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+				return
+			}
+		}
+
+		m, err := h.svc.Instantiate(c.Request.Context(), dto.ToInstantiateTemplateParams(id, *req))
+		if err != nil {
+			respondTemplateError(c, err, "failed to create mission from template")
+			return
+		}
+
+		c.Header("Location", fmt.Sprintf("/missions/%d", m.ID))
+		c.JSON(http.StatusCreated, missiondto.CreateMissionResponse{ID: m.ID})
+	}
+}
+
+func (h *TemplateHandler) decodeSave(c *gin.Context) (*dto.SaveTemplateRequest, bool) {
+	req, err := validator.DecodeJSON[dto.SaveTemplateRequest](h.validator, c.Request)
+	if err != nil {
+		if errors.Is(err, validator.ErrHandlerValidationFailed) {
+			httperror.RespondError(c, http.StatusBadRequest, "invalid_body", err.Error())
+			return nil, false
+		}
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_json", "invalid json body")
+		return nil, false
+	}
+	return req, true
+}
+
+func parseTemplateID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_id", "template id must be positive integer")
+		return 0, false
+	}
+	return id, true
+}
+
+func respondTemplateError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, serviceerrors.ErrTemplateNotFound):
+		httperror.RespondError(c, http.StatusNotFound, "not_found", "mission template not found")
+	case errors.Is(err, serviceerrors.ErrTemplateNameTaken):
+		httperror.RespondError(c, http.StatusConflict, "already_exists", "mission template with same name already exists")
+	case errors.Is(err, serviceerrors.ErrTemplateVarMissing):
+		httperror.RespondError(c, http.StatusBadRequest, "missing_vars", err.Error())
+	case errors.Is(err, serviceerrors.ErrInvalidTemplate):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_template", "template fields are invalid")
+	case errors.Is(err, serviceerrors.ErrInvalidCreateMission):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_mission", "mission fields are invalid")
+	case errors.Is(err, serviceerrors.ErrInvalidGoalName):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_name", "invalid goal name")
+	case errors.Is(err, serviceerrors.ErrInvalidCountry):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_country", "country must be an ISO 3166-1 alpha-2, alpha-3 code or English name")
+	case errors.Is(err, serviceerrors.ErrInvalidPriority):
+		httperror.RespondError(c, http.StatusBadRequest, "invalid_priority", "priority must be between 1 and 5")
+	default:
+		log.Error().Err(err).Msg(msg)
+		httperror.RespondError(c, http.StatusInternalServerError, "internal", "internal server error")
+	}
+}
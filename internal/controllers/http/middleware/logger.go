@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,7 +17,10 @@ type bodyLogWriter struct {
 }
 
 func (w bodyLogWriter) Write(b []byte) (int, error) {
-	w.body.Write(b)
+	// streamed exports can be large, keep only JSON bodies for the log
+	if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		w.body.Write(b)
+	}
 	return w.ResponseWriter.Write(b)
 }
 
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
 	goval "github.com/go-playground/validator/v10"
 )
 
@@ -18,6 +20,10 @@ type Validator struct {
 
 func NewValidator() *Validator {
 	v := goval.New()
+	// money is validated by its minor units, so "required" means non-zero
+	v.RegisterCustomTypeFunc(func(f reflect.Value) any {
+		return f.Interface().(domain.Money).Amount
+	}, domain.Money{})
 	return &Validator{
 		validator: v,
 	}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+type AutoAssignOutcome string
+
+const (
+	AutoAssigned AutoAssignOutcome = "assigned"
+	AutoSkipped  AutoAssignOutcome = "skipped"
+	AutoFailed   AutoAssignOutcome = "failed"
+)
+
+// AutoAssignment is one decision taken by the assignment scheduler.
+type AutoAssignment struct {
+	ID        int64
+	MissionID int64
+	CatID     *int64
+	Policy    string
+	Score     *float64
+	Outcome   AutoAssignOutcome
+	Detail    string
+	CreatedAt time.Time
+}
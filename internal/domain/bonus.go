@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Risk levels of a country, from the safest to the most dangerous.
+const (
+	MinRiskLevel = 1
+	MaxRiskLevel = 5
+)
+
+// CountryRisk is the risk level of an ISO 3166-1 alpha-2 country.
+type CountryRisk struct {
+	Country   string
+	Level     int
+	UpdatedAt time.Time
+}
+
+type BonusKind string
+
+const (
+	// BonusFlat pays Amount once per completed mission.
+	BonusFlat BonusKind = "flat"
+	// BonusPerGoal pays Amount per done goal.
+	BonusPerGoal BonusKind = "per_goal"
+	// BonusCountryRisk pays Amount per done goal in a country of RiskLevel.
+	BonusCountryRisk BonusKind = "country_risk"
+)
+
+func (k BonusKind) IsValid() bool {
+	switch k {
+	case BonusFlat, BonusPerGoal, BonusCountryRisk:
+		return true
+	}
+	return false
+}
+
+// BonusRule describes what the assigned cat earns when a mission completes.
+// RiskLevel is set only for BonusCountryRisk. Inactive rules award nothing.
+type BonusRule struct {
+	ID        int64
+	Name      string
+	Kind      BonusKind
+	Amount    Money
+	RiskLevel *int
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (r BonusRule) IsValid() bool {
+	name := strings.TrimSpace(r.Name)
+	if name == "" || len(name) > 64 || !r.Kind.IsValid() {
+		return false
+	}
+	if r.Amount.Amount <= 0 || r.Amount.Cmp(MaxSalary) > 0 {
+		return false
+	}
+	if r.Kind == BonusCountryRisk {
+		return r.RiskLevel != nil && *r.RiskLevel >= MinRiskLevel && *r.RiskLevel <= MaxRiskLevel
+	}
+	return r.RiskLevel == nil
+}
+
+// Bonus is an amount awarded to a cat for a completed mission. RuleID is nil
+// once the rule is deleted; RuleName and Amount keep what was awarded.
+type Bonus struct {
+	ID        int64
+	CatID     int64
+	MissionID int64
+	RuleID    *int64
+	RuleName  string
+	Kind      BonusKind
+	Quantity  int
+	Amount    Money
+	AwardedAt time.Time
+}
+
+// AwardBonuses applies the active rules to a completed mission. Only done
+// goals count, and risk maps alpha-2 countries to their risk level; a country
+// without a level earns no risk bonus. Bonuses go to the assigned cat, the
+// lead, only: support and lookout team members earn none, and a mission
+// without an assigned cat awards nothing.
+func AwardBonuses(m Mission, goals []MissionGoal, rules []BonusRule, risk map[string]int) []Bonus {
+	if m.CatID == nil {
+		return nil
+	}
+
+	done := 0
+	byLevel := make(map[int]int)
+	for _, g := range goals {
+		if g.Status != GoalDone {
+			continue
+		}
+		done++
+		if level, ok := risk[g.Country]; ok {
+			byLevel[level]++
+		}
+	}
+
+	var out []Bonus
+	for _, r := range rules {
+		if !r.Active {
+			continue
+		}
+		n := 0
+		switch r.Kind {
+		case BonusFlat:
+			n = 1
+		case BonusPerGoal:
+			n = done
+		case BonusCountryRisk:
+			if r.RiskLevel != nil {
+				n = byLevel[*r.RiskLevel]
+			}
+		}
+		if n == 0 {
+			continue
+		}
+
+		id := r.ID
+		out = append(out, Bonus{
+			CatID:     *m.CatID,
+			MissionID: m.ID,
+			RuleID:    &id,
+			RuleName:  r.Name,
+			Kind:      r.Kind,
+			Quantity:  n,
+			Amount:    r.Amount.MulFrac(int64(n), 1),
+		})
+	}
+	return out
+}
+
+// BonusTotals sums bonuses per currency, ordered by currency code.
+func BonusTotals(items []Bonus) []Money {
+	amounts := make([]Money, 0, len(items))
+	for _, b := range items {
+		amounts = append(amounts, b.Amount)
+	}
+	return SumByCurrency(amounts)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import "testing"
+
+func TestAwardBonuses(t *testing.T) {
+	catID := int64(7)
+	one, three, five := 1, 3, 5
+	m := Mission{ID: 1, CatID: &catID, Status: StatusCompleted}
+	goals := []MissionGoal{
+		{Country: "UA", Status: GoalDone},
+		{Country: "SY", Status: GoalDone},
+		{Country: "SY", Status: GoalDone},
+		{Country: "SY", Status: GoalFailed},
+		{Country: "FR", Status: GoalSkipped},
+	}
+	risk := map[string]int{"UA": 3, "SY": 5, "FR": 1}
+	rules := []BonusRule{
+		{ID: 1, Name: "completion", Kind: BonusFlat, Amount: NewMoney(100, 0), Active: true},
+		{ID: 2, Name: "goals", Kind: BonusPerGoal, Amount: Money{Amount: 1050, Currency: "EUR"}, Active: true},
+		{ID: 3, Name: "hot zone", Kind: BonusCountryRisk, Amount: NewMoney(200, 0), RiskLevel: &five, Active: true},
+		{ID: 4, Name: "medium", Kind: BonusCountryRisk, Amount: NewMoney(50, 0), RiskLevel: &three, Active: true},
+		{ID: 5, Name: "retired", Kind: BonusFlat, Amount: NewMoney(999, 0)},
+		{ID: 6, Name: "low", Kind: BonusCountryRisk, Amount: NewMoney(10, 0), RiskLevel: &one, Active: true},
+	}
+
+	got := AwardBonuses(m, goals, rules, risk)
+	want := []struct {
+		rule     int64
+		quantity int
+		amount   Money
+	}{
+		{rule: 1, quantity: 1, amount: NewMoney(100, 0)},
+		{rule: 2, quantity: 3, amount: Money{Amount: 3150, Currency: "EUR"}},
+		{rule: 3, quantity: 2, amount: NewMoney(400, 0)},
+		{rule: 4, quantity: 1, amount: NewMoney(50, 0)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d bonuses, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		b := got[i]
+		if *b.RuleID != w.rule || b.Quantity != w.quantity || b.Amount != w.amount || b.CatID != catID || b.MissionID != 1 {
+			t.Fatalf("bonus %d = %+v, want rule %d x%d = %v", i, b, w.rule, w.quantity, w.amount)
+		}
+	}
+
+	if got := AwardBonuses(Mission{ID: 2}, goals, rules, risk); got != nil {
+		t.Fatalf("mission without a cat must award nothing, got %+v", got)
+	}
+
+	totals := BonusTotals(got)
+	if len(totals) != 2 || totals[0] != (Money{Amount: 3150, Currency: "EUR"}) || totals[1] != NewMoney(550, 0) {
+		t.Fatalf("unexpected totals: %+v", totals)
+	}
+}
+
+func TestBonusRuleIsValid(t *testing.T) {
+	level := 2
+	tests := []struct {
+		name string
+		rule BonusRule
+		want bool
+	}{
+		{name: "flat", rule: BonusRule{Name: "done", Kind: BonusFlat, Amount: NewMoney(10, 0)}, want: true},
+		{name: "country risk", rule: BonusRule{Name: "risk", Kind: BonusCountryRisk, Amount: NewMoney(10, 0), RiskLevel: &level}, want: true},
+		{name: "risk level missing", rule: BonusRule{Name: "risk", Kind: BonusCountryRisk, Amount: NewMoney(10, 0)}},
+		{name: "risk level on flat", rule: BonusRule{Name: "done", Kind: BonusFlat, Amount: NewMoney(10, 0), RiskLevel: &level}},
+		{name: "zero amount", rule: BonusRule{Name: "done", Kind: BonusFlat}},
+		{name: "unknown kind", rule: BonusRule{Name: "done", Kind: "daily", Amount: NewMoney(10, 0)}},
+		{name: "blank name", rule: BonusRule{Name: " ", Kind: BonusFlat, Amount: NewMoney(10, 0)}},
+	}
+	for _, tc := range tests {
+		if got := tc.rule.IsValid(); got != tc.want {
+			t.Fatalf("%s: IsValid=%v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
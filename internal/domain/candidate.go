@@ -0,0 +1,44 @@
+package domain
+
+// BreedTraits are 1..5 ratings of a breed; Known is false when the breed was not found.
+type BreedTraits struct {
+	Known        bool
+	Intelligence int
+	Adaptability int
+	EnergyLevel  int
+}
+
+// CandidateStats is the raw data the recommendation is computed from.
+type CandidateStats struct {
+	Cat Cat
+	// Busy is set when the cat is on an active or paused mission.
+	Busy bool
+	// Planned is set when the cat is already on a planned mission.
+	Planned bool
+	// CountryGoalsDone and CountryGoalsTotal count goals in the mission's countries
+	// on finished missions the cat was part of.
+	CountryGoalsDone  int
+	CountryGoalsTotal int
+}
+
+// ScoreBreakdown shows how much every factor contributed to a candidate's score.
+type ScoreBreakdown struct {
+	Experience     float64
+	BreedTraits    float64
+	Workload       float64
+	CountrySuccess float64
+	SalaryCost     float64
+}
+
+type Candidate struct {
+	Cat     Cat
+	Busy    bool
+	Planned bool
+	// BaseSalary is the salary in DefaultCurrency, nil when no exchange rate
+	// is stored for the cat's currency.
+	BaseSalary  *Money
+	Traits      BreedTraits
+	SuccessRate *float64
+	Score       float64
+	Breakdown   ScoreBreakdown
+}
@@ -1,21 +1,44 @@
 package domain
 
+import "time"
+
 type Cat struct {
 	ID              int64
 	Name            string
 	YearsExperience int64
 	Breed           string
-	Salary          float64
+	Salary          Money
 }
 type ListCatsParams struct {
 	Name     *string
 	Breed    *string
 	MinYears *int
 	MaxYears *int
-	Limit    int
-	Offset   int
+	// Sort orders the list by CatSortFields; empty means newest first.
+	Sort   []SortField
+	Limit  int
+	Offset int
+	// Currency converts salaries for reporting; empty keeps each cat's own.
+	Currency string
 }
 type UpdateSalaryParams struct {
 	ID     int64
-	Salary float64
+	Salary Money
+	// EffectiveFrom defaults to today. It may be backdated, but not before
+	// the latest ledger entry of the cat.
+	EffectiveFrom *time.Time
+	Reason        string
+	Actor         string
+	// Override accepts a salary outside the cat's band; Reason is required.
+	Override bool
+}
+
+type CatImportRow struct {
+	Line int
+	Cat  Cat
+}
+type CatImportResult struct {
+	Line int
+	ID   int64
+	Err  error
 }
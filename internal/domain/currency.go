@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"math/big"
+	"strings"
+	"time"
+)
+
+// RateDecimals is the precision exchange rates are stored with.
+const RateDecimals = 8
+
+// NormalizeCurrency upper-cases an ISO 4217 code. Only the shape is checked;
+// a currency nobody has a rate for simply cannot be converted.
+func NormalizeCurrency(s string) (string, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) != 3 {
+		return "", false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return "", false
+		}
+	}
+	return s, true
+}
+
+// ExchangeRate says that one unit of From is worth Rate units of To.
+type ExchangeRate struct {
+	From      string
+	To        string
+	Rate      *big.Rat
+	UpdatedAt time.Time
+}
+
+// ParseRate reads a positive decimal with at most RateDecimals decimals.
+func ParseRate(s string) (*big.Rat, bool) {
+	s = strings.TrimSpace(s)
+	whole, frac, hasDot := strings.Cut(s, ".")
+	if whole == "" || len(whole) > 10 || len(frac) > RateDecimals || (hasDot && frac == "") || !digits(whole) || !digits(frac) {
+		return nil, false
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() <= 0 {
+		return nil, false
+	}
+	return r, true
+}
+
+// FormatRate writes r without trailing zeros, e.g. "1.0842".
+func FormatRate(r *big.Rat) string {
+	s := r.FloatString(RateDecimals)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// RateTable converts between currencies using the stored rates. A pair
+// without a rate falls back to the inverse rate, then to a conversion
+// through DefaultCurrency.
+type RateTable map[[2]string]*big.Rat
+
+func NewRateTable(rates []ExchangeRate) RateTable {
+	t := make(RateTable, len(rates))
+	for _, r := range rates {
+		t[[2]string{r.From, r.To}] = r.Rate
+	}
+	return t
+}
+
+func (t RateTable) Rate(from, to string) (*big.Rat, bool) {
+	if r, ok := t.pair(from, to); ok {
+		return r, true
+	}
+	if from == DefaultCurrency || to == DefaultCurrency {
+		return nil, false
+	}
+	a, ok := t.pair(from, DefaultCurrency)
+	if !ok {
+		return nil, false
+	}
+	b, ok := t.pair(DefaultCurrency, to)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).Mul(a, b), true
+}
+
+func (t RateTable) pair(from, to string) (*big.Rat, bool) {
+	if from == to {
+		return big.NewRat(1, 1), true
+	}
+	if r, ok := t[[2]string{from, to}]; ok {
+		return r, true
+	}
+	if r, ok := t[[2]string{to, from}]; ok {
+		return new(big.Rat).Inv(r), true
+	}
+	return nil, false
+}
+
+// Convert returns m in currency to, rounded half away from zero to the cent.
+func (t RateTable) Convert(m Money, to string) (Money, bool) {
+	r, ok := t.Rate(m.currency(), to)
+	if !ok {
+		return Money{}, false
+	}
+
+	v := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), r)
+	q, rem := new(big.Int).QuoRem(v.Num(), v.Denom(), new(big.Int))
+	// |rem|/den >= 1/2 rounds away from zero
+	if rem.Abs(rem).Lsh(rem, 1).Cmp(v.Denom()) >= 0 {
+		if v.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if !q.IsInt64() {
+		return Money{}, false
+	}
+	return Money{Amount: q.Int64(), Currency: to}, true
+}
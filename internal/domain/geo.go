@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxGoalRadius caps the area around a goal, in meters.
+const MaxGoalRadius = 1_000_000
+
+// GoalLocation pins a goal to a point, optionally with a radius in meters
+// describing the area the target moves in.
+type GoalLocation struct {
+	Lat    float64
+	Lon    float64
+	Radius *int
+}
+
+func (l GoalLocation) IsValid() bool {
+	if !validLat(l.Lat) || !validLon(l.Lon) {
+		return false
+	}
+	return l.Radius == nil || (*l.Radius > 0 && *l.Radius <= MaxGoalRadius)
+}
+
+// BBox is a bounding box in WGS84 degrees. MinLon greater than MaxLon means
+// the box crosses the antimeridian.
+type BBox struct {
+	MinLon, MinLat, MaxLon, MaxLat float64
+}
+
+// ParseBBox reads "minLon,minLat,maxLon,maxLat", the order used by GeoJSON.
+func ParseBBox(s string) (BBox, bool) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return BBox{}, false
+	}
+
+	var v [4]float64
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return BBox{}, false
+		}
+		v[i] = f
+	}
+
+	b := BBox{MinLon: v[0], MinLat: v[1], MaxLon: v[2], MaxLat: v[3]}
+	if !validLon(b.MinLon) || !validLon(b.MaxLon) || !validLat(b.MinLat) || !validLat(b.MaxLat) || b.MinLat > b.MaxLat {
+		return BBox{}, false
+	}
+	return b, true
+}
+
+// CrossesAntimeridian reports whether the box wraps around longitude 180.
+func (b BBox) CrossesAntimeridian() bool {
+	return b.MinLon > b.MaxLon
+}
+
+func (b BBox) Contains(lat, lon float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return lon >= b.MinLon || lon <= b.MaxLon
+	}
+	return lon >= b.MinLon && lon <= b.MaxLon
+}
+
+func validLat(v float64) bool {
+	return !math.IsNaN(v) && v >= -90 && v <= 90
+}
+
+func validLon(v float64) bool {
+	return !math.IsNaN(v) && v >= -180 && v <= 180
+}
@@ -9,80 +9,346 @@ type MissionStatus string
 const (
 	StatusPlanned   MissionStatus = "planned"
 	StatusActive    MissionStatus = "active"
+	StatusPaused    MissionStatus = "paused"
 	StatusCompleted MissionStatus = "completed"
+	StatusAborted   MissionStatus = "aborted"
+	StatusFailed    MissionStatus = "failed"
+)
+
+// Priority ranges from 1 (most urgent) to 5 (least urgent).
+const (
+	PriorityHighest = 1
+	PriorityDefault = 3
+	PriorityLowest  = 5
 )
 
 type Mission struct {
-	ID          int64
-	Title       string
-	Description string
-	Status      MissionStatus
-	CatID       *int64
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           int64
+	Title        string
+	Description  string
+	Status       MissionStatus
+	StatusReason string
+	CatID        *int64
+	DueAt        *time.Time
+	Priority     int
+	StartedAt    *time.Time
+	CompletedAt  *time.Time
+	Occurrence   *Occurrence
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 type MissionGoalStatus string
 
 const (
-	GoalTodo MissionGoalStatus = "todo"
-	GoalDone MissionGoalStatus = "done"
+	GoalTodo       MissionGoalStatus = "todo"
+	GoalInProgress MissionGoalStatus = "in_progress"
+	GoalDone       MissionGoalStatus = "done"
+	GoalFailed     MissionGoalStatus = "failed"
+	GoalSkipped    MissionGoalStatus = "skipped"
 )
 
+// goalTransitions lists the allowed next states of a goal. Failed and skipped
+// goals can be reopened; a done goal stays done.
+var goalTransitions = map[MissionGoalStatus][]MissionGoalStatus{
+	GoalTodo:       {GoalInProgress, GoalDone, GoalSkipped},
+	GoalInProgress: {GoalTodo, GoalDone, GoalFailed, GoalSkipped},
+	GoalDone:       {},
+	GoalFailed:     {GoalTodo},
+	GoalSkipped:    {GoalTodo},
+}
+
+func (s MissionGoalStatus) IsValid() bool {
+	_, ok := goalTransitions[s]
+	return ok
+}
+
+// IsResolved reports whether the goal needs no more work.
+func (s MissionGoalStatus) IsResolved() bool {
+	return s == GoalDone || s == GoalFailed || s == GoalSkipped
+}
+
+// NeedsActiveMission reports whether moving a goal into s means work is being
+// done, which only happens on an active mission.
+func (s MissionGoalStatus) NeedsActiveMission() bool {
+	return s == GoalInProgress || s == GoalDone || s == GoalFailed
+}
+
+func CanTransitionGoal(from, to MissionGoalStatus) bool {
+	for _, next := range goalTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// GoalProgress counts the goals of a mission per state.
+type GoalProgress struct {
+	Total      int
+	Todo       int
+	InProgress int
+	Done       int
+	Failed     int
+	Skipped    int
+}
+
+func NewGoalProgress(goals []MissionGoal) GoalProgress {
+	p := GoalProgress{Total: len(goals)}
+	for _, g := range goals {
+		switch g.Status {
+		case GoalTodo:
+			p.Todo++
+		case GoalInProgress:
+			p.InProgress++
+		case GoalDone:
+			p.Done++
+		case GoalFailed:
+			p.Failed++
+		case GoalSkipped:
+			p.Skipped++
+		}
+	}
+	return p
+}
+
+// Percent is the share of goals resolved one way or another, 0..100.
+// A mission without goals has made no progress.
+func (p GoalProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return (p.Done + p.Failed + p.Skipped) * 100 / p.Total
+}
+
 type MissionGoal struct {
-	ID        int64
+	ID            int64
+	MissionID     int64
+	Name          string
+	Country       string
+	Notes         string
+	Status        MissionGoalStatus
+	Position      int
+	Location      *GoalLocation
+	DoneByCatID   *int64
+	AssigneeCatID *int64
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+type UpdateGoalStatusParams struct {
 	MissionID int64
-	Name      string
-	Country   string
-	Notes     string
+	GoalID    int64
 	Status    MissionGoalStatus
-	CreatedAt time.Time
-	UpdatedAt time.Time
 }
 type CreateMissionParams struct {
 	Title       string
 	Description string
 	Status      MissionStatus
+	DueAt       *time.Time
+	Priority    *int
 	Goals       []CreateGoalParams
+	// Occurrence is set when a recurring schedule materialises the mission.
+	Occurrence *Occurrence
+}
+type CloneMissionParams struct {
+	ID int64
+	// Title and DueAt override the values of the new mission when set.
+	Title      *string
+	DueAt      *time.Time
+	Occurrence *Occurrence
 }
 type CreateGoalParams struct {
-	Name    string
-	Country string
-	Notes   string
+	Name     string
+	Country  string
+	Notes    string
+	Location *GoalLocation
 }
 type MissionFilter struct {
 	Status *MissionStatus
 	CatID  *int64
-	Q      *string
+	// MemberCatID matches missions where the cat holds any team role.
+	MemberCatID *int64
+	Q           *string
+	Priority    *int
+	DueBefore   *time.Time
+	DueAfter    *time.Time
+	// Overdue selects active missions whose deadline has passed.
+	Overdue bool
+	// BBox selects missions with at least one goal located inside the box.
+	BBox *BBox
+	// Sort orders the list by MissionSortFields; empty means newest first.
+	Sort []SortField
 	//pagination
 	Limit  int
 	Offset int
 }
 
+type SearchParams struct {
+	// Q uses web search syntax: quoted phrases, "or" and a leading "-" to exclude.
+	Q      string
+	Status *MissionStatus
+	Limit  int
+	Offset int
+}
+
+// SearchHit is a mission matched by full-text search. TitleHighlight and
+// Snippet are HTML: the stored text is escaped and matched terms are wrapped
+// in <mark> tags.
+type SearchHit struct {
+	ID             int64
+	Title          string
+	Status         MissionStatus
+	CatID          *int64
+	Rank           float64
+	TitleHighlight string
+	Snippet        string
+	CreatedAt      time.Time
+}
+
 type MissionListItem struct {
 	ID        int64
 	Title     string
 	Status    MissionStatus
 	CatID     *int64
+	DueAt     *time.Time
+	Priority  int
+	CreatedAt time.Time
+}
+type TeamRole string
+
+const (
+	RoleLead    TeamRole = "lead"
+	RoleSupport TeamRole = "support"
+	RoleLookout TeamRole = "lookout"
+)
+
+func (r TeamRole) IsValid() bool {
+	switch r {
+	case RoleLead, RoleSupport, RoleLookout:
+		return true
+	default:
+		return false
+	}
+}
+
+type MissionAssignment struct {
+	MissionID  int64
+	CatID      int64
+	Role       TeamRole
+	AssignedAt time.Time
+}
+
+// CatMission is a mission seen from one of its team members.
+type CatMission struct {
+	Mission MissionListItem
+	Role    TeamRole
+}
+type AssignMissionParams struct {
+	MissionID int64
+	CatID     *int64
+	// Note is required when a mission in progress changes hands.
+	Note string
+	// OnlyIfUnassigned refuses the assignment unless the mission is still
+	// planned and has no cat, checked with the mission row locked.
+	OnlyIfUnassigned bool
+}
+
+// MissionHandover records a mission in progress passing from one cat to another.
+type MissionHandover struct {
+	ID        int64
+	MissionID int64
+	FromCatID *int64
+	ToCatID   *int64
+	Note      string
 	CreatedAt time.Time
 }
 type UpdateMissionStatusParams struct {
 	ID     int64
 	Status MissionStatus
+	Reason string
+}
+
+// missionTransitions lists, for every status, the statuses a mission may move to next.
+// A status with no entries is terminal.
+var missionTransitions = map[MissionStatus][]MissionStatus{
+	StatusPlanned:   {StatusActive, StatusAborted},
+	StatusActive:    {StatusPaused, StatusCompleted, StatusAborted, StatusFailed},
+	StatusPaused:    {StatusActive, StatusAborted, StatusFailed},
+	StatusCompleted: {},
+	StatusAborted:   {},
+	StatusFailed:    {},
+}
+
+// reasonRequired marks terminal non-success statuses that must carry an explanation.
+var reasonRequired = map[MissionStatus]bool{
+	StatusAborted: true,
+	StatusFailed:  true,
+}
+
+func (s MissionStatus) IsValid() bool {
+	_, ok := missionTransitions[s]
+	return ok
+}
+
+func (s MissionStatus) IsTerminal() bool {
+	next, ok := missionTransitions[s]
+	return ok && len(next) == 0
+}
+
+func (s MissionStatus) RequiresReason() bool {
+	return reasonRequired[s]
+}
+
+// AllowedTransitions returns the statuses reachable from the given one.
+func AllowedTransitions(from MissionStatus) []MissionStatus {
+	next := missionTransitions[from]
+	out := make([]MissionStatus, len(next))
+	copy(out, next)
+	return out
 }
 
 func CanTransition(from, to MissionStatus) bool {
-	switch from {
+	for _, next := range missionTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
 
-	case StatusPlanned:
-		return to == StatusActive
+// MissionDependency says MissionID cannot start before DependsOnID is completed.
+type MissionDependency struct {
+	MissionID   int64
+	DependsOnID int64
+	CreatedAt   time.Time
+}
+type DependencyNode struct {
+	ID     int64
+	Title  string
+	Status MissionStatus
+}
 
-	case StatusActive:
-		return to == StatusCompleted
+// DependencyGraph holds every mission reachable from MissionID in either
+// direction and the edges between them.
+type DependencyGraph struct {
+	MissionID int64
+	Nodes     []DependencyNode
+	Edges     []MissionDependency
+}
 
-	case StatusCompleted:
-		return false
+// Blocking returns the direct dependencies of the root that are not completed yet.
+func (g DependencyGraph) Blocking() []int64 {
+	status := make(map[int64]MissionStatus, len(g.Nodes))
+	for _, n := range g.Nodes {
+		status[n.ID] = n.Status
+	}
 
-	default:
-		return false
+	var out []int64
+	for _, e := range g.Edges {
+		if e.MissionID == g.MissionID && status[e.DependsOnID] != StatusCompleted {
+			out = append(out, e.DependsOnID)
+		}
 	}
+	return out
 }
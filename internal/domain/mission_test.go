@@ -0,0 +1,155 @@
+package domain
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		from MissionStatus
+		to   MissionStatus
+		want bool
+	}{
+		{name: "planned -> active", from: StatusPlanned, to: StatusActive, want: true},
+		{name: "planned -> completed", from: StatusPlanned, to: StatusCompleted, want: false},
+		{name: "active -> paused", from: StatusActive, to: StatusPaused, want: true},
+		{name: "paused -> active", from: StatusPaused, to: StatusActive, want: true},
+		{name: "paused -> completed", from: StatusPaused, to: StatusCompleted, want: false},
+		{name: "active -> failed", from: StatusActive, to: StatusFailed, want: true},
+		{name: "completed -> active", from: StatusCompleted, to: StatusActive, want: false},
+		{name: "aborted -> planned", from: StatusAborted, to: StatusPlanned, want: false},
+		{name: "unknown -> active", from: MissionStatus("lost"), to: StatusActive, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := CanTransition(tc.from, tc.to); got != tc.want {
+				t.Fatalf("CanTransition(%s, %s)=%v, want %v", tc.from, tc.to, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMissionStatus_TerminalAndReason(t *testing.T) {
+	t.Parallel()
+
+	for _, st := range []MissionStatus{StatusCompleted, StatusAborted, StatusFailed} {
+		if !st.IsTerminal() {
+			t.Fatalf("%s must be terminal", st)
+		}
+		if len(AllowedTransitions(st)) != 0 {
+			t.Fatalf("%s must have no outgoing transitions", st)
+		}
+	}
+	for _, st := range []MissionStatus{StatusPlanned, StatusActive, StatusPaused} {
+		if st.IsTerminal() {
+			t.Fatalf("%s must not be terminal", st)
+		}
+	}
+
+	if !StatusAborted.RequiresReason() || !StatusFailed.RequiresReason() {
+		t.Fatalf("aborted and failed must require a reason")
+	}
+	if StatusCompleted.RequiresReason() {
+		t.Fatalf("completed must not require a reason")
+	}
+}
+
+func TestDependencyGraph_Blocking(t *testing.T) {
+	t.Parallel()
+
+	g := DependencyGraph{
+		MissionID: 1,
+		Nodes: []DependencyNode{
+			{ID: 1, Status: StatusPlanned},
+			{ID: 2, Status: StatusCompleted},
+			{ID: 3, Status: StatusActive},
+			{ID: 4, Status: StatusPlanned},
+		},
+		Edges: []MissionDependency{
+			{MissionID: 1, DependsOnID: 2},
+			{MissionID: 1, DependsOnID: 3},
+			// indirect dependencies do not block directly
+			{MissionID: 3, DependsOnID: 4},
+		},
+	}
+
+	got := g.Blocking()
+	if len(got) != 1 || got[0] != 3 {
+		t.Fatalf("want [3], got %v", got)
+	}
+}
+
+func TestGoalLifecycleAndProgress(t *testing.T) {
+	t.Parallel()
+
+	if !CanTransitionGoal(GoalTodo, GoalInProgress) || !CanTransitionGoal(GoalFailed, GoalTodo) {
+		t.Fatal("expected transitions to be allowed")
+	}
+	if CanTransitionGoal(GoalDone, GoalTodo) || CanTransitionGoal(GoalTodo, GoalFailed) {
+		t.Fatal("expected transitions to be rejected")
+	}
+
+	p := NewGoalProgress([]MissionGoal{
+		{Status: GoalDone},
+		{Status: GoalFailed},
+		{Status: GoalSkipped},
+		{Status: GoalInProgress},
+	})
+	if p.Percent() != 75 || p.InProgress != 1 || p.Total != 4 {
+		t.Fatalf("unexpected progress %+v (%d%%)", p, p.Percent())
+	}
+	if (GoalProgress{}).Percent() != 0 {
+		t.Fatal("mission without goals must report 0%")
+	}
+}
+
+func TestParseBBoxAndLocation(t *testing.T) {
+	t.Parallel()
+
+	b, ok := ParseBBox("22.1, 44.3, 40.2, 52.4")
+	if !ok || !b.Contains(50.45, 30.52) || b.Contains(52.52, 13.40) {
+		t.Fatalf("bbox around Ukraine parsed as %+v, ok=%v", b, ok)
+	}
+
+	wrap, ok := ParseBBox("170,-20,-170,0")
+	if !ok || !wrap.CrossesAntimeridian() || !wrap.Contains(-10, 179) || wrap.Contains(-10, 0) {
+		t.Fatalf("antimeridian bbox parsed as %+v, ok=%v", wrap, ok)
+	}
+
+	for _, s := range []string{"", "1,2,3", "0,10,1,5", "0,0,181,1", "a,b,c,d"} {
+		if _, ok := ParseBBox(s); ok {
+			t.Errorf("ParseBBox(%q) accepted", s)
+		}
+	}
+
+	radius, zero := 500, 0
+	if !(GoalLocation{Lat: -90, Lon: 180, Radius: &radius}).IsValid() {
+		t.Error("edge coordinates rejected")
+	}
+	if (GoalLocation{Lat: 91, Lon: 0}).IsValid() || (GoalLocation{Lat: 0, Lon: 0, Radius: &zero}).IsValid() {
+		t.Error("out of range location accepted")
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	t.Parallel()
+
+	got, ok := ParseSort(" -updated_at, title ", MissionSortFields)
+	if !ok || len(got) != 2 || got[0] != (SortField{Name: "updated_at", Desc: true}) || got[1] != (SortField{Name: "title"}) {
+		t.Fatalf("ParseSort = %+v, %v", got, ok)
+	}
+
+	if got, ok := ParseSort("", CatSortFields); !ok || got != nil {
+		t.Fatalf("empty sort = %+v, %v; want default", got, ok)
+	}
+
+	for _, s := range []string{"salary;drop", "name,-name", "title", "-", "a,b,c,d,e", "id,"} {
+		if _, ok := ParseSort(s, CatSortFields); ok {
+			t.Errorf("ParseSort(%q) accepted", s)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package domain
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency of amounts that do not state one.
+const DefaultCurrency = "USD"
+
+// Money is an exact amount in minor units (cents) of an ISO 4217 currency.
+// It replaces float64 wherever salaries are handled so values like 2139.10
+// survive the trip between JSON, Go and NUMERIC(12,2) unchanged.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// MaxSalary is the highest salary a cat can be paid.
+var MaxSalary = Money{Amount: 1_000_000_00, Currency: DefaultCurrency}
+
+// IsValidSalary reports whether m is within 0 and MaxSalary. A salary in
+// another currency is converted with t first, so the cap is the same amount
+// of money in every currency; one that cannot be converted is not valid.
+func IsValidSalary(m Money, t RateTable) bool {
+	if m.IsNegative() {
+		return false
+	}
+	v, ok := t.Convert(m, MaxSalary.Currency)
+	return ok && v.Cmp(MaxSalary) <= 0
+}
+
+// NewMoney builds an amount from whole units and cents, e.g. NewMoney(2139, 10).
+func NewMoney(units, cents int64) Money {
+	if units < 0 {
+		cents = -cents
+	}
+	return Money{Amount: units*100 + cents, Currency: DefaultCurrency}
+}
+
+// ParseMoney reads a decimal such as "2139.1" or "-0.05" with at most two
+// decimal places. Exponents, thousands separators and more precision are
+// rejected rather than rounded.
+func ParseMoney(s string) (Money, bool) {
+	s = strings.TrimSpace(s)
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	whole, frac, hasDot := strings.Cut(s, ".")
+	if whole == "" || len(frac) > 2 || (hasDot && frac == "") || !digits(whole) || !digits(frac) {
+		return Money{}, false
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/100-1 {
+		return Money{}, false
+	}
+	cents, _ := strconv.ParseInt(frac, 10, 64)
+
+	m := Money{Amount: units*100 + cents, Currency: DefaultCurrency}
+	if neg {
+		m.Amount = -m.Amount
+	}
+	return m, true
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the amount with exactly two decimals, without currency.
+func (m Money) String() string {
+	a := m.Amount
+	sign := ""
+	if a < 0 {
+		sign, a = "-", -a
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, a/100, a%100)
+}
+
+// Float64 is for scoring and ratios only, never for stored amounts.
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / 100
+}
+
+func (m Money) IsZero() bool     { return m.Amount == 0 }
+func (m Money) IsNegative() bool { return m.Amount < 0 }
+
+// SameCurrency reports whether m and o can be added or compared. An empty
+// currency is taken as DefaultCurrency.
+func (m Money) SameCurrency(o Money) bool {
+	return m.currency() == o.currency()
+}
+
+func (m Money) currency() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+// Add returns m+o. It reports false when the currencies differ.
+func (m Money) Add(o Money) (Money, bool) {
+	if !m.SameCurrency(o) {
+		return Money{}, false
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.currency()}, true
+}
+
+// Sub returns m-o. It reports false when the currencies differ.
+func (m Money) Sub(o Money) (Money, bool) {
+	return m.Add(o.Neg())
+}
+
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Cmp compares the amounts of m and o, which must share a currency: -1 if
+// m < o, 0 if equal, +1 if m > o.
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	}
+	return 0
+}
+
+// MulFrac returns m*num/den rounded half away from zero to the cent, e.g.
+// a monthly salary prorated over days worked.
+func (m Money) MulFrac(num, den int64) Money {
+	if den == 0 {
+		return Money{Currency: m.Currency}
+	}
+	p := m.Amount * num
+	q, r := p/den, p%den
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= abs(den) {
+		if (p < 0) != (den < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+	return Money{Amount: q, Currency: m.Currency}
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimals, so
+// clients keep reading salaries as numbers.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a string holding one. The digits
+// are read as written, never through float64.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	s := string(b)
+	if unq, err := strconv.Unquote(s); err == nil {
+		s = unq
+	}
+
+	v, ok := ParseMoney(s)
+	if !ok {
+		return fmt.Errorf("invalid amount %s: must be a decimal with at most two decimal places", b)
+	}
+	v.Currency = m.Currency
+	if v.Currency == "" {
+		v.Currency = DefaultCurrency
+	}
+	*m = v
+	return nil
+}
+
+// Value stores the amount as decimal text, which NUMERIC takes without loss.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads a NUMERIC column. The currency is kept when already set.
+func (m *Money) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', 2, 64)
+	case nil:
+		return fmt.Errorf("scan money: NULL, use NullMoney")
+	default:
+		return fmt.Errorf("scan money: unsupported type %T", src)
+	}
+
+	// NUMERIC(12,2) always has two decimals, but sums and casts may not
+	if whole, frac, ok := strings.Cut(s, "."); ok && len(frac) > 2 {
+		if strings.Trim(frac[2:], "0") != "" {
+			return fmt.Errorf("scan money: %q has more than two decimals", s)
+		}
+		s = whole + "." + frac[:2]
+	}
+
+	v, ok := ParseMoney(s)
+	if !ok {
+		return fmt.Errorf("scan money: invalid amount %q", s)
+	}
+	v.Currency = m.currency()
+	*m = v
+	return nil
+}
+
+// NullMoney scans a nullable NUMERIC column.
+type NullMoney struct {
+	Money Money
+	Valid bool
+}
+
+func (n *NullMoney) Scan(src any) error {
+	if src == nil {
+		n.Money, n.Valid = Money{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Money.Scan(src)
+}
+
+func (n NullMoney) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Money.Value()
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in     string
+		want   int64
+		wantOK bool
+	}{
+		{in: "2139.10", want: 213910, wantOK: true},
+		{in: "2139.1", want: 213910, wantOK: true},
+		{in: "0", want: 0, wantOK: true},
+		{in: "-0.05", want: -5, wantOK: true},
+		{in: "1000000", want: 100000000, wantOK: true},
+		{in: "1.005", wantOK: false},
+		{in: "1e3", wantOK: false},
+		{in: "1,000", wantOK: false},
+		{in: "1.", wantOK: false},
+		{in: ".5", wantOK: false},
+		{in: "", wantOK: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := ParseMoney(tc.in)
+			if ok != tc.wantOK {
+				t.Fatalf("ParseMoney(%q) ok=%v, want %v", tc.in, ok, tc.wantOK)
+			}
+			if ok && got.Amount != tc.want {
+				t.Fatalf("ParseMoney(%q)=%d, want %d", tc.in, got.Amount, tc.want)
+			}
+		})
+	}
+}
+
+func TestMoneyJSONAndScan(t *testing.T) {
+	t.Parallel()
+
+	var v struct {
+		Salary Money `json:"salary"`
+	}
+	if err := json.Unmarshal([]byte(`{"salary": 2139.10}`), &v); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if v.Salary.Amount != 213910 || v.Salary.Currency != DefaultCurrency {
+		t.Fatalf("got %+v", v.Salary)
+	}
+	if err := json.Unmarshal([]byte(`{"salary": 0.001}`), &v); err == nil {
+		t.Fatal("three decimals must be rejected")
+	}
+
+	b, err := json.Marshal(NewMoney(2139, 10))
+	if err != nil || string(b) != "2139.10" {
+		t.Fatalf("marshal=%s, %v", b, err)
+	}
+
+	var m Money
+	if err := m.Scan([]byte("49.900000")); err != nil || m.Amount != 4990 {
+		t.Fatalf("scan=%+v, %v", m, err)
+	}
+	if err := m.Scan("1.234"); err == nil {
+		t.Fatal("scan must reject sub-cent values")
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	t.Parallel()
+
+	if got := NewMoney(1000, 0).MulFrac(10, 31); got.Amount != 32258 {
+		t.Fatalf("MulFrac=%d, want 32258", got.Amount)
+	}
+	if got := NewMoney(0, 5).MulFrac(1, 2); got.Amount != 3 {
+		t.Fatalf("half must round away from zero, got %d", got.Amount)
+	}
+	if _, ok := NewMoney(1, 0).Add(Money{Amount: 100, Currency: "EUR"}); ok {
+		t.Fatal("adding different currencies must fail")
+	}
+	if !IsValidSalary(MaxSalary, nil) || IsValidSalary(MaxSalary.Neg(), nil) || IsValidSalary(Money{Amount: MaxSalary.Amount + 1}, nil) {
+		t.Fatal("salary bounds are off")
+	}
+	rates := NewRateTable([]ExchangeRate{{From: "USD", To: "JPY", Rate: big.NewRat(150, 1)}})
+	if !IsValidSalary(Money{Amount: 150 * MaxSalary.Amount, Currency: "JPY"}, rates) ||
+		IsValidSalary(Money{Amount: 150*MaxSalary.Amount + 150, Currency: "JPY"}, rates) {
+		t.Fatal("salary cap must apply to the converted amount")
+	}
+	if IsValidSalary(Money{Amount: 100, Currency: "EUR"}, rates) {
+		t.Fatal("a salary that cannot be converted must not be valid")
+	}
+}
+
+func TestRateTableConvert(t *testing.T) {
+	t.Parallel()
+
+	eurUSD, _ := ParseRate("1.25")
+	usdJPY, _ := ParseRate("150")
+	table := NewRateTable([]ExchangeRate{
+		{From: "EUR", To: "USD", Rate: eurUSD},
+		{From: "USD", To: "JPY", Rate: usdJPY},
+	})
+
+	tests := []struct {
+		name   string
+		in     Money
+		to     string
+		want   int64
+		wantOK bool
+	}{
+		{name: "direct", in: Money{Amount: 1000, Currency: "EUR"}, to: "USD", want: 1250, wantOK: true},
+		{name: "inverse", in: Money{Amount: 1000, Currency: "USD"}, to: "EUR", want: 800, wantOK: true},
+		{name: "through USD", in: Money{Amount: 100, Currency: "EUR"}, to: "JPY", want: 18750, wantOK: true},
+		{name: "same currency", in: Money{Amount: 123, Currency: "GBP"}, to: "GBP", want: 123, wantOK: true},
+		{name: "rounds to the nearest cent", in: Money{Amount: 1, Currency: "USD"}, to: "EUR", want: 1, wantOK: true},
+		{name: "unknown pair", in: Money{Amount: 100, Currency: "GBP"}, to: "USD", wantOK: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := table.Convert(tc.in, tc.to)
+			if ok != tc.wantOK {
+				t.Fatalf("ok=%v, want %v", ok, tc.wantOK)
+			}
+			if ok && (got.Amount != tc.want || got.Currency != tc.to) {
+				t.Fatalf("got %+v, want %d %s", got, tc.want, tc.to)
+			}
+		})
+	}
+
+	if _, ok := ParseRate("0"); ok {
+		t.Fatal("zero rate must be rejected")
+	}
+	if _, ok := ParseRate("1.123456789"); ok {
+		t.Fatal("nine decimals must be rejected")
+	}
+	if got := FormatRate(big.NewRat(271, 250)); got != "1.084" {
+		t.Fatalf("FormatRate=%s", got)
+	}
+}
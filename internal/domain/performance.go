@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// CatPerformance summarises what a cat achieved since Since (all time when nil).
+// Missions count for every cat on the team, whatever the role; goals count for
+// the cat that finished them.
+type CatPerformance struct {
+	CatID             int64
+	Name              string
+	Since             *time.Time
+	MissionsCompleted int
+	MissionsFailed    int
+	GoalsDone         int
+	// AvgCompletionTime is measured from started_at to completed_at of the
+	// completed missions; zero when there are none.
+	AvgCompletionTime time.Duration
+	Countries         []CountryActivity
+}
+type CountryActivity struct {
+	Country   string
+	GoalsDone int
+}
+
+type LeaderboardMetric string
+
+const (
+	MetricMissions LeaderboardMetric = "missions"
+	MetricGoals    LeaderboardMetric = "goals"
+)
+
+func (m LeaderboardMetric) IsValid() bool {
+	return m == MetricMissions || m == MetricGoals
+}
+
+type LeaderboardParams struct {
+	Since  time.Time
+	Metric LeaderboardMetric
+	Limit  int
+}
+type LeaderboardEntry struct {
+	Rank              int
+	CatID             int64
+	Name              string
+	Breed             string
+	MissionsCompleted int
+	GoalsDone         int
+	AvgCompletionTime time.Duration
+}
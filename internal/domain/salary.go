@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// SalaryChange is one entry of the salary ledger. OldSalary is nil for the
+// entry recorded when the cat was created.
+type SalaryChange struct {
+	ID            int64
+	CatID         int64
+	OldSalary     *Money
+	NewSalary     Money
+	EffectiveFrom time.Time
+	Reason        string
+	Actor         string
+	// Overridden marks a salary accepted outside its band.
+	Overridden bool
+	CreatedAt  time.Time
+}
+
+// Payroll is what every cat earned in Month. Salaries are monthly rates; a
+// rate that applies to part of the month is paid for the days it covers.
+// Bonuses are paid in the month they were awarded. Totals holds one sum per
+// currency, ordered by currency code.
+type Payroll struct {
+	Month       time.Time
+	DaysInMonth int
+	Lines       []PayrollLine
+	Totals      []Money
+}
+
+// PayrollLine is what one cat earned in one currency. Amount is Salary plus
+// Bonus.
+type PayrollLine struct {
+	CatID    int64
+	Name     string
+	Breed    string
+	DaysPaid int
+	Salary   Money
+	Bonus    Money
+	Amount   Money
+}
+
+// Tally recomputes Totals from Lines.
+func (p *Payroll) Tally() {
+	amounts := make([]Money, 0, len(p.Lines))
+	for _, l := range p.Lines {
+		amounts = append(amounts, l.Amount)
+	}
+	p.Totals = SumByCurrency(amounts)
+}
+
+// SumByCurrency adds up amounts per currency, ordered by currency code.
+func SumByCurrency(amounts []Money) []Money {
+	sums := make(map[string]int64)
+	for _, m := range amounts {
+		sums[m.currency()] += m.Amount
+	}
+	out := make([]Money, 0, len(sums))
+	for c, a := range sums {
+		out = append(out, Money{Amount: a, Currency: c})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Currency < out[j].Currency })
+	return out
+}
+
+// MonthStart truncates t to the first day of its month in UTC.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Date truncates t to midnight UTC, the precision of effective dates.
+func Date(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// SalaryBand is the salary range allowed for cats with MinYears..MaxYears of
+// experience. A nil MaxYears has no upper bound; an empty Breed applies to
+// every breed. Min and Max share a currency.
+type SalaryBand struct {
+	ID        int64
+	MinYears  int64
+	MaxYears  *int64
+	Breed     string
+	Min       Money
+	Max       Money
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (b SalaryBand) IsValid() bool {
+	if b.MinYears < 0 || (b.MaxYears != nil && *b.MaxYears < b.MinYears) {
+		return false
+	}
+	if !b.Min.SameCurrency(b.Max) || b.Min.IsNegative() || b.Min.Cmp(b.Max) > 0 {
+		return false
+	}
+	return b.Max.Cmp(MaxSalary) <= 0
+}
+
+// Covers reports whether the band applies to a cat.
+func (b SalaryBand) Covers(years int64, breed string) bool {
+	if b.Breed != "" && !strings.EqualFold(b.Breed, strings.TrimSpace(breed)) {
+		return false
+	}
+	return years >= b.MinYears && (b.MaxYears == nil || years <= *b.MaxYears)
+}
+
+// Contains reports whether m is inside the band. m must be in the band's
+// currency.
+func (b SalaryBand) Contains(m Money) bool {
+	return m.Cmp(b.Min) >= 0 && m.Cmp(b.Max) <= 0
+}
+
+// FindBand picks the band for a cat. A band for the cat's breed wins over a
+// band for every breed.
+func FindBand(bands []SalaryBand, years int64, breed string) (SalaryBand, bool) {
+	var (
+		found SalaryBand
+		ok    bool
+	)
+	for _, b := range bands {
+		if !b.Covers(years, breed) {
+			continue
+		}
+		if b.Breed != "" {
+			return b, true
+		}
+		if !ok {
+			found, ok = b, true
+		}
+	}
+	return found, ok
+}
+
+// SalaryOverride lets a salary outside its band through. Reason is required
+// and kept in the salary ledger.
+type SalaryOverride struct {
+	Override bool
+	Reason   string
+}
+
+// OutOfBand is a cat whose current salary is outside its band. Salary is the
+// cat's salary in the band's currency.
+type OutOfBand struct {
+	Cat    Cat
+	Band   SalaryBand
+	Salary Money
+}
+
+// Below reports whether the salary is under the band rather than over it.
+func (o OutOfBand) Below() bool {
+	return o.Salary.Cmp(o.Band.Min) < 0
+}
+
+// Gap is how far the salary is from the nearest end of the band.
+func (o OutOfBand) Gap() Money {
+	if o.Below() {
+		g, _ := o.Band.Min.Sub(o.Salary)
+		return g
+	}
+	g, _ := o.Salary.Sub(o.Band.Max)
+	return g
+}
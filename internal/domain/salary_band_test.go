@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestFindBand(t *testing.T) {
+	three, ten := int64(3), int64(10)
+	bands := []SalaryBand{
+		{ID: 1, MinYears: 0, MaxYears: &three},
+		{ID: 2, MinYears: 4},
+		{ID: 3, MinYears: 0, MaxYears: &ten, Breed: "Siamese"},
+	}
+
+	tests := []struct {
+		years  int64
+		breed  string
+		wantID int64
+		wantOK bool
+	}{
+		{years: 0, breed: "bengal", wantID: 1, wantOK: true},
+		{years: 3, breed: "bengal", wantID: 1, wantOK: true},
+		{years: 40, breed: "bengal", wantID: 2, wantOK: true},
+		{years: 2, breed: " siamese ", wantID: 3, wantOK: true},
+		{years: 11, breed: "siamese", wantID: 2, wantOK: true},
+		{years: -1, breed: "bengal"},
+	}
+	for _, tc := range tests {
+		b, ok := FindBand(bands, tc.years, tc.breed)
+		if ok != tc.wantOK || b.ID != tc.wantID {
+			t.Fatalf("FindBand(%d, %q) = %d, %v; want %d, %v", tc.years, tc.breed, b.ID, ok, tc.wantID, tc.wantOK)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// MissionSchedule materialises a planned mission for every run of a cron
+// expression, either from a template or by cloning a source mission.
+type MissionSchedule struct {
+	ID         int64
+	TemplateID *int64
+	MissionID  *int64
+	Cron       string
+	Timezone   string
+	// LeadTime is how long before a run its mission is created.
+	LeadTime  time.Duration
+	Vars      map[string]string
+	Enabled   bool
+	NextRunAt time.Time
+	LastRunAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Occurrence identifies one run of a schedule. A mission is created at most
+// once per occurrence.
+type Occurrence struct {
+	ScheduleID int64
+	At         time.Time
+}
+type CreateScheduleParams struct {
+	TemplateID *int64
+	MissionID  *int64
+	Cron       string
+	Timezone   string
+	LeadTime   time.Duration
+	Vars       map[string]string
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// MaxSortKeys bounds how many keys a list can be ordered by.
+const MaxSortKeys = 4
+
+// SortField is one key of a list ordering.
+type SortField struct {
+	Name string
+	Desc bool
+}
+
+var (
+	MissionSortFields = []string{"created_at", "updated_at", "due_at", "priority", "started_at", "completed_at", "title", "status"}
+	CatSortFields     = []string{"id", "name", "years_experience", "breed", "salary", "created_at"}
+)
+
+// ParseSort reads a comma separated list of field names, each optionally
+// prefixed with "-" for descending order, e.g. "-updated_at,title". Every
+// field must be in allowed and appear at most once.
+func ParseSort(s string, allowed []string) ([]SortField, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, true
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts) > MaxSortKeys {
+		return nil, false
+	}
+
+	out := make([]SortField, 0, len(parts))
+	seen := make(map[string]struct{}, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		f := SortField{Name: p}
+		if strings.HasPrefix(p, "-") {
+			f = SortField{Name: p[1:], Desc: true}
+		}
+		if !slices.Contains(allowed, f.Name) {
+			return nil, false
+		}
+		if _, dup := seen[f.Name]; dup {
+			return nil, false
+		}
+		seen[f.Name] = struct{}{}
+		out = append(out, f)
+	}
+	return out, true
+}
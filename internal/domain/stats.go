@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Stats is the dashboard snapshot returned by GET /stats.
+type Stats struct {
+	MissionsByStatus map[MissionStatus]int
+	GoalsByStatus    map[MissionGoalStatus]int
+	Cats             int
+	// IdleCats hold no role on a planned, active or paused mission.
+	IdleCats int
+	// AvgMissionDuration is measured from started_at to completed_at over
+	// completed missions; zero when none has completed yet.
+	AvgMissionDuration time.Duration
+	SalaryByBreed      []BreedSalary
+	// CompletionsPerWeek covers the most recent weeks, oldest first, with
+	// empty weeks included.
+	CompletionsPerWeek []WeekCount
+	GeneratedAt        time.Time
+}
+
+// BreedSalary sums the salaries of one breed paid in one currency.
+type BreedSalary struct {
+	Breed string
+	Cats  int
+	Total Money
+}
+type WeekCount struct {
+	WeekStart time.Time
+	Count     int
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"time"
+)
+
+// placeholderRe matches template placeholders such as {{target}}.
+var placeholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+type MissionTemplate struct {
+	ID          int64
+	Name        string
+	Title       string
+	Description string
+	Priority    int
+	Goals       []TemplateGoal
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+type TemplateGoal struct {
+	ID         int64
+	TemplateID int64
+	Position   int
+	Name       string
+	Country    string
+	Notes      string
+}
+type SaveTemplateParams struct {
+	Name        string
+	Title       string
+	Description string
+	Priority    *int
+	Goals       []CreateGoalParams
+}
+type InstantiateTemplateParams struct {
+	TemplateID int64
+	// Vars fill the {{name}} placeholders of the template.
+	Vars       map[string]string
+	Title      *string
+	DueAt      *time.Time
+	Priority   *int
+	Occurrence *Occurrence
+}
+
+// Placeholders returns the sorted names used as {{name}} in the template texts.
+func (t MissionTemplate) Placeholders() []string {
+	texts := []string{t.Title, t.Description}
+	for _, g := range t.Goals {
+		texts = append(texts, g.Name, g.Country, g.Notes)
+	}
+
+	seen := make(map[string]struct{})
+	for _, s := range texts {
+		for _, m := range placeholderRe.FindAllStringSubmatch(s, -1) {
+			seen[m[1]] = struct{}{}
+		}
+	}
+
+	out := make([]string, 0, len(seen))
+	for name := range seen {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// HasPlaceholder reports whether s contains at least one {{name}} placeholder.
+func HasPlaceholder(s string) bool {
+	return placeholderRe.MatchString(s)
+}
+
+// FillPlaceholders replaces every {{name}} in s with vars[name]. Names without a
+// value are left in place and reported in missing.
+func FillPlaceholders(s string, vars map[string]string) (out string, missing []string) {
+	out = placeholderRe.ReplaceAllStringFunc(s, func(m string) string {
+		name := placeholderRe.FindStringSubmatch(m)[1]
+		v, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return m
+		}
+		return v
+	})
+	return out, missing
+}
@@ -0,0 +1,108 @@
+package catapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+type breedValidator interface {
+	IsValid(ctx context.Context, breed string) (bool, error)
+}
+
+type traitsSource interface {
+	Traits(ctx context.Context, breed string) (domain.BreedTraits, error)
+}
+
+type cachedBreed struct {
+	ok        bool
+	expiresAt time.Time
+}
+
+// CachedValidator remembers breed lookups for ttl so repeated checks of the
+// same breed do not hit TheCatAPI. Failed lookups are not cached.
+type CachedValidator struct {
+	next breedValidator
+	ttl  time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedBreed
+}
+
+func NewCachedValidator(next breedValidator, ttl time.Duration) *CachedValidator {
+	return &CachedValidator{
+		next:  next,
+		ttl:   ttl,
+		cache: make(map[string]cachedBreed),
+	}
+}
+
+func (v *CachedValidator) IsValid(ctx context.Context, breed string) (bool, error) {
+	key := normBreed(breed)
+	now := time.Now()
+
+	v.mu.Lock()
+	if e, ok := v.cache[key]; ok && now.Before(e.expiresAt) {
+		v.mu.Unlock()
+		return e.ok, nil
+	}
+	v.mu.Unlock()
+
+	ok, err := v.next.IsValid(ctx, breed)
+	if err != nil {
+		return false, err
+	}
+
+	v.mu.Lock()
+	v.cache[key] = cachedBreed{ok: ok, expiresAt: now.Add(v.ttl)}
+	v.mu.Unlock()
+
+	return ok, nil
+}
+
+type cachedTraits struct {
+	traits    domain.BreedTraits
+	expiresAt time.Time
+}
+
+// CachedTraits remembers breed trait lookups for ttl. Failed lookups are not cached.
+type CachedTraits struct {
+	next traitsSource
+	ttl  time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedTraits
+}
+
+func NewCachedTraits(next traitsSource, ttl time.Duration) *CachedTraits {
+	return &CachedTraits{
+		next:  next,
+		ttl:   ttl,
+		cache: make(map[string]cachedTraits),
+	}
+}
+
+func (t *CachedTraits) Traits(ctx context.Context, breed string) (domain.BreedTraits, error) {
+	key := normBreed(breed)
+	now := time.Now()
+
+	t.mu.Lock()
+	if e, ok := t.cache[key]; ok && now.Before(e.expiresAt) {
+		t.mu.Unlock()
+		return e.traits, nil
+	}
+	t.mu.Unlock()
+
+	traits, err := t.next.Traits(ctx, breed)
+	if err != nil {
+		return domain.BreedTraits{}, err
+	}
+
+	t.mu.Lock()
+	t.cache[key] = cachedTraits{traits: traits, expiresAt: now.Add(t.ttl)}
+	t.mu.Unlock()
+
+	return traits, nil
+}
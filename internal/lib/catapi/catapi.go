@@ -16,8 +16,11 @@ type Client struct {
 	apiKey  string
 }
 type Breed struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Intelligence int    `json:"intelligence"`
+	Adaptability int    `json:"adaptability"`
+	EnergyLevel  int    `json:"energy_level"`
 }
 
 func NewClient(baseURL, apiKey string, timeout time.Duration) *Client {
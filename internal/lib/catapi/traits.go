@@ -0,0 +1,46 @@
+package catapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+// Traits returns the 1..5 trait ratings TheCatAPI publishes for a breed.
+// Unknown breeds come back with Known set to false.
+func (c *Client) Traits(ctx context.Context, breed string) (domain.BreedTraits, error) {
+	q := normBreed(breed)
+	if q == "" {
+		return domain.BreedTraits{}, nil
+	}
+
+	breeds, status, err := c.SearchBreeds(ctx, q)
+	if err != nil {
+		return domain.BreedTraits{}, err
+	}
+	if status != http.StatusOK {
+		return domain.BreedTraits{}, fmt.Errorf("catapi status=%d", status)
+	}
+	if len(breeds) == 0 {
+		return domain.BreedTraits{}, nil
+	}
+
+	// prefer an exact name match over the first fuzzy hit
+	b := breeds[0]
+	for _, cand := range breeds {
+		if strings.EqualFold(cand.Name, q) {
+			b = cand
+			break
+		}
+	}
+
+	return domain.BreedTraits{
+		Known:        true,
+		Intelligence: b.Intelligence,
+		Adaptability: b.Adaptability,
+		EnergyLevel:  b.EnergyLevel,
+	}, nil
+}
@@ -0,0 +1,103 @@
+// Package countries embeds the ISO 3166-1 country list and resolves alpha-2,
+// alpha-3 codes and English names to the canonical alpha-2 code.
+//
+// The data is generated from the Debian iso-codes package; regions follow the
+// UN M49 continental grouping (Africa, Americas, Asia, Europe, Oceania) with
+// Antarctica kept on its own.
+package countries
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+//go:embed countries.csv
+var rawCSV string
+
+type Country struct {
+	Alpha2  string
+	Alpha3  string
+	Numeric string
+	Name    string
+	Region  string
+}
+
+var (
+	all     []Country
+	byAlpha map[string]int
+	byName  map[string]int
+)
+
+func init() {
+	r := csv.NewReader(strings.NewReader(rawCSV))
+	records, err := r.ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("countries: embedded dataset: %v", err))
+	}
+
+	all = make([]Country, 0, len(records))
+	byAlpha = make(map[string]int, 2*len(records))
+	byName = make(map[string]int, 2*len(records))
+
+	// alpha2,alpha3,numeric,name,official_name,common_name,region
+	for _, rec := range records[1:] {
+		c := Country{
+			Alpha2:  rec[0],
+			Alpha3:  rec[1],
+			Numeric: rec[2],
+			Name:    rec[3],
+			Region:  rec[6],
+		}
+		i := len(all)
+		all = append(all, c)
+
+		byAlpha[c.Alpha2] = i
+		byAlpha[c.Alpha3] = i
+		for _, n := range rec[3:6] {
+			if n != "" {
+				byName[foldName(n)] = i
+			}
+		}
+	}
+}
+
+func foldName(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// Lookup resolves an alpha-2 code, alpha-3 code or English name
+// (case-insensitive) to its country.
+func Lookup(s string) (Country, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Country{}, false
+	}
+	if len(s) == 2 || len(s) == 3 {
+		if i, ok := byAlpha[strings.ToUpper(s)]; ok {
+			return all[i], true
+		}
+	}
+	if i, ok := byName[foldName(s)]; ok {
+		return all[i], true
+	}
+	return Country{}, false
+}
+
+// Normalize returns the alpha-2 code for s, or false if s is not a known
+// country.
+func Normalize(s string) (string, bool) {
+	c, ok := Lookup(s)
+	if !ok {
+		return "", false
+	}
+	return c.Alpha2, true
+}
+
+// All returns every country ordered by alpha-2 code. The slice is a copy.
+func All() []Country {
+	out := make([]Country, len(all))
+	copy(out, all)
+	return out
+}
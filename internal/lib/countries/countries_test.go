@@ -0,0 +1,42 @@
+package countries
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"ua", "UA", true},
+		{"UKR", "UA", true},
+		{" ukraine ", "UA", true},
+		{"United Kingdom", "GB", true},
+		{"Bolivia", "BO", true},
+		{"Bolivia, Plurinational State of", "BO", true},
+		{"XX", "", false},
+		{"ZZZ", "", false},
+		{"", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := Normalize(tc.in)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("Normalize(%q) = %q, %v; want %q, %v", tc.in, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	list := All()
+	if len(list) < 249 {
+		t.Fatalf("got %d countries, want at least 249", len(list))
+	}
+	for _, c := range list {
+		if len(c.Alpha2) != 2 || len(c.Alpha3) != 3 || c.Name == "" || c.Region == "" {
+			t.Errorf("incomplete entry %+v", c)
+		}
+	}
+	if c, _ := Lookup("JP"); c.Region != "Asia" {
+		t.Errorf("JP region = %q, want Asia", c.Region)
+	}
+}
@@ -0,0 +1,177 @@
+// Package cron parses standard five-field cron expressions
+// (minute hour day-of-month month day-of-week) and computes their next run.
+//
+// Supported syntax per field: "*", numbers, ranges "a-b", steps "*/n" and
+// "a-b/n", and comma separated lists of those. The descriptors @hourly,
+// @daily, @weekly, @monthly and @yearly are accepted as shorthands.
+// Day-of-week runs 0-6 with Sunday as 0; 7 is also accepted for Sunday.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidExpr = errors.New("invalid cron expression")
+
+// maxLookahead bounds the search for the next run, so impossible dates such
+// as February 30th end instead of looping forever.
+const maxLookahead = 5 * 366 * 24 * time.Hour
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type field struct {
+	min, max int
+}
+
+var fields = [5]field{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 7},  // day of week
+}
+
+// Schedule is a parsed cron expression. Each set holds one bit per allowed value.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar follow the classic rule: when both day fields are
+	// restricted a day matches if either of them does.
+	domStar, dowStar bool
+}
+
+// Parse parses a cron expression.
+func Parse(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return Schedule{}, fmt.Errorf("%w: want 5 fields, got %d", ErrInvalidExpr, len(parts))
+	}
+
+	var sets [5]uint64
+	for i, p := range parts {
+		set, err := parseField(p, fields[i])
+		if err != nil {
+			return Schedule{}, err
+		}
+		sets[i] = set
+	}
+
+	// Sunday may be written as 0 or 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+		sets[4] &^= 1 << 7
+	}
+
+	return Schedule{
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		domStar: parts[2] == "*",
+		dowStar: parts[4] == "*",
+	}, nil
+}
+
+func parseField(s string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(s, ",") {
+		lo, hi, step := f.min, f.max, 1
+
+		rng := item
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%w: bad step in %q", ErrInvalidExpr, item)
+			}
+			step, rng = n, item[:i]
+		}
+
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(a)
+			hi, err2 = strconv.Atoi(b)
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("%w: bad range %q", ErrInvalidExpr, item)
+			}
+		default:
+			n, err := strconv.Atoi(rng)
+			if err != nil {
+				return 0, fmt.Errorf("%w: bad value %q", ErrInvalidExpr, item)
+			}
+			lo, hi = n, n
+			if step > 1 {
+				hi = f.max
+			}
+		}
+
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, fmt.Errorf("%w: %q out of range %d-%d", ErrInvalidExpr, item, f.min, f.max)
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// Next returns the first run strictly after t, in t's location.
+// It returns the zero time when the expression never matches.
+func (s Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxLookahead)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s Schedule) dayMatches(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
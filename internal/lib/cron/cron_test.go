@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, time.March, 1, 10, 30, 0, 0, time.UTC) // Friday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, time.March, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * 1", time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * MON", time.Time{}},
+		{"30 10 1 * *", time.Date(2024, time.April, 1, 10, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2024, time.March, 3, 12, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 8-18/5 * * 1-5", time.Date(2024, time.March, 1, 13, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if tt.want.IsZero() {
+			if !errors.Is(err, ErrInvalidExpr) {
+				t.Errorf("%q: want ErrInvalidExpr, got %v", tt.expr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: %v", tt.expr, err)
+		}
+		if got := s.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q: next=%v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *"} {
+		if _, err := Parse(expr); !errors.Is(err, ErrInvalidExpr) {
+			t.Errorf("%q: want ErrInvalidExpr, got %v", expr, err)
+		}
+	}
+}
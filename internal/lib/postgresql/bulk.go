@@ -0,0 +1,82 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// MaxInsertRows keeps multi-row inserts well below the 65535 bind parameter limit.
+const MaxInsertRows = 1000
+
+// Querier is the part of *sql.DB and *sql.Tx the bulk helpers need.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// ReserveIDs takes n values from the serial sequence of table.id, so callers
+// can insert rows with ids that line up with their input order.
+func ReserveIDs(ctx context.Context, q Querier, table string, n int) ([]int64, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT nextval(pg_get_serial_sequence($1, 'id'))
+		FROM generate_series(1, $2);`, table, n)
+	if err != nil {
+		return nil, fmt.Errorf("reserve %s ids: %w", table, err)
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, n)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iter: %w", err)
+	}
+	return ids, nil
+}
+
+// InsertRows runs insert (an "INSERT INTO t (a, b) VALUES " prefix) once per
+// chunk of at most MaxInsertRows rows. row returns the cols arguments of row i.
+// Driver errors are returned unwrapped so callers can map them.
+func InsertRows(ctx context.Context, q Querier, insert string, n, cols int, row func(i int) []any) error {
+	for start := 0; start < n; start += MaxInsertRows {
+		end := min(start+MaxInsertRows, n)
+
+		args := make([]any, 0, (end-start)*cols)
+		for i := start; i < end; i++ {
+			args = append(args, row(i)...)
+		}
+
+		if _, err := q.ExecContext(ctx, insert+ValuesList(end-start, cols), args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValuesList renders "($1,$2),($3,$4)" style placeholders for n rows of cols columns.
+func ValuesList(n, cols int) string {
+	var b strings.Builder
+	pos := 1
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('(')
+		for j := 0; j < cols; j++ {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(&b, "$%d", pos)
+			pos++
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
@@ -0,0 +1,19 @@
+package postgresql
+
+import "testing"
+
+func TestValuesList(t *testing.T) {
+	tests := []struct {
+		n, cols int
+		want    string
+	}{
+		{n: 1, cols: 1, want: "($1)"},
+		{n: 2, cols: 3, want: "($1,$2,$3),($4,$5,$6)"},
+		{n: 0, cols: 2, want: ""},
+	}
+	for _, tt := range tests {
+		if got := ValuesList(tt.n, tt.cols); got != tt.want {
+			t.Errorf("ValuesList(%d, %d) = %q, want %q", tt.n, tt.cols, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package postgresql
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+)
+
+// SQLSTATE codes the repositories map to service errors.
+const (
+	UniqueViolation     = "23505"
+	ForeignKeyViolation = "23503"
+)
+
+// ErrorCode returns the SQLSTATE code and constraint name of a Postgres
+// error, or empty strings for any other error. The app connects through
+// lib/pq, but pgx errors are understood too so a driver switch cannot
+// silently turn every mapped error into a 500.
+func ErrorCode(err error) (code, constraint string) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code), pqErr.Constraint
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code, pgErr.ConstraintName
+	}
+	return "", ""
+}
@@ -0,0 +1,42 @@
+package postgresql
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantCode       string
+		wantConstraint string
+	}{
+		{
+			name:     "lib/pq error",
+			err:      &pq.Error{Code: "23505", Constraint: "uq_missions_occurrence"},
+			wantCode: UniqueViolation, wantConstraint: "uq_missions_occurrence",
+		},
+		{
+			name:     "wrapped lib/pq error",
+			err:      fmt.Errorf("insert: %w", &pq.Error{Code: "23503"}),
+			wantCode: ForeignKeyViolation,
+		},
+		{
+			name:     "pgx error",
+			err:      &pgconn.PgError{Code: "23505", ConstraintName: "uq"},
+			wantCode: UniqueViolation, wantConstraint: "uq",
+		},
+		{name: "other error", err: errors.New("boom")},
+	}
+	for _, tc := range tests {
+		code, constraint := ErrorCode(tc.err)
+		if code != tc.wantCode || constraint != tc.wantConstraint {
+			t.Fatalf("%s: got (%q, %q), want (%q, %q)", tc.name, code, constraint, tc.wantCode, tc.wantConstraint)
+		}
+	}
+}
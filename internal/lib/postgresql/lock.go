@@ -0,0 +1,47 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AdvisoryLocker takes Postgres session-level advisory locks so that only one
+// app instance runs a given background job at a time.
+type AdvisoryLocker struct {
+	db *sql.DB
+}
+
+func NewAdvisoryLocker(db *sql.DB) *AdvisoryLocker {
+	return &AdvisoryLocker{db: db}
+}
+
+// TryLock tries to take the lock identified by key without waiting.
+// When ok is true the caller owns the lock until it calls unlock.
+// The lock lives on a dedicated connection, so it is also released if the
+// process dies.
+func (l *AdvisoryLocker) TryLock(ctx context.Context, key int64) (unlock func(), ok bool, err error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("advisory lock conn: %w", err)
+	}
+
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&ok); err != nil {
+		_ = conn.Close()
+		return nil, false, fmt.Errorf("advisory lock: %w", err)
+	}
+	if !ok {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	unlock = func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, key); err != nil {
+			log.Error().Err(err).Int64("key", key).Msg("advisory unlock failed")
+		}
+		_ = conn.Close()
+	}
+	return unlock, true, nil
+}
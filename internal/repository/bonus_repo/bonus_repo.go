@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/postgresql"
+	servieserrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type BonusRepo struct {
+	db *sql.DB
+}
+
+func NewBonusRepository(db *sql.DB) *BonusRepo {
+	return &BonusRepo{db: db}
+}
+
+const ruleColumns = `id, name, kind, amount, currency, risk_level, active, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRule(row rowScanner) (domain.BonusRule, error) {
+	var (
+		r     domain.BonusRule
+		level sql.NullInt16
+	)
+	if err := row.Scan(&r.ID, &r.Name, &r.Kind, &r.Amount, &r.Amount.Currency, &level, &r.Active, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		return domain.BonusRule{}, err
+	}
+	if level.Valid {
+		v := int(level.Int16)
+		r.RiskLevel = &v
+	}
+	return r, nil
+}
+
+func (r *BonusRepo) ListRules(ctx context.Context) ([]domain.BonusRule, error) {
+	return QueryRules(ctx, r.db)
+}
+
+// QueryRules lists every bonus rule through q, which may be the transaction
+// that completes a mission.
+func QueryRules(ctx context.Context, q postgresql.Querier) ([]domain.BonusRule, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT `+ruleColumns+`
+		FROM bonus_rules
+		ORDER BY id;`)
+	if err != nil {
+		return nil, fmt.Errorf("list bonus rules: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.BonusRule
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rule)
+	}
+	return out, rows.Err()
+}
+
+func (r *BonusRepo) GetRule(ctx context.Context, id int64) (domain.BonusRule, error) {
+	rule, err := scanRule(r.db.QueryRowContext(ctx, `
+		SELECT `+ruleColumns+` FROM bonus_rules WHERE id = $1;`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.BonusRule{}, servieserrors.ErrBonusRuleNotFound
+		}
+		return domain.BonusRule{}, fmt.Errorf("get bonus rule: %w", err)
+	}
+	return rule, nil
+}
+
+func (r *BonusRepo) CreateRule(ctx context.Context, rule domain.BonusRule) (domain.BonusRule, error) {
+	out, err := scanRule(r.db.QueryRowContext(ctx, `
+		INSERT INTO bonus_rules (name, kind, amount, currency, risk_level, active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+ruleColumns+`;`,
+		rule.Name, rule.Kind, rule.Amount, rule.Amount.Currency, rule.RiskLevel, rule.Active))
+	if err != nil {
+		return domain.BonusRule{}, fmt.Errorf("create bonus rule: %w", err)
+	}
+	return out, nil
+}
+
+// UpdateRule replaces a rule. Bonuses already awarded keep their amounts.
+func (r *BonusRepo) UpdateRule(ctx context.Context, rule domain.BonusRule) (domain.BonusRule, error) {
+	out, err := scanRule(r.db.QueryRowContext(ctx, `
+		UPDATE bonus_rules
+		SET name = $2, kind = $3, amount = $4, currency = $5, risk_level = $6, active = $7, updated_at = now()
+		WHERE id = $1
+		RETURNING `+ruleColumns+`;`,
+		rule.ID, rule.Name, rule.Kind, rule.Amount, rule.Amount.Currency, rule.RiskLevel, rule.Active))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.BonusRule{}, servieserrors.ErrBonusRuleNotFound
+		}
+		return domain.BonusRule{}, fmt.Errorf("update bonus rule: %w", err)
+	}
+	return out, nil
+}
+
+func (r *BonusRepo) DeleteRule(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM bonus_rules WHERE id = $1;`, id)
+	if err != nil {
+		return fmt.Errorf("delete bonus rule: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return servieserrors.ErrBonusRuleNotFound
+	}
+	return nil
+}
+
+func (r *BonusRepo) ListRiskLevels(ctx context.Context) ([]domain.CountryRisk, error) {
+	return QueryRiskLevels(ctx, r.db)
+}
+
+// QueryRiskLevels lists the country risk levels through q.
+func QueryRiskLevels(ctx context.Context, q postgresql.Querier) ([]domain.CountryRisk, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT country, level, updated_at
+		FROM country_risk_levels
+		ORDER BY country;`)
+	if err != nil {
+		return nil, fmt.Errorf("list country risk levels: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.CountryRisk
+	for rows.Next() {
+		var c domain.CountryRisk
+		if err := rows.Scan(&c.Country, &c.Level, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// UpsertRiskLevel stores the risk level of a country, replacing the previous one.
+func (r *BonusRepo) UpsertRiskLevel(ctx context.Context, c domain.CountryRisk) (domain.CountryRisk, error) {
+	var out domain.CountryRisk
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO country_risk_levels (country, level)
+		VALUES ($1, $2)
+		ON CONFLICT (country)
+		DO UPDATE SET level = EXCLUDED.level, updated_at = now()
+		RETURNING country, level, updated_at;`, c.Country, c.Level).Scan(&out.Country, &out.Level, &out.UpdatedAt)
+	if err != nil {
+		return domain.CountryRisk{}, fmt.Errorf("upsert country risk level: %w", err)
+	}
+	return out, nil
+}
+
+func (r *BonusRepo) DeleteRiskLevel(ctx context.Context, country string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM country_risk_levels WHERE country = $1;`, country)
+	if err != nil {
+		return fmt.Errorf("delete country risk level: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return servieserrors.ErrCountryRiskNotFound
+	}
+	return nil
+}
+
+// ListCatBonuses returns the bonuses of a cat, newest first.
+func (r *BonusRepo) ListCatBonuses(ctx context.Context, catID int64) ([]domain.Bonus, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM cats WHERE id = $1 AND deleted_at IS NULL);`, catID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("check cat: %w", err)
+	}
+	if !exists {
+		return nil, servieserrors.ErrCatNotFound
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, cat_id, mission_id, rule_id, rule_name, kind, quantity, amount, currency, awarded_at
+		FROM bonuses
+		WHERE cat_id = $1
+		ORDER BY awarded_at DESC, id DESC;`, catID)
+	if err != nil {
+		return nil, fmt.Errorf("list bonuses: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.Bonus
+	for rows.Next() {
+		var b domain.Bonus
+		if err := rows.Scan(&b.ID, &b.CatID, &b.MissionID, &b.RuleID, &b.RuleName, &b.Kind,
+			&b.Quantity, &b.Amount, &b.Amount.Currency, &b.AwardedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	servieserrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+const bandColumns = `id, min_years, max_years, breed, min_salary, max_salary, currency, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBand(row rowScanner) (domain.SalaryBand, error) {
+	var (
+		b        domain.SalaryBand
+		maxYears sql.NullInt64
+	)
+	if err := row.Scan(&b.ID, &b.MinYears, &maxYears, &b.Breed, &b.Min, &b.Max, &b.Min.Currency, &b.CreatedAt, &b.UpdatedAt); err != nil {
+		return domain.SalaryBand{}, err
+	}
+	if maxYears.Valid {
+		v := maxYears.Int64
+		b.MaxYears = &v
+	}
+	b.Max.Currency = b.Min.Currency
+	return b, nil
+}
+
+func (r *CatRepository) ListSalaryBands(ctx context.Context) ([]domain.SalaryBand, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+bandColumns+`
+		FROM salary_bands
+		ORDER BY lower(breed), min_years, id;`)
+	if err != nil {
+		return nil, fmt.Errorf("list salary bands: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.SalaryBand
+	for rows.Next() {
+		b, err := scanBand(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+func (r *CatRepository) GetSalaryBand(ctx context.Context, id int64) (domain.SalaryBand, error) {
+	b, err := scanBand(r.db.QueryRowContext(ctx, `
+		SELECT `+bandColumns+` FROM salary_bands WHERE id = $1;`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.SalaryBand{}, servieserrors.ErrSalaryBandNotFound
+		}
+		return domain.SalaryBand{}, fmt.Errorf("get salary band: %w", err)
+	}
+	return b, nil
+}
+
+// CreateSalaryBand and UpdateSalaryBand lock the table while checking for
+// overlaps, so two concurrent writes cannot both pass the check.
+func (r *CatRepository) CreateSalaryBand(ctx context.Context, b domain.SalaryBand) (domain.SalaryBand, error) {
+	return r.writeSalaryBand(ctx, b, `
+		INSERT INTO salary_bands (min_years, max_years, breed, min_salary, max_salary, currency)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+bandColumns+`;`)
+}
+
+func (r *CatRepository) UpdateSalaryBand(ctx context.Context, b domain.SalaryBand) (domain.SalaryBand, error) {
+	return r.writeSalaryBand(ctx, b, `
+		UPDATE salary_bands
+		SET min_years = $1, max_years = $2, breed = $3,
+		    min_salary = $4, max_salary = $5, currency = $6, updated_at = now()
+		WHERE id = $7
+		RETURNING `+bandColumns+`;`)
+}
+
+func (r *CatRepository) writeSalaryBand(ctx context.Context, b domain.SalaryBand, q string) (domain.SalaryBand, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return domain.SalaryBand{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE salary_bands IN SHARE ROW EXCLUSIVE MODE;`); err != nil {
+		return domain.SalaryBand{}, fmt.Errorf("lock salary bands: %w", err)
+	}
+
+	var overlap bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM salary_bands
+			WHERE id <> $1
+			  AND lower(breed) = lower($2)
+			  AND int8range(min_years, max_years, '[]') && int8range($3, $4, '[]')
+		);`, b.ID, b.Breed, b.MinYears, b.MaxYears).Scan(&overlap)
+	if err != nil {
+		return domain.SalaryBand{}, fmt.Errorf("check overlap: %w", err)
+	}
+	if overlap {
+		return domain.SalaryBand{}, servieserrors.ErrSalaryBandOverlap
+	}
+
+	args := []any{b.MinYears, b.MaxYears, b.Breed, b.Min, b.Max, b.Min.Currency}
+	if b.ID != 0 {
+		args = append(args, b.ID)
+	}
+	out, err := scanBand(tx.QueryRowContext(ctx, q, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.SalaryBand{}, servieserrors.ErrSalaryBandNotFound
+		}
+		return domain.SalaryBand{}, fmt.Errorf("write salary band: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.SalaryBand{}, err
+	}
+	return out, nil
+}
+
+func (r *CatRepository) DeleteSalaryBand(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM salary_bands WHERE id = $1;`, id)
+	if err != nil {
+		return fmt.Errorf("delete salary band: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return servieserrors.ErrSalaryBandNotFound
+	}
+	return nil
+}
@@ -5,10 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/postgresql"
 	servieserrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/lib/pq"
 )
 
 type CatRepository struct {
@@ -20,27 +24,144 @@ func NewCatRepository(db *sql.DB) *CatRepository {
 		db: db,
 	}
 }
-func (r *CatRepository) CreateCat(ctx context.Context, c *domain.Cat) (int64, error) {
+func (r *CatRepository) CreateCat(ctx context.Context, c *domain.Cat, ov domain.SalaryOverride) (int64, error) {
 	var id int64
 
+	// the first ledger entry is written in the same statement as the cat
 	q := `
-		INSERT INTO cats(name, years_experience, breed, salary)
-		VALUES ($1,$2,$3,$4)
-		RETURNING id;`
+		WITH c AS (
+			INSERT INTO cats(name, years_experience, breed, salary, salary_currency)
+			VALUES ($1,$2,$3,$4,$5)
+			RETURNING id, salary, salary_currency
+		)
+		INSERT INTO salary_changes (cat_id, new_salary, currency, effective_from, reason, overridden)
+		SELECT id, salary, salary_currency, (now() AT TIME ZONE 'UTC')::date, coalesce(nullif($6, ''), 'initial'), $7
+		FROM c
+		RETURNING cat_id;`
 
-	err := r.db.QueryRowContext(ctx, q, c.Name, c.YearsExperience, c.Breed, c.Salary).Scan(&id)
+	err := r.db.QueryRowContext(ctx, q, c.Name, c.YearsExperience, c.Breed, c.Salary, currencyOf(c.Salary), ov.Reason, ov.Override).Scan(&id)
 	return id, err
 }
+
+// CreateCats inserts cats in one transaction. Ids are reserved from the identity
+// sequence first so the result follows the input order.
+func (r *CatRepository) CreateCats(ctx context.Context, cats []domain.Cat) ([]int64, error) {
+	if len(cats) == 0 {
+		return nil, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	ids, err := postgresql.ReserveIDs(ctx, tx, "cats", len(cats))
+	if err != nil {
+		return nil, err
+	}
+
+	const q = `INSERT INTO cats(id, name, years_experience, breed, salary, salary_currency) VALUES `
+	err = postgresql.InsertRows(ctx, tx, q, len(cats), 6, func(i int) []any {
+		c := cats[i]
+		return []any{ids[i], c.Name, c.YearsExperience, c.Breed, c.Salary, currencyOf(c.Salary)}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("insert cats: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO salary_changes (cat_id, new_salary, currency, effective_from, reason)
+		SELECT id, salary, salary_currency, (now() AT TIME ZONE 'UTC')::date, 'initial'
+		FROM cats
+		WHERE id = ANY($1);`, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("insert salary ledger: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
 func (r *CatRepository) GetCat(ctx context.Context, id int64) (domain.Cat, error) {
 	var c domain.Cat
 
-	q := `SELECT id, name, years_experience, breed, salary
+	q := `SELECT id, name, years_experience, breed, salary, salary_currency
 	      FROM cats
-		  WHERE id = $1;`
+		  WHERE id = $1 AND deleted_at IS NULL;`
 
-	err := r.db.QueryRowContext(ctx, q, id).Scan(&c.ID, &c.Name, &c.YearsExperience, &c.Breed, &c.Salary)
+	err := r.db.QueryRowContext(ctx, q, id).Scan(&c.ID, &c.Name, &c.YearsExperience, &c.Breed, &c.Salary, &c.Salary.Currency)
 	return c, err
 }
+func buildCatWhere(p domain.ListCatsParams) (string, []any) {
+	conds := []string{"deleted_at IS NULL"}
+	var args []any
+
+	if p.Name != nil {
+		if n := strings.TrimSpace(*p.Name); n != "" {
+			args = append(args, "%"+n+"%")
+			conds = append(conds, fmt.Sprintf("name ILIKE $%d", len(args)))
+		}
+	}
+	if p.Breed != nil {
+		if b := strings.TrimSpace(*p.Breed); b != "" {
+			args = append(args, b)
+			conds = append(conds, fmt.Sprintf("breed ILIKE $%d", len(args)))
+		}
+	}
+	if p.MinYears != nil {
+		args = append(args, *p.MinYears)
+		conds = append(conds, fmt.Sprintf("years_experience >= $%d", len(args)))
+	}
+	if p.MaxYears != nil {
+		args = append(args, *p.MaxYears)
+		conds = append(conds, fmt.Sprintf("years_experience <= $%d", len(args)))
+	}
+
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// catSortColumns maps domain.CatSortFields to SQL. Amounts in different
+// currencies are not comparable, so salary sorts by currency first.
+var catSortColumns = map[string][]string{
+	"id":               {"id"},
+	"name":             {"lower(name)"},
+	"years_experience": {"years_experience"},
+	"breed":            {"lower(breed)"},
+	"salary":           {"salary_currency", "salary"},
+	"created_at":       {"created_at"},
+}
+
+// buildCatOrder renders the ORDER BY clause, ending with id as a tie-breaker
+// unless the caller already sorts by it.
+func buildCatOrder(sort []domain.SortField) (string, error) {
+	if len(sort) == 0 {
+		return " ORDER BY id DESC", nil
+	}
+
+	keys := make([]string, 0, len(sort)+1)
+	byID := false
+	for _, f := range sort {
+		cols, ok := catSortColumns[f.Name]
+		if !ok {
+			return "", fmt.Errorf("unknown sort column %q", f.Name)
+		}
+		dir := "ASC"
+		if f.Desc {
+			dir = "DESC"
+		}
+		for _, col := range cols {
+			keys = append(keys, col+" "+dir)
+		}
+		byID = byID || f.Name == "id"
+	}
+	if !byID {
+		keys = append(keys, "id DESC")
+	}
+
+	return " ORDER BY " + strings.Join(keys, ", "), nil
+}
+
 func (r *CatRepository) ListCats(ctx context.Context, p domain.ListCatsParams) ([]domain.Cat, error) {
 	if p.Limit <= 0 {
 		p.Limit = 50
@@ -49,14 +170,19 @@ func (r *CatRepository) ListCats(ctx context.Context, p domain.ListCatsParams) (
 		p.Offset = 0
 	}
 
+	order, err := buildCatOrder(p.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := buildCatWhere(p)
 	q := `
-		SELECT id, name, years_experience, breed, salary
-		FROM cats
-		ORDER BY id DESC
-		LIMIT $1 OFFSET $2;
-	`
+		SELECT id, name, years_experience, breed, salary, salary_currency
+		FROM cats` + where + order + fmt.Sprintf(`
+		LIMIT $%d OFFSET $%d;`, len(args)+1, len(args)+2)
+	args = append(args, p.Limit, p.Offset)
 
-	rows, err := r.db.QueryContext(ctx, q, p.Limit, p.Offset)
+	rows, err := r.db.QueryContext(ctx, q, args...)
 	if err != nil {
 		return nil, fmt.Errorf("list cats: %w", err)
 	}
@@ -72,6 +198,7 @@ func (r *CatRepository) ListCats(ctx context.Context, p domain.ListCatsParams) (
 			&c.YearsExperience,
 			&c.Breed,
 			&c.Salary,
+			&c.Salary.Currency,
 		); err != nil {
 			return nil, fmt.Errorf("scan cat: %w", err)
 		}
@@ -84,41 +211,213 @@ func (r *CatRepository) ListCats(ctx context.Context, p domain.ListCatsParams) (
 
 	return out, nil
 }
-func (r *CatRepository) DeleteCat(ctx context.Context, id int64) (int64, error) {
+
+// StreamCats walks every cat matching the filter without loading them all into memory.
+// Limit and offset are ignored.
+func (r *CatRepository) StreamCats(ctx context.Context, p domain.ListCatsParams, fn func(domain.Cat) error) error {
+	where, args := buildCatWhere(p)
 	q := `
-	DELETE
-	FROM cats
-	WHERE id = $1;`
+		SELECT id, name, years_experience, breed, salary, salary_currency
+		FROM cats` + where + `
+		ORDER BY id;`
 
-	res, err := r.db.ExecContext(ctx, q, id)
+	rows, err := r.db.QueryContext(ctx, q, args...)
 	if err != nil {
-		return 0, fmt.Errorf("delete cat: %w", err)
+		return fmt.Errorf("stream cats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c domain.Cat
+		if err := rows.Scan(
+			&c.ID,
+			&c.Name,
+			&c.YearsExperience,
+			&c.Breed,
+			&c.Salary,
+			&c.Salary.Currency,
+		); err != nil {
+			return fmt.Errorf("scan cat: %w", err)
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
 	}
-	n, _ := res.RowsAffected()
-	return n, err
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iter: %w", err)
+	}
+	return nil
+}
+
+// DeleteCat marks the cat as deleted. The cat row is locked first so a
+// concurrent assignment cannot slip in between the mission check and the update.
+func (r *CatRepository) DeleteCat(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var locked int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT id
+		FROM cats
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE;`, id).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return servieserrors.ErrCatNotFound
+		}
+		return fmt.Errorf("lock cat: %w", err)
+	}
+
+	var active bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM missions WHERE cat_id = $1 AND status IN ('active', 'paused')
+		) OR EXISTS (
+			SELECT 1
+			FROM mission_assignments a
+			JOIN missions m ON m.id = a.mission_id
+			WHERE a.cat_id = $1 AND m.status IN ('active', 'paused')
+		);`, id).Scan(&active)
+	if err != nil {
+		return fmt.Errorf("check active missions: %w", err)
+	}
+	if active {
+		return servieserrors.ErrCatHasActiveMission
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE cats
+		SET deleted_at = now(), updated_at = now()
+		WHERE id = $1;`, id); err != nil {
+		return fmt.Errorf("delete cat: %w", err)
+	}
+
+	return tx.Commit()
 }
-func (r *CatRepository) UpdateSalary(ctx context.Context, id int64, salary float64) (domain.Cat, error) {
+func (r *CatRepository) RestoreCat(ctx context.Context, id int64) (domain.Cat, error) {
 	q := `
 	UPDATE cats
-	SET salary = $1
-	WHERE id = $2
-	RETURNING id, name, years_experience, breed, salary
-	;`
+	SET deleted_at = NULL, updated_at = now()
+	WHERE id = $1 AND deleted_at IS NOT NULL AND purged_at IS NULL
+	RETURNING id, name, years_experience, breed, salary, salary_currency;`
 
 	var c domain.Cat
-	err := r.db.QueryRowContext(ctx, q, salary, id).
+	err := r.db.QueryRowContext(ctx, q, id).
 		Scan(
 			&c.ID,
 			&c.Name,
 			&c.YearsExperience,
 			&c.Breed,
 			&c.Salary,
+			&c.Salary.Currency,
 		)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			var exists bool
+			if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM cats WHERE id = $1 AND purged_at IS NULL);`, id).Scan(&exists); err != nil {
+				return domain.Cat{}, err
+			}
+			if exists {
+				return domain.Cat{}, servieserrors.ErrCatNotDeleted
+			}
+			return domain.Cat{}, servieserrors.ErrCatNotFound
+		}
+		return domain.Cat{}, err
+	}
+	return c, nil
+}
+
+// PurgeDeletedCats anonymises cats soft deleted before the cutoff. The rows
+// themselves are kept: missions, goals, handovers, the salary ledger and
+// bonuses reference them with ON DELETE RESTRICT, so history and payroll
+// records stay intact. A purged cat can no longer be restored.
+func (r *CatRepository) PurgeDeletedCats(ctx context.Context, before time.Time) (int64, error) {
+	q := `
+	UPDATE cats
+	SET name = 'purged cat #' || id, breed = '', purged_at = now(), updated_at = now()
+	WHERE deleted_at IS NOT NULL
+	  AND deleted_at < $1
+	  AND purged_at IS NULL;`
+
+	res, err := r.db.ExecContext(ctx, q, before)
+	if err != nil {
+		return 0, fmt.Errorf("purge cats: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// UpdateSalary changes the salary and appends the change to the ledger in
+// one transaction. The cat row is locked so concurrent changes are recorded
+// one after the other with the right old value.
+func (r *CatRepository) UpdateSalary(ctx context.Context, p domain.UpdateSalaryParams) (domain.Cat, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var old domain.Money
+	err = tx.QueryRowContext(ctx, `
+		SELECT salary, salary_currency
+		FROM cats
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE;`, p.ID).Scan(&old, &old.Currency)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Cat{}, servieserrors.ErrCatNotFound
 		}
+		return domain.Cat{}, fmt.Errorf("lock cat: %w", err)
+	}
+
+	var latest sql.NullTime
+	if err := tx.QueryRowContext(ctx, `
+		SELECT max(effective_from) FROM salary_changes WHERE cat_id = $1;`, p.ID).Scan(&latest); err != nil {
+		return domain.Cat{}, fmt.Errorf("latest salary change: %w", err)
+	}
+	if latest.Valid && p.EffectiveFrom.Before(latest.Time) {
+		return domain.Cat{}, servieserrors.ErrInvalidEffectiveDate
+	}
+
+	// an empty currency keeps the one the cat is paid in
+	var c domain.Cat
+	err = tx.QueryRowContext(ctx, `
+		UPDATE cats
+		SET salary = $1, salary_currency = coalesce(nullif($2, ''), salary_currency), updated_at = now()
+		WHERE id = $3
+		RETURNING id, name, years_experience, breed, salary, salary_currency;`, p.Salary, p.Salary.Currency, p.ID).
+		Scan(
+			&c.ID,
+			&c.Name,
+			&c.YearsExperience,
+			&c.Breed,
+			&c.Salary,
+			&c.Salary.Currency,
+		)
+	if err != nil {
+		return domain.Cat{}, fmt.Errorf("update salary: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO salary_changes (cat_id, old_salary, old_currency, new_salary, currency, effective_from, reason, actor, overridden)
+		VALUES ($1, $2, $3, $4, $5, $6::date, $7, $8, $9);`,
+		p.ID, old, old.Currency, c.Salary, c.Salary.Currency, p.EffectiveFrom, p.Reason, p.Actor, p.Override); err != nil {
+		return domain.Cat{}, fmt.Errorf("insert salary change: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
 		return domain.Cat{}, err
 	}
 	return c, nil
 }
+
+// currencyOf falls back to the column default for amounts built without one.
+func currencyOf(m domain.Money) string {
+	if m.Currency == "" {
+		return domain.DefaultCurrency
+	}
+	return m.Currency
+}
@@ -0,0 +1,22 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+func TestBuildCatOrder(t *testing.T) {
+	got, err := buildCatOrder([]domain.SortField{{Name: "salary", Desc: true}, {Name: "name"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := " ORDER BY salary_currency DESC, salary DESC, lower(name) ASC, id DESC"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if _, err := buildCatOrder([]domain.SortField{{Name: "password"}}); err == nil {
+		t.Fatal("unknown column must be rejected")
+	}
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	servieserrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+// SalaryHistory returns the ledger of a cat, newest change first.
+func (r *CatRepository) SalaryHistory(ctx context.Context, catID int64) ([]domain.SalaryChange, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM cats WHERE id = $1 AND deleted_at IS NULL);`, catID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("check cat: %w", err)
+	}
+	if !exists {
+		return nil, servieserrors.ErrCatNotFound
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, cat_id, old_salary, coalesce(old_currency, ''), new_salary, currency, effective_from, reason, actor, overridden, created_at
+		FROM salary_changes
+		WHERE cat_id = $1
+		ORDER BY effective_from DESC, id DESC;`, catID)
+	if err != nil {
+		return nil, fmt.Errorf("salary history: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.SalaryChange
+	for rows.Next() {
+		var (
+			ch          domain.SalaryChange
+			old         domain.NullMoney
+			oldCurrency string
+		)
+		if err := rows.Scan(&ch.ID, &ch.CatID, &old, &oldCurrency, &ch.NewSalary, &ch.NewSalary.Currency,
+			&ch.EffectiveFrom, &ch.Reason, &ch.Actor, &ch.Overridden, &ch.CreatedAt); err != nil {
+			return nil, err
+		}
+		if old.Valid {
+			old.Money.Currency = oldCurrency
+			ch.OldSalary = &old.Money
+		}
+		out = append(out, ch)
+	}
+	return out, rows.Err()
+}
+
+// Payroll prorates each ledger period over the days it covers in the month.
+// A rate is paid from its effective date up to the next change; soft deleted
+// cats are paid up to the day they were deleted. Bonuses awarded in the month
+// are added to the line of their currency. A cat whose currency changed
+// during the month, or who earned a bonus in another currency, gets one line
+// per currency.
+func (r *CatRepository) Payroll(ctx context.Context, month time.Time) (domain.Payroll, error) {
+	start := domain.MonthStart(month)
+	stop := start.AddDate(0, 1, 0)
+	days := int(stop.Sub(start).Hours() / 24)
+
+	rows, err := r.db.QueryContext(ctx, `
+		WITH periods AS (
+			SELECT s.cat_id,
+			       s.new_salary,
+			       s.currency,
+			       s.effective_from AS from_d,
+			       lead(s.effective_from) OVER (PARTITION BY s.cat_id ORDER BY s.effective_from, s.id) AS to_d
+			FROM salary_changes s
+		), paid AS (
+			SELECT c.id, c.name, c.breed, p.new_salary, p.currency,
+			       greatest(0,
+			         least(coalesce(p.to_d, $2::date), $2::date, coalesce((c.deleted_at AT TIME ZONE 'UTC')::date, $2::date))
+			         - greatest(p.from_d, $1::date)
+			       ) AS days
+			FROM periods p
+			JOIN cats c ON c.id = p.cat_id
+			WHERE c.deleted_at IS NULL OR c.deleted_at >= $1::date
+		), salaries AS (
+			SELECT id, currency, sum(days)::int AS days, round(sum(new_salary * days / $3::numeric), 2) AS salary
+			FROM paid
+			GROUP BY id, currency
+			HAVING sum(days) > 0
+		), awarded AS (
+			SELECT cat_id AS id, currency, sum(amount) AS bonus
+			FROM bonuses
+			WHERE (awarded_at AT TIME ZONE 'UTC')::date >= $1::date
+			  AND (awarded_at AT TIME ZONE 'UTC')::date < $2::date
+			GROUP BY cat_id, currency
+		)
+		SELECT c.id, c.name, c.breed, coalesce(s.currency, a.currency) AS currency,
+		       coalesce(s.days, 0), coalesce(s.salary, 0), coalesce(a.bonus, 0)
+		FROM salaries s
+		FULL JOIN awarded a ON a.id = s.id AND a.currency = s.currency
+		JOIN cats c ON c.id = coalesce(s.id, a.id)
+		ORDER BY c.id, currency;`, start, stop, days)
+	if err != nil {
+		return domain.Payroll{}, fmt.Errorf("payroll: %w", err)
+	}
+	defer rows.Close()
+
+	p := domain.Payroll{Month: start, DaysInMonth: days}
+	for rows.Next() {
+		var (
+			l        domain.PayrollLine
+			currency string
+		)
+		if err := rows.Scan(&l.CatID, &l.Name, &l.Breed, &currency, &l.DaysPaid, &l.Salary, &l.Bonus); err != nil {
+			return domain.Payroll{}, err
+		}
+		l.Salary.Currency, l.Bonus.Currency = currency, currency
+		l.Amount, _ = l.Salary.Add(l.Bonus)
+		p.Lines = append(p.Lines, l)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.Payroll{}, err
+	}
+	p.Tally()
+	return p, nil
+}
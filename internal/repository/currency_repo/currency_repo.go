@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	servieserrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type CurrencyRepo struct {
+	db *sql.DB
+}
+
+func NewCurrencyRepository(db *sql.DB) *CurrencyRepo {
+	return &CurrencyRepo{db: db}
+}
+
+func (r *CurrencyRepo) ListRates(ctx context.Context) ([]domain.ExchangeRate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT from_currency, to_currency, rate::text, updated_at
+		FROM exchange_rates
+		ORDER BY from_currency, to_currency;`)
+	if err != nil {
+		return nil, fmt.Errorf("list rates: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.ExchangeRate
+	for rows.Next() {
+		rate, err := scanRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rate)
+	}
+	return out, rows.Err()
+}
+
+// UpsertRate stores the rate of a pair, replacing the previous one.
+func (r *CurrencyRepo) UpsertRate(ctx context.Context, rate domain.ExchangeRate) (domain.ExchangeRate, error) {
+	row := r.db.QueryRowContext(ctx, `
+		INSERT INTO exchange_rates (from_currency, to_currency, rate)
+		VALUES ($1, $2, $3::numeric)
+		ON CONFLICT (from_currency, to_currency)
+		DO UPDATE SET rate = EXCLUDED.rate, updated_at = now()
+		RETURNING from_currency, to_currency, rate::text, updated_at;`,
+		rate.From, rate.To, domain.FormatRate(rate.Rate))
+
+	out, err := scanRate(row)
+	if err != nil {
+		return domain.ExchangeRate{}, fmt.Errorf("upsert rate: %w", err)
+	}
+	return out, nil
+}
+
+func (r *CurrencyRepo) DeleteRate(ctx context.Context, from, to string) error {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM exchange_rates WHERE from_currency = $1 AND to_currency = $2;`, from, to)
+	if err != nil {
+		return fmt.Errorf("delete rate: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return servieserrors.ErrExchangeRateNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRate(row rowScanner) (domain.ExchangeRate, error) {
+	var (
+		rate domain.ExchangeRate
+		text string
+	)
+	if err := row.Scan(&rate.From, &rate.To, &text, &rate.UpdatedAt); err != nil {
+		return domain.ExchangeRate{}, err
+	}
+	v, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return domain.ExchangeRate{}, fmt.Errorf("scan rate: invalid numeric %q", text)
+	}
+	rate.Rate = v
+	return rate, nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	bonusrepo "github.com/DavydAbbasov/spy-cat/internal/repository/bonus_repo"
+)
+
+// awardBonuses applies the active bonus rules to a mission that has just
+// been completed in tx.
+func awardBonuses(ctx context.Context, tx *sql.Tx, m domain.Mission) error {
+	if m.CatID == nil {
+		return nil
+	}
+
+	rules, err := bonusrepo.QueryRules(ctx, tx)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	levels, err := bonusrepo.QueryRiskLevels(ctx, tx)
+	if err != nil {
+		return err
+	}
+	risk := make(map[string]int, len(levels))
+	for _, l := range levels {
+		risk[l.Country] = l.Level
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+goalColumns+`
+		FROM mission_goals
+		WHERE mission_id = $1;`, m.ID)
+	if err != nil {
+		return fmt.Errorf("list goals: %w", err)
+	}
+	defer rows.Close()
+
+	var goals []domain.MissionGoal
+	for rows.Next() {
+		g, err := scanGoal(rows)
+		if err != nil {
+			return err
+		}
+		goals = append(goals, g)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, b := range domain.AwardBonuses(m, goals, rules, risk) {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO bonuses (cat_id, mission_id, rule_id, rule_name, kind, quantity, amount, currency)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8);`,
+			b.CatID, b.MissionID, b.RuleID, b.RuleName, b.Kind, b.Quantity, b.Amount, b.Amount.Currency); err != nil {
+			return fmt.Errorf("insert bonus: %w", err)
+		}
+	}
+	return nil
+}
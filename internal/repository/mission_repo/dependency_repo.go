@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/postgresql"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/lib/pq"
+)
+
+// dependencyLockKey serialises changes to the dependency graph, so two
+// concurrent inserts cannot close a cycle that neither of them sees alone.
+const dependencyLockKey int64 = 0x5c47_de95
+
+// AddDependency records that missionID depends on dependsOnID. Only planned
+// missions can gain dependencies and the new edge must not close a cycle.
+func (r *MissionRepo) AddDependency(ctx context.Context, missionID, dependsOnID int64) (domain.MissionDependency, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return domain.MissionDependency{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1);`, dependencyLockKey); err != nil {
+		return domain.MissionDependency{}, fmt.Errorf("lock dependency graph: %w", err)
+	}
+
+	var status domain.MissionStatus
+	err = tx.QueryRowContext(ctx, `SELECT status FROM missions WHERE id = $1 FOR UPDATE;`, missionID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.MissionDependency{}, serviceerrors.ErrMissionNotFound
+		}
+		return domain.MissionDependency{}, err
+	}
+	if status != domain.StatusPlanned {
+		return domain.MissionDependency{}, serviceerrors.ErrMissionNotPlanned
+	}
+
+	// the edge closes a cycle if missionID is already upstream of dependsOnID
+	var cycle bool
+	err = tx.QueryRowContext(ctx, `
+		WITH RECURSIVE up(id) AS (
+		  SELECT depends_on_id FROM mission_dependencies WHERE mission_id = $1
+		  UNION
+		  SELECT d.depends_on_id FROM mission_dependencies d JOIN up ON d.mission_id = up.id
+		)
+		SELECT EXISTS (SELECT 1 FROM up WHERE id = $2);`, dependsOnID, missionID).Scan(&cycle)
+	if err != nil {
+		return domain.MissionDependency{}, fmt.Errorf("detect dependency cycle: %w", err)
+	}
+	if cycle {
+		return domain.MissionDependency{}, serviceerrors.ErrDependencyCycle
+	}
+
+	out := domain.MissionDependency{MissionID: missionID, DependsOnID: dependsOnID}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO mission_dependencies (mission_id, depends_on_id)
+		VALUES ($1, $2)
+		RETURNING created_at;`, missionID, dependsOnID).Scan(&out.CreatedAt)
+	if err != nil {
+		switch code, _ := postgresql.ErrorCode(err); code {
+		case postgresql.UniqueViolation:
+			return domain.MissionDependency{}, serviceerrors.ErrDependencyExists
+		case postgresql.ForeignKeyViolation:
+			return domain.MissionDependency{}, serviceerrors.ErrDependencyNotFound
+		}
+		return domain.MissionDependency{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.MissionDependency{}, err
+	}
+	return out, nil
+}
+
+func (r *MissionRepo) RemoveDependency(ctx context.Context, missionID, dependsOnID int64) error {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM mission_dependencies
+		WHERE mission_id = $1 AND depends_on_id = $2;`, missionID, dependsOnID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return serviceerrors.ErrDependencyNotFound
+	}
+	return nil
+}
+
+// DependencyGraph walks the dependencies of the mission both upstream and downstream.
+func (r *MissionRepo) DependencyGraph(ctx context.Context, missionID int64) (domain.DependencyGraph, error) {
+	g := domain.DependencyGraph{MissionID: missionID}
+
+	rows, err := r.db.QueryContext(ctx, `
+		WITH RECURSIVE up AS (
+		  SELECT mission_id, depends_on_id, created_at FROM mission_dependencies WHERE mission_id = $1
+		  UNION
+		  SELECT d.mission_id, d.depends_on_id, d.created_at
+		  FROM mission_dependencies d JOIN up ON d.mission_id = up.depends_on_id
+		), down AS (
+		  SELECT mission_id, depends_on_id, created_at FROM mission_dependencies WHERE depends_on_id = $1
+		  UNION
+		  SELECT d.mission_id, d.depends_on_id, d.created_at
+		  FROM mission_dependencies d JOIN down ON d.depends_on_id = down.mission_id
+		)
+		SELECT mission_id, depends_on_id, created_at FROM up
+		UNION
+		SELECT mission_id, depends_on_id, created_at FROM down
+		ORDER BY 1, 2;`, missionID)
+	if err != nil {
+		return domain.DependencyGraph{}, fmt.Errorf("walk dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{missionID}
+	seen := map[int64]struct{}{missionID: {}}
+	for rows.Next() {
+		var e domain.MissionDependency
+		if err := rows.Scan(&e.MissionID, &e.DependsOnID, &e.CreatedAt); err != nil {
+			return domain.DependencyGraph{}, err
+		}
+		g.Edges = append(g.Edges, e)
+		for _, id := range []int64{e.MissionID, e.DependsOnID} {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return domain.DependencyGraph{}, err
+	}
+
+	nodes, err := r.db.QueryContext(ctx, `
+		SELECT id, title, status
+		FROM missions
+		WHERE id = ANY($1)
+		ORDER BY id;`, pq.Array(ids))
+	if err != nil {
+		return domain.DependencyGraph{}, err
+	}
+	defer nodes.Close()
+
+	for nodes.Next() {
+		var n domain.DependencyNode
+		if err := nodes.Scan(&n.ID, &n.Title, &n.Status); err != nil {
+			return domain.DependencyGraph{}, err
+		}
+		g.Nodes = append(g.Nodes, n)
+	}
+	if err := nodes.Err(); err != nil {
+		return domain.DependencyGraph{}, err
+	}
+
+	if _, ok := findNode(g.Nodes, missionID); !ok {
+		return domain.DependencyGraph{}, serviceerrors.ErrMissionNotFound
+	}
+	return g, nil
+}
+
+func findNode(nodes []domain.DependencyNode, id int64) (domain.DependencyNode, bool) {
+	for _, n := range nodes {
+		if n.ID == id {
+			return n, true
+		}
+	}
+	return domain.DependencyNode{}, false
+}
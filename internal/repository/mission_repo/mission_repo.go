@@ -6,14 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
 	"github.com/rs/zerolog/log"
 
 	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/postgresql"
 	service "github.com/DavydAbbasov/spy-cat/internal/service/mission_service"
-	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/lib/pq"
 )
 
 type pgTx struct{ tx *sql.Tx }
@@ -29,6 +31,90 @@ func NewMissionRepository(db *sql.DB) *MissionRepo {
 	return &MissionRepo{db: db}
 }
 
+const missionColumns = `id, title, description, status, status_reason, cat_id, due_at, priority, started_at, completed_at, schedule_id, scheduled_for, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanMission reads a row selected with missionColumns.
+func scanMission(row rowScanner) (domain.Mission, error) {
+	var (
+		m            domain.Mission
+		scheduleID   sql.NullInt64
+		scheduledFor sql.NullTime
+	)
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Description,
+		&m.Status,
+		&m.StatusReason,
+		&m.CatID,
+		&m.DueAt,
+		&m.Priority,
+		&m.StartedAt,
+		&m.CompletedAt,
+		&scheduleID,
+		&scheduledFor,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+	if scheduleID.Valid && scheduledFor.Valid {
+		m.Occurrence = &domain.Occurrence{ScheduleID: scheduleID.Int64, At: scheduledFor.Time}
+	}
+	return m, err
+}
+
+const goalColumns = `id, mission_id, name, country, notes, status, position, latitude, longitude, radius_m, done_by_cat_id, assignee_cat_id, started_at, finished_at, created_at, updated_at`
+
+// scanGoal reads a row selected with goalColumns.
+func scanGoal(row rowScanner) (domain.MissionGoal, error) {
+	var (
+		g        domain.MissionGoal
+		lat, lon sql.NullFloat64
+		radius   sql.NullInt32
+	)
+	err := row.Scan(
+		&g.ID,
+		&g.MissionID,
+		&g.Name,
+		&g.Country,
+		&g.Notes,
+		&g.Status,
+		&g.Position,
+		&lat,
+		&lon,
+		&radius,
+		&g.DoneByCatID,
+		&g.AssigneeCatID,
+		&g.StartedAt,
+		&g.FinishedAt,
+		&g.CreatedAt,
+		&g.UpdatedAt,
+	)
+	if lat.Valid && lon.Valid {
+		g.Location = &domain.GoalLocation{Lat: lat.Float64, Lon: lon.Float64}
+		if radius.Valid {
+			r := int(radius.Int32)
+			g.Location.Radius = &r
+		}
+	}
+	return g, err
+}
+
+// locationArgs spreads an optional goal location into latitude, longitude
+// and radius_m arguments.
+func locationArgs(l *domain.GoalLocation) (lat, lon, radius any) {
+	if l == nil {
+		return nil, nil, nil
+	}
+	if l.Radius != nil {
+		radius = *l.Radius
+	}
+	return l.Lat, l.Lon, radius
+}
+
 func (r *MissionRepo) BeginTx(ctx context.Context) (service.Tx, error) {
 	raw, err := r.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
@@ -41,33 +127,109 @@ func (r *MissionRepo) InsertMission(ctx context.Context, tx service.Tx, m *domai
 	pgtx := tx.(*pgTx)
 
 	const q = `
-		INSERT INTO missions (title, description, status, cat_id)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO missions (title, description, status, cat_id, due_at, priority, schedule_id, scheduled_for)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id;
 	`
 
-	err := pgtx.tx.QueryRowContext(ctx, q, m.Title, m.Description, m.Status, m.CatID).Scan(&m.ID)
-	return m.ID, err
+	var (
+		scheduleID   *int64
+		scheduledFor *time.Time
+	)
+	if m.Occurrence != nil {
+		scheduleID, scheduledFor = &m.Occurrence.ScheduleID, &m.Occurrence.At
+	}
+
+	err := pgtx.tx.QueryRowContext(ctx, q,
+		m.Title, m.Description, m.Status, m.CatID, m.DueAt, m.Priority, scheduleID, scheduledFor,
+	).Scan(&m.ID)
+	if err != nil {
+		return 0, insertMissionError(err)
+	}
+	return m.ID, nil
+}
+
+// insertMissionError maps a retry creating an occurrence that already exists
+// to ErrOccurrenceExists, so the scheduler can advance past it.
+func insertMissionError(err error) error {
+	code, constraint := postgresql.ErrorCode(err)
+	if code == postgresql.UniqueViolation && constraint == "uq_missions_occurrence" {
+		return serviceerrors.ErrOccurrenceExists
+	}
+	return err
 }
 
-func (r *MissionRepo) InsertGoals(ctx context.Context, tx service.Tx, missionID int64, goals []domain.MissionGoal) error {
+// InsertMissions reserves ids from the sequence up front, so the returned ids
+// line up with the input order, and writes the rows with one multi-row INSERT per chunk.
+func (r *MissionRepo) InsertMissions(ctx context.Context, tx service.Tx, ms []domain.Mission) ([]int64, error) {
 	pgtx := tx.(*pgTx)
 
-	const q = `
-		INSERT INTO mission_goals (mission_id, name, country, notes)
-		VALUES ($1, $2, $3, $4);
-	`
+	if len(ms) == 0 {
+		return nil, nil
+	}
 
-	for _, g := range goals {
-		if _, err := pgtx.tx.ExecContext(ctx, q, missionID, g.Name, g.Country, g.Notes); err != nil {
-			return err
+	ids, err := postgresql.ReserveIDs(ctx, pgtx.tx, "missions", len(ms))
+	if err != nil {
+		return nil, err
+	}
+
+	const q = `INSERT INTO missions (id, title, description, status, cat_id, due_at, priority) VALUES `
+	err = postgresql.InsertRows(ctx, pgtx.tx, q, len(ms), 7, func(i int) []any {
+		m := ms[i]
+		return []any{ids[i], m.Title, m.Description, m.Status, m.CatID, m.DueAt, m.Priority}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("insert missions: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *MissionRepo) InsertGoals(ctx context.Context, tx service.Tx, missionID int64, goals []domain.MissionGoal) error {
+	rows := make([]domain.MissionGoal, len(goals))
+	for i, g := range goals {
+		g.MissionID = missionID
+		rows[i] = g
+	}
+	return r.InsertGoalRows(ctx, tx, rows)
+}
+
+// InsertGoalRows writes goals that may belong to different missions using multi-row INSERTs.
+func (r *MissionRepo) InsertGoalRows(ctx context.Context, tx service.Tx, goals []domain.MissionGoal) error {
+	pgtx := tx.(*pgTx)
+
+	const q = `INSERT INTO mission_goals (mission_id, name, country, notes, position, latitude, longitude, radius_m) VALUES `
+	err := postgresql.InsertRows(ctx, pgtx.tx, q, len(goals), 8, func(i int) []any {
+		g := goals[i]
+		lat, lon, radius := locationArgs(g.Location)
+		return []any{g.MissionID, g.Name, g.Country, g.Notes, g.Position, lat, lon, radius}
+	})
+	if err != nil {
+		if code, _ := postgresql.ErrorCode(err); code == postgresql.ForeignKeyViolation {
+			return serviceerrors.ErrMissionNotFound
 		}
+		return fmt.Errorf("insert goals: %w", err)
 	}
 	return nil
 }
 func (r *MissionRepo) AssignCat(ctx context.Context, tx service.Tx, missionID int64, catID *int64) error {
 	pgtx := tx.(*pgTx)
 
+	if catID != nil {
+		// share lock keeps the cat from being soft deleted until the tx commits
+		var id int64
+		err := pgtx.tx.QueryRowContext(ctx, `
+			SELECT id FROM cats
+			WHERE id = $1 AND deleted_at IS NULL
+			FOR SHARE;`, *catID).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return serviceerrors.ErrCatNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	q := `
 		UPDATE missions
 		SET cat_id = $2, updated_at = now()
@@ -83,33 +245,324 @@ func (r *MissionRepo) AssignCat(ctx context.Context, tx service.Tx, missionID in
 			return serviceerrors.ErrMissionNotFound
 		}
 
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		if code, _ := postgresql.ErrorCode(err); code == postgresql.ForeignKeyViolation {
 			return serviceerrors.ErrCatNotFound
 		}
 		return err
 	}
 
+	// missions.cat_id and the lead row of the team always point to the same cat
+	var oldLead sql.NullInt64
+	err = pgtx.tx.QueryRowContext(ctx, `
+		DELETE FROM mission_assignments
+		WHERE mission_id = $1 AND role = 'lead'
+		RETURNING cat_id;`, missionID).Scan(&oldLead)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("drop lead: %w", err)
+	}
+
+	// the old lead leaves the team, so like a removed member it keeps no goals
+	if oldLead.Valid && (catID == nil || *catID != oldLead.Int64) {
+		if _, err := pgtx.tx.ExecContext(ctx, `
+			UPDATE mission_goals
+			SET assignee_cat_id = NULL, updated_at = now()
+			WHERE mission_id = $1 AND assignee_cat_id = $2;`, missionID, oldLead.Int64); err != nil {
+			return fmt.Errorf("clear goal assignees: %w", err)
+		}
+	}
+	if catID != nil {
+		if _, err := pgtx.tx.ExecContext(ctx, `
+			INSERT INTO mission_assignments (mission_id, cat_id, role)
+			VALUES ($1, $2, 'lead')
+			ON CONFLICT (mission_id, cat_id) DO UPDATE SET role = 'lead', assigned_at = now();`, missionID, *catID); err != nil {
+			return fmt.Errorf("set lead: %w", err)
+		}
+	}
+
 	return nil
 }
-func (r *MissionRepo) GetMission(ctx context.Context, id int64) (domain.Mission, error) {
-	var m domain.Mission
 
+// AddTeamMember puts a cat on the mission team with a non-lead role.
+func (r *MissionRepo) AddTeamMember(ctx context.Context, a domain.MissionAssignment) (domain.MissionAssignment, error) {
+	q := `
+		INSERT INTO mission_assignments (mission_id, cat_id, role)
+		SELECT $1, c.id, $3
+		FROM cats c
+		WHERE c.id = $2 AND c.deleted_at IS NULL
+		RETURNING mission_id, cat_id, role, assigned_at;`
+
+	var out domain.MissionAssignment
+	err := r.db.QueryRowContext(ctx, q, a.MissionID, a.CatID, a.Role).
+		Scan(&out.MissionID, &out.CatID, &out.Role, &out.AssignedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.MissionAssignment{}, serviceerrors.ErrCatNotFound
+		}
+
+		switch code, _ := postgresql.ErrorCode(err); code {
+		case postgresql.UniqueViolation:
+			return domain.MissionAssignment{}, serviceerrors.ErrAlreadyTeamMember
+		case postgresql.ForeignKeyViolation:
+			return domain.MissionAssignment{}, serviceerrors.ErrMissionNotFound
+		}
+		return domain.MissionAssignment{}, err
+	}
+	return out, nil
+}
+
+// RemoveTeamMember drops a non-lead member and clears the goals assigned to them.
+func (r *MissionRepo) RemoveTeamMember(ctx context.Context, missionID, catID int64) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var role domain.TeamRole
+	err = tx.QueryRowContext(ctx, `
+		DELETE FROM mission_assignments
+		WHERE mission_id = $1 AND cat_id = $2 AND role <> 'lead'
+		RETURNING role;`, missionID, catID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return serviceerrors.ErrNotTeamMember
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE mission_goals
+		SET assignee_cat_id = NULL, updated_at = now()
+		WHERE mission_id = $1 AND assignee_cat_id = $2;`, missionID, catID); err != nil {
+		return fmt.Errorf("clear goal assignees: %w", err)
+	}
+
+	return tx.Commit()
+}
+func (r *MissionRepo) ListTeam(ctx context.Context, missionID int64) ([]domain.MissionAssignment, error) {
+	q := `
+		SELECT mission_id, cat_id, role, assigned_at
+		FROM mission_assignments
+		WHERE mission_id = $1
+		ORDER BY CASE role WHEN 'lead' THEN 0 WHEN 'support' THEN 1 ELSE 2 END, assigned_at, cat_id;`
+
+	rows, err := r.db.QueryContext(ctx, q, missionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.MissionAssignment, 0)
+	for rows.Next() {
+		var a domain.MissionAssignment
+		if err := rows.Scan(&a.MissionID, &a.CatID, &a.Role, &a.AssignedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// SetGoalAssignee assigns a goal to a team member, or clears it with a nil cat.
+func (r *MissionRepo) SetGoalAssignee(ctx context.Context, missionID, goalID int64, catID *int64) (domain.MissionGoal, error) {
+	q := `
+		UPDATE mission_goals g
+		SET assignee_cat_id = $3, updated_at = now()
+		WHERE g.id = $2 AND g.mission_id = $1
+		  AND ($3::bigint IS NULL OR EXISTS (
+			SELECT 1 FROM mission_assignments a
+			WHERE a.mission_id = $1 AND a.cat_id = $3
+		  ))
+		RETURNING ` + goalColumns + `;`
+
+	g, err := scanGoal(r.db.QueryRowContext(ctx, q, missionID, goalID, catID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			var exists bool
+			if err := r.db.QueryRowContext(ctx, `
+				SELECT EXISTS (SELECT 1 FROM mission_goals WHERE id = $2 AND mission_id = $1);`,
+				missionID, goalID).Scan(&exists); err != nil {
+				return domain.MissionGoal{}, err
+			}
+			if !exists {
+				return domain.MissionGoal{}, serviceerrors.ErrGoalNotFound
+			}
+			return domain.MissionGoal{}, serviceerrors.ErrNotTeamMember
+		}
+		return domain.MissionGoal{}, err
+	}
+	return g, nil
+}
+
+// ListCatMissions returns every mission the cat is on, whatever the role.
+func (r *MissionRepo) ListCatMissions(ctx context.Context, catID int64) ([]domain.CatMission, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM cats WHERE id = $1 AND deleted_at IS NULL);`, catID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, serviceerrors.ErrCatNotFound
+	}
+
+	q := `
+		SELECT m.id, m.title, m.status, m.cat_id, m.due_at, m.priority, m.created_at, a.role
+		FROM mission_assignments a
+		JOIN missions m ON m.id = a.mission_id
+		WHERE a.cat_id = $1
+		ORDER BY m.created_at DESC, m.id DESC;`
+
+	rows, err := r.db.QueryContext(ctx, q, catID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.CatMission, 0)
+	for rows.Next() {
+		var cm domain.CatMission
+		it := &cm.Mission
+		if err := rows.Scan(&it.ID, &it.Title, &it.Status, &it.CatID, &it.DueAt, &it.Priority, &it.CreatedAt, &cm.Role); err != nil {
+			return nil, err
+		}
+		out = append(out, cm)
+	}
+	return out, rows.Err()
+}
+
+// ListCandidateStats gathers, for every cat not yet on the mission team, what the
+// recommendation needs: current workload and goal history in the given countries.
+func (r *MissionRepo) ListCandidateStats(ctx context.Context, missionID int64, countries []string) ([]domain.CandidateStats, error) {
+	q := `
+		SELECT c.id, c.name, c.years_experience, c.breed, c.salary, c.salary_currency,
+		       EXISTS (
+		         SELECT 1
+		         FROM mission_assignments a
+		         JOIN missions m ON m.id = a.mission_id
+		         WHERE a.cat_id = c.id AND m.status IN ('active', 'paused')
+		       ) AS busy,
+		       EXISTS (
+		         SELECT 1
+		         FROM mission_assignments a
+		         JOIN missions m ON m.id = a.mission_id
+		         WHERE a.cat_id = c.id AND m.status = 'planned'
+		       ) AS planned,
+		       COALESCE(h.done, 0), COALESCE(h.total, 0)
+		FROM cats c
+		LEFT JOIN LATERAL (
+		  SELECT count(*) FILTER (WHERE g.status = 'done') AS done,
+		         count(*) FILTER (WHERE g.status <> 'skipped') AS total
+		  FROM mission_assignments a
+		  JOIN missions m      ON m.id = a.mission_id AND m.status IN ('completed', 'aborted', 'failed')
+		  JOIN mission_goals g ON g.mission_id = m.id AND g.country = ANY($2)
+		  WHERE a.cat_id = c.id
+		) h ON true
+		WHERE c.deleted_at IS NULL
+		  AND NOT EXISTS (
+		    SELECT 1 FROM mission_assignments a WHERE a.mission_id = $1 AND a.cat_id = c.id
+		  )
+		ORDER BY c.id;`
+
+	rows, err := r.db.QueryContext(ctx, q, missionID, pq.Array(countries))
+	if err != nil {
+		return nil, fmt.Errorf("candidate stats: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.CandidateStats
+	for rows.Next() {
+		var st domain.CandidateStats
+		if err := rows.Scan(
+			&st.Cat.ID,
+			&st.Cat.Name,
+			&st.Cat.YearsExperience,
+			&st.Cat.Breed,
+			&st.Cat.Salary,
+			&st.Cat.Salary.Currency,
+			&st.Busy,
+			&st.Planned,
+			&st.CountryGoalsDone,
+			&st.CountryGoalsTotal,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, st)
+	}
+	return out, rows.Err()
+}
+
+// GetMissionForUpdate locks the mission row for the rest of the transaction.
+func (r *MissionRepo) GetMissionForUpdate(ctx context.Context, tx service.Tx, id int64) (domain.Mission, error) {
+	pgtx := tx.(*pgTx)
+
+	q := `
+		SELECT ` + missionColumns + `
+		FROM missions
+		WHERE id = $1
+		FOR UPDATE;`
+
+	m, err := scanMission(pgtx.tx.QueryRowContext(ctx, q, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Mission{}, serviceerrors.ErrMissionNotFound
+		}
+		return domain.Mission{}, err
+	}
+	return m, nil
+}
+func (r *MissionRepo) InsertHandover(ctx context.Context, tx service.Tx, h *domain.MissionHandover) error {
+	pgtx := tx.(*pgTx)
+
+	q := `
+		INSERT INTO mission_handovers (mission_id, from_cat_id, to_cat_id, note)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at;`
+
+	return pgtx.tx.QueryRowContext(ctx, q, h.MissionID, h.FromCatID, h.ToCatID, h.Note).Scan(&h.ID, &h.CreatedAt)
+}
+
+// AttributeDoneGoals credits the cat with every done goal of the mission not credited yet.
+func (r *MissionRepo) AttributeDoneGoals(ctx context.Context, tx service.Tx, missionID, catID int64) error {
+	pgtx := tx.(*pgTx)
+
+	q := `
+		UPDATE mission_goals
+		SET done_by_cat_id = $2, updated_at = now()
+		WHERE mission_id = $1 AND status = 'done' AND done_by_cat_id IS NULL;`
+
+	_, err := pgtx.tx.ExecContext(ctx, q, missionID, catID)
+	return err
+}
+func (r *MissionRepo) ListHandovers(ctx context.Context, missionID int64) ([]domain.MissionHandover, error) {
+	q := `
+		SELECT id, mission_id, from_cat_id, to_cat_id, note, created_at
+		FROM mission_handovers
+		WHERE mission_id = $1
+		ORDER BY created_at, id;`
+
+	rows, err := r.db.QueryContext(ctx, q, missionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.MissionHandover, 0)
+	for rows.Next() {
+		var h domain.MissionHandover
+		if err := rows.Scan(&h.ID, &h.MissionID, &h.FromCatID, &h.ToCatID, &h.Note, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, h)
+	}
+	return out, rows.Err()
+}
+func (r *MissionRepo) GetMission(ctx context.Context, id int64) (domain.Mission, error) {
 	q := `
-		SELECT id, title, description,status, cat_id, created_at, updated_at
+		SELECT ` + missionColumns + `
 		FROM missions
 		WHERE id = $1;`
 
-	err := r.db.QueryRowContext(ctx, q, id).
-		Scan(
-			&m.ID,
-			&m.Title,
-			&m.Description,
-			&m.Status,
-			&m.CatID,
-			&m.CreatedAt,
-			&m.UpdatedAt,
-		)
+	m, err := scanMission(r.db.QueryRowContext(ctx, q, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Mission{}, serviceerrors.ErrMissionNotFound
@@ -120,10 +573,10 @@ func (r *MissionRepo) GetMission(ctx context.Context, id int64) (domain.Mission,
 }
 func (r *MissionRepo) GetMissionGoals(ctx context.Context, missionID int64) ([]domain.MissionGoal, error) {
 	q := `
-		SELECT id, mission_id, name, country, notes, status, created_at, updated_at
+		SELECT ` + goalColumns + `
 		FROM mission_goals
 		WHERE mission_id = $1
-		ORDER BY id;
+		ORDER BY position, id;
 	`
 	rows, err := r.db.QueryContext(ctx, q, missionID)
 	if err != nil {
@@ -133,8 +586,8 @@ func (r *MissionRepo) GetMissionGoals(ctx context.Context, missionID int64) ([]d
 
 	var out []domain.MissionGoal
 	for rows.Next() {
-		var g domain.MissionGoal
-		if err := rows.Scan(&g.ID, &g.MissionID, &g.Name, &g.Country, &g.Notes, &g.Status, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		g, err := scanGoal(rows)
+		if err != nil {
 			return nil, err
 		}
 		out = append(out, g)
@@ -169,12 +622,55 @@ func buildWhere(f domain.MissionFilter) whereParts {
 		i++
 	}
 
-	// title ILIKE $N
+	if f.MemberCatID != nil {
+		conds = append(conds, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM mission_assignments a WHERE a.mission_id = missions.id AND a.cat_id = $%d)", i))
+		args = append(args, *f.MemberCatID)
+		i++
+	}
+
+	if f.Priority != nil {
+		conds = append(conds, fmt.Sprintf("priority = $%d", i))
+		args = append(args, *f.Priority)
+		i++
+	}
+
+	if f.DueBefore != nil {
+		conds = append(conds, fmt.Sprintf("due_at < $%d", i))
+		args = append(args, *f.DueBefore)
+		i++
+	}
+
+	if f.DueAfter != nil {
+		conds = append(conds, fmt.Sprintf("due_at >= $%d", i))
+		args = append(args, *f.DueAfter)
+		i++
+	}
+
+	if f.Overdue {
+		conds = append(conds, "status = 'active' AND due_at < now()")
+	}
+
+	// any goal inside the box; a box crossing the antimeridian matches either side
+	if f.BBox != nil {
+		lonCond := "g.longitude BETWEEN $%[3]d AND $%[4]d"
+		if f.BBox.CrossesAntimeridian() {
+			lonCond = "(g.longitude >= $%[3]d OR g.longitude <= $%[4]d)"
+		}
+		conds = append(conds, fmt.Sprintf(`EXISTS (SELECT 1 FROM mission_goals g
+			WHERE g.mission_id = missions.id
+			AND g.latitude BETWEEN $%[1]d AND $%[2]d
+			AND `+lonCond+`)`, i, i+1, i+2, i+3))
+		args = append(args, f.BBox.MinLat, f.BBox.MaxLat, f.BBox.MinLon, f.BBox.MaxLon)
+		i += 4
+	}
+
+	// full-text match over title, description and goals, see 0014_mission_search
 	if f.Q != nil {
 		q := strings.TrimSpace(*f.Q)
 		if q != "" {
-			conds = append(conds, fmt.Sprintf("title ILIKE $%d", i))
-			args = append(args, "%"+q+"%")
+			conds = append(conds, fmt.Sprintf("search_vector @@ websearch_to_tsquery('english', $%d)", i))
+			args = append(args, q)
 			i++
 		}
 	}
@@ -188,15 +684,55 @@ func buildWhere(f domain.MissionFilter) whereParts {
 		args: args,
 	}
 }
-func (r *MissionRepo) queryItems(ctx context.Context, w whereParts, limit, offset int) ([]domain.MissionListItem, error) {
-	sel := `
-	SELECT id, title, status, cat_id, created_at
-	FROM missions
-	`
-	order := `
+
+// missionSortColumns maps domain.MissionSortFields to SQL.
+var missionSortColumns = map[string]string{
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"due_at":       "due_at",
+	"priority":     "priority",
+	"started_at":   "started_at",
+	"completed_at": "completed_at",
+	"title":        "lower(title)",
+	"status":       "status",
+}
+
+// buildOrder renders the ORDER BY clause. id is always appended so pages stay
+// stable when the requested keys tie.
+func buildOrder(sort []domain.SortField) (string, error) {
+	if len(sort) == 0 {
+		return `
 	ORDER BY created_at
 	DESC, id DESC
+	`, nil
+	}
+
+	keys := make([]string, 0, len(sort)+1)
+	for _, f := range sort {
+		col, ok := missionSortColumns[f.Name]
+		if !ok {
+			return "", fmt.Errorf("unknown sort column %q", f.Name)
+		}
+		dir := "ASC"
+		if f.Desc {
+			dir = "DESC"
+		}
+		keys = append(keys, col+" "+dir+" NULLS LAST")
+	}
+	keys = append(keys, "id DESC")
+
+	return " ORDER BY " + strings.Join(keys, ", ") + " ", nil
+}
+
+func (r *MissionRepo) queryItems(ctx context.Context, w whereParts, sort []domain.SortField, limit, offset int) ([]domain.MissionListItem, error) {
+	sel := `
+	SELECT id, title, status, cat_id, due_at, priority, created_at
+	FROM missions
 	`
+	order, err := buildOrder(sort)
+	if err != nil {
+		return nil, err
+	}
 
 	limitPos := len(w.args) + 1
 	offsetPos := limitPos + 1
@@ -223,6 +759,8 @@ func (r *MissionRepo) queryItems(ctx context.Context, w whereParts, limit, offse
 			&it.Title,
 			&it.Status,
 			&cat,
+			&it.DueAt,
+			&it.Priority,
 			&it.CreatedAt); err != nil {
 			return nil, err
 		}
@@ -255,7 +793,7 @@ func (r *MissionRepo) queryTotal(ctx context.Context, w whereParts) (int, error)
 func (r *MissionRepo) ListMissions(ctx context.Context, f domain.MissionFilter) ([]domain.MissionListItem, int, error) {
 	w := buildWhere(f)
 
-	items, err := r.queryItems(ctx, w, f.Limit, f.Offset)
+	items, err := r.queryItems(ctx, w, f.Sort, f.Limit, f.Offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("items: %w", err)
 	}
@@ -267,24 +805,66 @@ func (r *MissionRepo) ListMissions(ctx context.Context, f domain.MissionFilter)
 
 	return items, total, nil
 }
-func (r *MissionRepo) UpdateStatusIfCurrent(ctx context.Context, id int64, newStatus, expected domain.MissionStatus) (domain.Mission, bool, error) {
+
+// StreamMissions walks every mission matching the filter row by row.
+// Limit and offset are ignored.
+func (r *MissionRepo) StreamMissions(ctx context.Context, f domain.MissionFilter, fn func(domain.Mission) error) error {
+	w := buildWhere(f)
+
+	q := `
+	SELECT ` + missionColumns + `
+	FROM missions
+	` + w.sql + `
+	ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, w.args...)
+	if err != nil {
+		return fmt.Errorf("stream missions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		m, err := scanMission(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// UpdateStatusIfCurrent changes the status only while it is still expected.
+// Activation also requires every dependency to be completed, so a dependency
+// cannot slip in between the service check and the update. Completion awards
+// the bonuses of the assigned cat in the same transaction.
+func (r *MissionRepo) UpdateStatusIfCurrent(ctx context.Context, id int64, newStatus, expected domain.MissionStatus, reason string) (domain.Mission, bool, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return domain.Mission{}, false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
 	q := `
 	UPDATE missions
-	SET status = $2, updated_at = now()
+	SET status = $2,
+	    status_reason = $4,
+	    started_at = CASE WHEN $2 = 'active' THEN COALESCE(started_at, now()) ELSE started_at END,
+	    completed_at = CASE WHEN $2 = 'completed' THEN now() ELSE completed_at END,
+	    updated_at = now()
 	WHERE id = $1 AND status = $3
-	RETURNING id, title, description, status, cat_id, created_at, updated_at;
+	  AND ($2 <> 'active' OR NOT EXISTS (
+	    SELECT 1
+	    FROM mission_dependencies d
+	    JOIN missions dep ON dep.id = d.depends_on_id
+	    WHERE d.mission_id = $1 AND dep.status <> 'completed'
+	  ))
+	RETURNING ` + missionColumns + `;
 	`
-	var m domain.Mission
-	err := r.db.QueryRowContext(ctx, q, id, newStatus, expected).
-		Scan(
-			&m.ID,
-			&m.Title,
-			&m.Description,
-			&m.Status,
-			&m.CatID,
-			&m.CreatedAt,
-			&m.UpdatedAt,
-		)
+	m, err := scanMission(tx.QueryRowContext(ctx, q, id, newStatus, expected, reason))
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.Mission{}, false, nil
 	}
@@ -292,29 +872,36 @@ func (r *MissionRepo) UpdateStatusIfCurrent(ctx context.Context, id int64, newSt
 		return domain.Mission{}, false, err
 	}
 
+	if m.Status == domain.StatusCompleted {
+		if err := awardBonuses(ctx, tx, m); err != nil {
+			return domain.Mission{}, false, fmt.Errorf("award bonuses: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.Mission{}, false, err
+	}
+
 	return m, true, nil
 }
+
+// InsertGoal appends a goal after the last one of the mission.
 func (r *MissionRepo) InsertGoal(ctx context.Context, missionID int64, p domain.CreateGoalParams) (domain.MissionGoal, error) {
 	q := `
-	INSERT INTO mission_goals (mission_id, name, country, notes)
-	VALUES ($1, $2, $3, $4)
-	RETURNING id, mission_id, name, country, notes, status, created_at, updated_at;
+	INSERT INTO mission_goals (mission_id, name, country, notes, latitude, longitude, radius_m, position)
+	SELECT $1, $2, $3, $4, $5, $6, $7, COALESCE(MAX(position), 0) + 1
+	FROM mission_goals
+	WHERE mission_id = $1
+	RETURNING ` + goalColumns + `;
 	`
-	var g domain.MissionGoal
-	if err := r.db.QueryRowContext(ctx, q, missionID, p.Name, p.Country, p.Notes).
-		Scan(
-			&g.ID,
-			&g.MissionID,
-			&g.Name,
-			&g.Country,
-			&g.Notes,
-			&g.Status,
-			&g.CreatedAt,
-			&g.UpdatedAt); err != nil {
-
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+	lat, lon, radius := locationArgs(p.Location)
+	g, err := scanGoal(r.db.QueryRowContext(ctx, q, missionID, p.Name, p.Country, p.Notes, lat, lon, radius))
+	if err != nil {
+
+		switch code, _ := postgresql.ErrorCode(err); code {
+		case postgresql.ForeignKeyViolation:
 			return domain.MissionGoal{}, serviceerrors.ErrMissionNotFound
+		case postgresql.UniqueViolation: // another goal took the position
+			return domain.MissionGoal{}, serviceerrors.ErrConflict
 		}
 
 		return domain.MissionGoal{}, err
@@ -322,3 +909,130 @@ func (r *MissionRepo) InsertGoal(ctx context.Context, missionID int64, p domain.
 
 	return g, nil
 }
+
+// ListUnassignedPlanned returns planned missions without a cat, most urgent first.
+func (r *MissionRepo) ListUnassignedPlanned(ctx context.Context, limit int) ([]domain.Mission, error) {
+	q := `
+		SELECT ` + missionColumns + `
+		FROM missions
+		WHERE status = 'planned' AND cat_id IS NULL
+		ORDER BY priority ASC, due_at ASC NULLS LAST, created_at ASC, id ASC
+		LIMIT $1;`
+
+	rows, err := r.db.QueryContext(ctx, q, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list unassigned missions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.Mission
+	for rows.Next() {
+		m, err := scanMission(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// InsertAutoAssignment records a decision taken by the assignment scheduler.
+func (r *MissionRepo) InsertAutoAssignment(ctx context.Context, a *domain.AutoAssignment) error {
+	q := `
+		INSERT INTO auto_assignments (mission_id, cat_id, policy, score, outcome, detail)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at;`
+
+	err := r.db.QueryRowContext(ctx, q,
+		a.MissionID, a.CatID, a.Policy, a.Score, a.Outcome, a.Detail,
+	).Scan(&a.ID, &a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert auto assignment: %w", err)
+	}
+	return nil
+}
+
+// UpdateGoalStatusIfCurrent moves a goal to a new state only while it is still
+// in expected. Starting a goal stamps started_at, resolving it stamps finished_at
+// and a done goal is credited to its assignee or the mission lead.
+func (r *MissionRepo) UpdateGoalStatusIfCurrent(ctx context.Context, missionID, goalID int64, newStatus, expected domain.MissionGoalStatus) (domain.MissionGoal, bool, error) {
+	q := `
+	UPDATE mission_goals g
+	SET status = $3,
+	    started_at = CASE
+	      WHEN $3 = 'in_progress' THEN COALESCE(g.started_at, now())
+	      WHEN $3 = 'todo' THEN NULL
+	      ELSE g.started_at END,
+	    finished_at = CASE WHEN $3 IN ('done', 'failed', 'skipped') THEN now() ELSE NULL END,
+	    done_by_cat_id = CASE
+	      WHEN $3 = 'done' THEN COALESCE(g.assignee_cat_id, m.cat_id)
+	      ELSE NULL END,
+	    updated_at = now()
+	FROM missions m
+	WHERE g.id = $2 AND g.mission_id = $1 AND m.id = g.mission_id AND g.status = $4
+	RETURNING ` + prefixColumns("g", goalColumns) + `;
+	`
+	g, err := scanGoal(r.db.QueryRowContext(ctx, q, missionID, goalID, newStatus, expected))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.MissionGoal{}, false, nil
+	}
+	if err != nil {
+		return domain.MissionGoal{}, false, err
+	}
+	return g, true, nil
+}
+
+// ReorderGoals sets the goal positions to follow ids, which must list every
+// goal of the mission exactly once.
+func (r *MissionRepo) ReorderGoals(ctx context.Context, missionID int64, ids []int64) ([]domain.MissionGoal, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `SELECT id FROM missions WHERE id = $1 FOR UPDATE;`, missionID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceerrors.ErrMissionNotFound
+		}
+		return nil, err
+	}
+
+	var matches bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT count(*) = cardinality($2::bigint[])
+		   AND count(*) FILTER (WHERE id = ANY($2)) = count(*)
+		FROM mission_goals
+		WHERE mission_id = $1;`, missionID, pq.Array(ids)).Scan(&matches)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, serviceerrors.ErrInvalidGoalOrder
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE mission_goals g
+		SET position = o.pos, updated_at = now()
+		FROM unnest($2::bigint[]) WITH ORDINALITY AS o(id, pos)
+		WHERE g.id = o.id AND g.mission_id = $1 AND g.position <> o.pos;`,
+		missionID, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("reorder goals: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetMissionGoals(ctx, missionID)
+}
+
+// prefixColumns qualifies every column of a column list with a table alias.
+func prefixColumns(alias, cols string) string {
+	parts := strings.Split(cols, ",")
+	for i, p := range parts {
+		parts[i] = alias + "." + strings.TrimSpace(p)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/lib/pq"
+)
+
+// The app talks to Postgres through lib/pq, so the mapping is checked with
+// the error type that driver returns.
+func TestInsertMissionError(t *testing.T) {
+	dup := fmt.Errorf("scan: %w", &pq.Error{Code: "23505", Constraint: "uq_missions_occurrence"})
+	if err := insertMissionError(dup); !errors.Is(err, serviceerrors.ErrOccurrenceExists) {
+		t.Fatalf("want ErrOccurrenceExists, got %v", err)
+	}
+
+	other := &pq.Error{Code: "23505", Constraint: "missions_pkey"}
+	if err := insertMissionError(other); err != other {
+		t.Fatalf("other unique violations must pass through, got %v", err)
+	}
+}
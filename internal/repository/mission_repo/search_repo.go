@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+// ts_headline marks matches with control characters instead of tags, so the
+// stored text can be escaped first; highlightHTML then turns them into <mark>.
+const (
+	markStart = "\x01"
+	markStop  = "\x02"
+
+	titleHeadlineOptions = `StartSel="` + markStart + `", StopSel="` + markStop + `", HighlightAll=true`
+	headlineOptions      = `StartSel="` + markStart + `", StopSel="` + markStop + `", MaxFragments=2, MaxWords=25, MinWords=8, FragmentDelimiter=" … "`
+)
+
+var markReplacer = strings.NewReplacer(markStart, "<mark>", markStop, "</mark>")
+
+// highlightHTML makes a ts_headline result safe to embed in HTML.
+func highlightHTML(s string) string {
+	return markReplacer.Replace(html.EscapeString(s))
+}
+
+// SearchMissions ranks missions against a web search query. Headlines are
+// expensive, so they are only built for the requested page.
+func (r *MissionRepo) SearchMissions(ctx context.Context, p domain.SearchParams) ([]domain.SearchHit, int, error) {
+	where := `WHERE m.search_vector @@ q.query`
+	args := []any{p.Q}
+	if p.Status != nil {
+		where += ` AND m.status = $2`
+		args = append(args, *p.Status)
+	}
+	n := len(args)
+
+	q := fmt.Sprintf(`
+	WITH q AS (SELECT websearch_to_tsquery('english', $1) AS query),
+	page AS (
+		SELECT m.id, m.title, m.description, m.status, m.cat_id, m.created_at,
+		       ts_rank_cd(m.search_vector, q.query) AS rank
+		FROM missions m, q
+		%s
+		ORDER BY rank DESC, m.id DESC
+		LIMIT $%d OFFSET $%d
+	)
+	SELECT p.id, p.title, p.status, p.cat_id, p.rank, p.created_at,
+	       ts_headline('english', p.title, q.query, $%d),
+	       ts_headline('english', concat_ws(' ', p.description, g.text), q.query, $%d)
+	FROM page p
+	CROSS JOIN q
+	LEFT JOIN LATERAL (
+		SELECT string_agg(concat_ws(' ', name, notes), ' ' ORDER BY position) AS text
+		FROM mission_goals
+		WHERE mission_id = p.id
+	) g ON true
+	ORDER BY p.rank DESC, p.id DESC;
+	`, where, n+1, n+2, n+3, n+4)
+
+	rows, err := r.db.QueryContext(ctx, q, append(args, p.Limit, p.Offset, titleHeadlineOptions, headlineOptions)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search: %w", err)
+	}
+	defer rows.Close()
+
+	hits := make([]domain.SearchHit, 0, p.Limit)
+	for rows.Next() {
+		var (
+			h   domain.SearchHit
+			cat sql.NullInt64
+		)
+		if err := rows.Scan(&h.ID, &h.Title, &h.Status, &cat, &h.Rank, &h.CreatedAt, &h.TitleHighlight, &h.Snippet); err != nil {
+			return nil, 0, err
+		}
+		if cat.Valid {
+			v := cat.Int64
+			h.CatID = &v
+		}
+		h.TitleHighlight, h.Snippet = highlightHTML(h.TitleHighlight), highlightHTML(h.Snippet)
+		hits = append(hits, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	count := `
+	WITH q AS (SELECT websearch_to_tsquery('english', $1) AS query)
+	SELECT count(*)
+	FROM missions m, q
+	` + where
+	var total int
+	if err := r.db.QueryRowContext(ctx, count, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("search count: %w", err)
+	}
+
+	return hits, total, nil
+}
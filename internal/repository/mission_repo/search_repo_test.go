@@ -0,0 +1,11 @@
+package postgres
+
+import "testing"
+
+func TestHighlightHTML(t *testing.T) {
+	got := highlightHTML("<script>x</script> near the " + markStart + "harbour" + markStop + " & docks")
+	want := "&lt;script&gt;x&lt;/script&gt; near the <mark>harbour</mark> &amp; docks"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
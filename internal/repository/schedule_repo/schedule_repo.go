@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/postgresql"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type ScheduleRepo struct {
+	db *sql.DB
+}
+
+func NewScheduleRepository(db *sql.DB) *ScheduleRepo {
+	return &ScheduleRepo{db: db}
+}
+
+const scheduleColumns = `id, template_id, mission_id, cron, timezone, EXTRACT(EPOCH FROM lead_time)::bigint, vars, enabled, next_run_at, last_run_at, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSchedule(row rowScanner) (domain.MissionSchedule, error) {
+	var (
+		s        domain.MissionSchedule
+		leadSecs int64
+		vars     []byte
+	)
+	err := row.Scan(
+		&s.ID,
+		&s.TemplateID,
+		&s.MissionID,
+		&s.Cron,
+		&s.Timezone,
+		&leadSecs,
+		&vars,
+		&s.Enabled,
+		&s.NextRunAt,
+		&s.LastRunAt,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return domain.MissionSchedule{}, err
+	}
+	s.LeadTime = time.Duration(leadSecs) * time.Second
+	if err := json.Unmarshal(vars, &s.Vars); err != nil {
+		return domain.MissionSchedule{}, fmt.Errorf("decode schedule vars: %w", err)
+	}
+	return s, nil
+}
+
+func (r *ScheduleRepo) InsertSchedule(ctx context.Context, s *domain.MissionSchedule) error {
+	vars, err := json.Marshal(s.Vars)
+	if err != nil {
+		return err
+	}
+
+	q := `
+		INSERT INTO mission_schedules (template_id, mission_id, cron, timezone, lead_time, vars, enabled, next_run_at)
+		VALUES ($1, $2, $3, $4, make_interval(secs => $5), $6, $7, $8)
+		RETURNING ` + scheduleColumns + `;`
+
+	out, err := scanSchedule(r.db.QueryRowContext(ctx, q,
+		s.TemplateID, s.MissionID, s.Cron, s.Timezone, s.LeadTime.Seconds(), vars, s.Enabled, s.NextRunAt,
+	))
+	if err != nil {
+		if code, _ := postgresql.ErrorCode(err); code == postgresql.ForeignKeyViolation {
+			if s.TemplateID != nil {
+				return serviceerrors.ErrTemplateNotFound
+			}
+			return serviceerrors.ErrMissionNotFound
+		}
+		return fmt.Errorf("insert schedule: %w", err)
+	}
+	*s = out
+	return nil
+}
+
+func (r *ScheduleRepo) GetSchedule(ctx context.Context, id int64) (domain.MissionSchedule, error) {
+	q := `
+		SELECT ` + scheduleColumns + `
+		FROM mission_schedules
+		WHERE id = $1;`
+
+	s, err := scanSchedule(r.db.QueryRowContext(ctx, q, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.MissionSchedule{}, serviceerrors.ErrScheduleNotFound
+		}
+		return domain.MissionSchedule{}, err
+	}
+	return s, nil
+}
+
+func (r *ScheduleRepo) ListSchedules(ctx context.Context, limit, offset int) ([]domain.MissionSchedule, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT count(*) FROM mission_schedules;`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	q := `
+		SELECT ` + scheduleColumns + `
+		FROM mission_schedules
+		ORDER BY id
+		LIMIT $1 OFFSET $2;`
+
+	return r.query(ctx, q, total, limit, offset)
+}
+
+// ListDueSchedules returns enabled schedules whose next run falls within
+// their lead time of now.
+func (r *ScheduleRepo) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]domain.MissionSchedule, error) {
+	q := `
+		SELECT ` + scheduleColumns + `
+		FROM mission_schedules
+		WHERE enabled AND next_run_at - lead_time <= $1
+		ORDER BY next_run_at, id
+		LIMIT $2;`
+
+	out, _, err := r.query(ctx, q, 0, now, limit)
+	return out, err
+}
+
+func (r *ScheduleRepo) query(ctx context.Context, q string, total int, args ...any) ([]domain.MissionSchedule, int, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var out []domain.MissionSchedule
+	for rows.Next() {
+		s, err := scanSchedule(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		out = append(out, s)
+	}
+	return out, total, rows.Err()
+}
+
+// AdvanceSchedule moves the schedule to its next run. It only applies when the
+// schedule is still at expected, so a concurrent edit is not overwritten.
+func (r *ScheduleRepo) AdvanceSchedule(ctx context.Context, id int64, expected, next time.Time) error {
+	q := `
+		UPDATE mission_schedules
+		SET next_run_at = $3, last_run_at = $2, updated_at = now()
+		WHERE id = $1 AND next_run_at = $2;`
+
+	_, err := r.db.ExecContext(ctx, q, id, expected, next)
+	return err
+}
+
+// SetScheduleEnabled toggles the schedule. next is the run to resume from.
+func (r *ScheduleRepo) SetScheduleEnabled(ctx context.Context, id int64, enabled bool, next time.Time) (domain.MissionSchedule, error) {
+	q := `
+		UPDATE mission_schedules
+		SET enabled = $2, next_run_at = $3, updated_at = now()
+		WHERE id = $1
+		RETURNING ` + scheduleColumns + `;`
+
+	s, err := scanSchedule(r.db.QueryRowContext(ctx, q, id, enabled, next))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.MissionSchedule{}, serviceerrors.ErrScheduleNotFound
+		}
+		return domain.MissionSchedule{}, err
+	}
+	return s, nil
+}
+
+func (r *ScheduleRepo) DeleteSchedule(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM mission_schedules WHERE id = $1;`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return serviceerrors.ErrScheduleNotFound
+	}
+	return nil
+}
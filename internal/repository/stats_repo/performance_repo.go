@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+// CatPerformance derives a cat's record from mission and goal timestamps.
+// A nil since covers the whole history.
+func (r *StatsRepo) CatPerformance(ctx context.Context, catID int64, since *time.Time) (domain.CatPerformance, error) {
+	p := domain.CatPerformance{CatID: catID, Since: since}
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT name FROM cats WHERE id = $1 AND deleted_at IS NULL;`, catID,
+	).Scan(&p.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.CatPerformance{}, serviceerrors.ErrCatNotFound
+		}
+		return domain.CatPerformance{}, fmt.Errorf("get cat: %w", err)
+	}
+
+	var avgSeconds sql.NullFloat64
+	err = r.db.QueryRowContext(ctx, `
+		SELECT count(*) FILTER (WHERE m.status = 'completed'),
+		       count(*) FILTER (WHERE m.status = 'failed'),
+		       avg(EXTRACT(EPOCH FROM m.completed_at - m.started_at)) FILTER (WHERE m.status = 'completed')
+		FROM mission_assignments a
+		JOIN missions m ON m.id = a.mission_id
+		WHERE a.cat_id = $1
+		  AND m.status IN ('completed', 'failed')
+		  AND ($2::timestamptz IS NULL OR m.completed_at >= $2);`, catID, since,
+	).Scan(&p.MissionsCompleted, &p.MissionsFailed, &avgSeconds)
+	if err != nil {
+		return domain.CatPerformance{}, fmt.Errorf("missions: %w", err)
+	}
+	p.AvgCompletionTime = secondsToDuration(avgSeconds)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT country, count(*)
+		FROM mission_goals
+		WHERE done_by_cat_id = $1
+		  AND status = 'done'
+		  AND ($2::timestamptz IS NULL OR finished_at >= $2)
+		GROUP BY country
+		ORDER BY count(*) DESC, country;`, catID, since)
+	if err != nil {
+		return domain.CatPerformance{}, fmt.Errorf("countries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c domain.CountryActivity
+		if err := rows.Scan(&c.Country, &c.GoalsDone); err != nil {
+			return domain.CatPerformance{}, err
+		}
+		p.GoalsDone += c.GoalsDone
+		p.Countries = append(p.Countries, c)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.CatPerformance{}, err
+	}
+
+	return p, nil
+}
+
+// Leaderboard ranks cats with at least one completed mission or done goal
+// since p.Since. Ties on the chosen metric fall back to the other metric,
+// then to the faster average, then to id.
+func (r *StatsRepo) Leaderboard(ctx context.Context, p domain.LeaderboardParams) ([]domain.LeaderboardEntry, error) {
+	order := `missions DESC, goals DESC`
+	if p.Metric == domain.MetricGoals {
+		order = `goals DESC, missions DESC`
+	}
+
+	q := `
+	WITH m AS (
+		SELECT a.cat_id,
+		       count(*) AS missions,
+		       avg(EXTRACT(EPOCH FROM ms.completed_at - ms.started_at)) AS avg_seconds
+		FROM mission_assignments a
+		JOIN missions ms ON ms.id = a.mission_id
+		WHERE ms.status = 'completed' AND ms.completed_at >= $1
+		GROUP BY a.cat_id
+	), g AS (
+		SELECT done_by_cat_id AS cat_id, count(*) AS goals
+		FROM mission_goals
+		WHERE status = 'done' AND done_by_cat_id IS NOT NULL AND finished_at >= $1
+		GROUP BY done_by_cat_id
+	), board AS (
+		SELECT c.id, c.name, c.breed,
+		       coalesce(m.missions, 0) AS missions,
+		       coalesce(g.goals, 0)    AS goals,
+		       m.avg_seconds
+		FROM cats c
+		LEFT JOIN m ON m.cat_id = c.id
+		LEFT JOIN g ON g.cat_id = c.id
+		WHERE c.deleted_at IS NULL
+		  AND (m.cat_id IS NOT NULL OR g.cat_id IS NOT NULL)
+	)
+	SELECT id, name, breed, missions, goals, avg_seconds
+	FROM board
+	ORDER BY ` + order + `, avg_seconds ASC NULLS LAST, id
+	LIMIT $2;`
+
+	rows, err := r.db.QueryContext(ctx, q, p.Since, p.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.LeaderboardEntry, 0, p.Limit)
+	for rows.Next() {
+		var (
+			e          domain.LeaderboardEntry
+			avgSeconds sql.NullFloat64
+		)
+		if err := rows.Scan(&e.CatID, &e.Name, &e.Breed, &e.MissionsCompleted, &e.GoalsDone, &avgSeconds); err != nil {
+			return nil, err
+		}
+		e.Rank = len(out) + 1
+		e.AvgCompletionTime = secondsToDuration(avgSeconds)
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func secondsToDuration(s sql.NullFloat64) time.Duration {
+	if !s.Valid {
+		return 0
+	}
+	return time.Duration(s.Float64 * float64(time.Second)).Round(time.Second)
+}
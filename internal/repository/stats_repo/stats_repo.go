@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+type StatsRepo struct {
+	db *sql.DB
+}
+
+func NewStatsRepository(db *sql.DB) *StatsRepo {
+	return &StatsRepo{db: db}
+}
+
+// Stats computes every dashboard aggregate inside one read-only snapshot, so
+// the numbers agree with each other.
+func (r *StatsRepo) Stats(ctx context.Context, weeks int) (domain.Stats, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return domain.Stats{}, fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	s := domain.Stats{
+		MissionsByStatus: make(map[domain.MissionStatus]int),
+		GoalsByStatus:    make(map[domain.MissionGoalStatus]int),
+	}
+
+	if err := countBy(ctx, tx, `SELECT status, count(*) FROM missions GROUP BY status`, func(k string, n int) {
+		s.MissionsByStatus[domain.MissionStatus(k)] = n
+	}); err != nil {
+		return domain.Stats{}, fmt.Errorf("missions by status: %w", err)
+	}
+
+	if err := countBy(ctx, tx, `SELECT status, count(*) FROM mission_goals GROUP BY status`, func(k string, n int) {
+		s.GoalsByStatus[domain.MissionGoalStatus(k)] = n
+	}); err != nil {
+		return domain.Stats{}, fmt.Errorf("goals by status: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT count(*),
+		       count(*) FILTER (WHERE NOT EXISTS (
+		         SELECT 1
+		         FROM mission_assignments a
+		         JOIN missions m ON m.id = a.mission_id
+		         WHERE a.cat_id = c.id AND m.status IN ('planned', 'active', 'paused')
+		       ))
+		FROM cats c
+		WHERE c.deleted_at IS NULL;`,
+	).Scan(&s.Cats, &s.IdleCats)
+	if err != nil {
+		return domain.Stats{}, fmt.Errorf("cats: %w", err)
+	}
+
+	var avgSeconds sql.NullFloat64
+	err = tx.QueryRowContext(ctx, `
+		SELECT avg(EXTRACT(EPOCH FROM completed_at - started_at))
+		FROM missions
+		WHERE status = 'completed' AND started_at IS NOT NULL AND completed_at IS NOT NULL;`,
+	).Scan(&avgSeconds)
+	if err != nil {
+		return domain.Stats{}, fmt.Errorf("avg duration: %w", err)
+	}
+	s.AvgMissionDuration = secondsToDuration(avgSeconds)
+
+	if s.SalaryByBreed, err = salaryByBreed(ctx, tx); err != nil {
+		return domain.Stats{}, fmt.Errorf("salary by breed: %w", err)
+	}
+
+	if s.CompletionsPerWeek, err = completionsPerWeek(ctx, tx, weeks); err != nil {
+		return domain.Stats{}, fmt.Errorf("completions per week: %w", err)
+	}
+
+	s.GeneratedAt = time.Now().UTC()
+	return s, nil
+}
+
+func countBy(ctx context.Context, tx *sql.Tx, q string, fn func(key string, n int)) error {
+	rows, err := tx.QueryContext(ctx, q)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			k string
+			n int
+		)
+		if err := rows.Scan(&k, &n); err != nil {
+			return err
+		}
+		fn(k, n)
+	}
+	return rows.Err()
+}
+
+func salaryByBreed(ctx context.Context, tx *sql.Tx) ([]domain.BreedSalary, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT breed, salary_currency, count(*), sum(salary)
+		FROM cats
+		WHERE deleted_at IS NULL
+		GROUP BY breed, salary_currency
+		ORDER BY sum(salary) DESC, breed, salary_currency;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.BreedSalary
+	for rows.Next() {
+		var b domain.BreedSalary
+		if err := rows.Scan(&b.Breed, &b.Total.Currency, &b.Cats, &b.Total); err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+// completionsPerWeek buckets completions into ISO weeks (starting Monday,
+// UTC) and fills weeks without completions with zero.
+func completionsPerWeek(ctx context.Context, tx *sql.Tx, weeks int) ([]domain.WeekCount, error) {
+	rows, err := tx.QueryContext(ctx, `
+		WITH w AS (
+		  SELECT generate_series(
+		    date_trunc('week', now() AT TIME ZONE 'UTC') - ($1::int - 1) * interval '1 week',
+		    date_trunc('week', now() AT TIME ZONE 'UTC'),
+		    interval '1 week'
+		  ) AS week_start
+		)
+		SELECT w.week_start, coalesce(c.n, 0)
+		FROM w
+		LEFT JOIN (
+		  SELECT date_trunc('week', completed_at AT TIME ZONE 'UTC') AS week_start, count(*) AS n
+		  FROM missions
+		  WHERE status = 'completed'
+		    AND completed_at >= (SELECT min(week_start) FROM w) AT TIME ZONE 'UTC'
+		  GROUP BY 1
+		) c USING (week_start)
+		ORDER BY w.week_start;`, weeks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.WeekCount, 0, weeks)
+	for rows.Next() {
+		var wc domain.WeekCount
+		if err := rows.Scan(&wc.WeekStart, &wc.Count); err != nil {
+			return nil, err
+		}
+		wc.WeekStart = time.Date(wc.WeekStart.Year(), wc.WeekStart.Month(), wc.WeekStart.Day(), 0, 0, 0, 0, time.UTC)
+		out = append(out, wc)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/postgresql"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type TemplateRepo struct {
+	db *sql.DB
+}
+
+func NewTemplateRepository(db *sql.DB) *TemplateRepo {
+	return &TemplateRepo{db: db}
+}
+
+const templateColumns = `id, name, title, description, priority, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTemplate(row rowScanner) (domain.MissionTemplate, error) {
+	var t domain.MissionTemplate
+	err := row.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Title,
+		&t.Description,
+		&t.Priority,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	return t, err
+}
+
+// CreateTemplate inserts the template and its goals in one transaction.
+func (r *TemplateRepo) CreateTemplate(ctx context.Context, t *domain.MissionTemplate) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	q := `
+		INSERT INTO mission_templates (name, title, description, priority)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at;`
+
+	err = tx.QueryRowContext(ctx, q, t.Name, t.Title, t.Description, t.Priority).
+		Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return mapTemplateErr(err)
+	}
+	if err := insertTemplateGoals(ctx, tx, t.ID, t.Goals); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateTemplate replaces the template fields and its whole goal list.
+func (r *TemplateRepo) UpdateTemplate(ctx context.Context, t *domain.MissionTemplate) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	q := `
+		UPDATE mission_templates
+		SET name = $2, title = $3, description = $4, priority = $5, updated_at = now()
+		WHERE id = $1
+		RETURNING created_at, updated_at;`
+
+	err = tx.QueryRowContext(ctx, q, t.ID, t.Name, t.Title, t.Description, t.Priority).
+		Scan(&t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return serviceerrors.ErrTemplateNotFound
+		}
+		return mapTemplateErr(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mission_template_goals WHERE template_id = $1;`, t.ID); err != nil {
+		return fmt.Errorf("clear template goals: %w", err)
+	}
+	if err := insertTemplateGoals(ctx, tx, t.ID, t.Goals); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertTemplateGoals(ctx context.Context, tx *sql.Tx, templateID int64, goals []domain.TemplateGoal) error {
+	if len(goals) == 0 {
+		return nil
+	}
+
+	const cols = 5
+	var b strings.Builder
+	args := make([]any, 0, len(goals)*cols)
+	for i := range goals {
+		goals[i].TemplateID = templateID
+		goals[i].Position = i + 1
+
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		n := i * cols
+		fmt.Fprintf(&b, "($%d,$%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5)
+		args = append(args, templateID, goals[i].Position, goals[i].Name, goals[i].Country, goals[i].Notes)
+	}
+
+	q := `
+		INSERT INTO mission_template_goals (template_id, position, name, country, notes)
+		VALUES ` + b.String() + `
+		RETURNING id;`
+
+	rows, err := tx.QueryContext(ctx, q, args...)
+	if err != nil {
+		return fmt.Errorf("insert template goals: %w", err)
+	}
+	defer rows.Close()
+
+	// RETURNING follows the VALUES order for a plain multi-row insert
+	for i := 0; rows.Next(); i++ {
+		if err := rows.Scan(&goals[i].ID); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *TemplateRepo) GetTemplate(ctx context.Context, id int64) (domain.MissionTemplate, error) {
+	q := `
+		SELECT ` + templateColumns + `
+		FROM mission_templates
+		WHERE id = $1;`
+
+	t, err := scanTemplate(r.db.QueryRowContext(ctx, q, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.MissionTemplate{}, serviceerrors.ErrTemplateNotFound
+		}
+		return domain.MissionTemplate{}, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, template_id, position, name, country, notes
+		FROM mission_template_goals
+		WHERE template_id = $1
+		ORDER BY position;`, id)
+	if err != nil {
+		return domain.MissionTemplate{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var g domain.TemplateGoal
+		if err := rows.Scan(&g.ID, &g.TemplateID, &g.Position, &g.Name, &g.Country, &g.Notes); err != nil {
+			return domain.MissionTemplate{}, err
+		}
+		t.Goals = append(t.Goals, g)
+	}
+	return t, rows.Err()
+}
+
+// ListTemplates returns templates without their goals, ordered by name.
+func (r *TemplateRepo) ListTemplates(ctx context.Context, limit, offset int) ([]domain.MissionTemplate, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT count(*) FROM mission_templates;`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	q := `
+		SELECT ` + templateColumns + `
+		FROM mission_templates
+		ORDER BY name, id
+		LIMIT $1 OFFSET $2;`
+
+	rows, err := r.db.QueryContext(ctx, q, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var out []domain.MissionTemplate
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		out = append(out, t)
+	}
+	return out, total, rows.Err()
+}
+
+func (r *TemplateRepo) DeleteTemplate(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM mission_templates WHERE id = $1;`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return serviceerrors.ErrTemplateNotFound
+	}
+	return nil
+}
+
+func mapTemplateErr(err error) error {
+	if code, _ := postgresql.ErrorCode(err); code == postgresql.UniqueViolation {
+		return serviceerrors.ErrTemplateNameTaken
+	}
+	return err
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/rs/zerolog/log"
+)
+
+// lockKey is the advisory lock that keeps a single instance assigning at a time.
+const lockKey int64 = 0x5c47_a551
+
+// maxCandidates is how many ranked cats are considered per mission.
+const maxCandidates = 100
+
+type AutoAssigner interface {
+	// RunOnce assigns a batch of unassigned planned missions and returns what it
+	// decided. It returns nil without doing anything when another instance holds the lock.
+	RunOnce(ctx context.Context) ([]domain.AutoAssignment, error)
+}
+type Repository interface {
+	ListUnassignedPlanned(ctx context.Context, limit int) ([]domain.Mission, error)
+	GetMission(ctx context.Context, id int64) (domain.Mission, error)
+	InsertAutoAssignment(ctx context.Context, a *domain.AutoAssignment) error
+}
+type Locker interface {
+	TryLock(ctx context.Context, key int64) (unlock func(), ok bool, err error)
+}
+type Ranker interface {
+	Candidates(ctx context.Context, missionID int64, limit int) ([]domain.Candidate, error)
+}
+type Assigner interface {
+	AssignCat(ctx context.Context, p domain.AssignMissionParams) error
+}
+type autoAssigner struct {
+	repo      Repository
+	locker    Locker
+	ranker    Ranker
+	assigner  Assigner
+	policy    Policy
+	batchSize int
+}
+
+func NewAutoAssigner(repo Repository, locker Locker, ranker Ranker, assigner Assigner, policy Policy, batchSize int) AutoAssigner {
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+	return &autoAssigner{
+		repo:      repo,
+		locker:    locker,
+		ranker:    ranker,
+		assigner:  assigner,
+		policy:    policy,
+		batchSize: batchSize,
+	}
+}
+
+func (s *autoAssigner) RunOnce(ctx context.Context) ([]domain.AutoAssignment, error) {
+	unlock, ok, err := s.locker.TryLock(ctx, lockKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		log.Debug().Msg("auto assignment locked by another instance")
+		return nil, nil
+	}
+	defer unlock()
+
+	missions, err := s.repo.ListUnassignedPlanned(ctx, s.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// cats picked in this run are not offered again, so one run spreads the work
+	taken := make(map[int64]struct{})
+	var out []domain.AutoAssignment
+	for _, m := range missions {
+		if ctx.Err() != nil {
+			return out, ctx.Err()
+		}
+		a, done := s.assignOne(ctx, m.ID, taken)
+		if !done {
+			continue
+		}
+		if err := s.repo.InsertAutoAssignment(ctx, &a); err != nil {
+			log.Error().Err(err).Int64("mission_id", a.MissionID).Msg("failed to record auto assignment")
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+// assignOne tries to assign a single mission. done is false when the mission
+// changed since it was listed and there is nothing to record.
+func (s *autoAssigner) assignOne(ctx context.Context, missionID int64, taken map[int64]struct{}) (domain.AutoAssignment, bool) {
+	a := domain.AutoAssignment{MissionID: missionID, Policy: s.policy.Name()}
+
+	// a planner may have picked a cat by hand since the batch was listed
+	m, err := s.repo.GetMission(ctx, missionID)
+	if err != nil || m.Status != domain.StatusPlanned || m.CatID != nil {
+		return a, false
+	}
+
+	ranked, err := s.ranker.Candidates(ctx, m.ID, maxCandidates)
+	if err != nil {
+		a.Outcome, a.Detail = domain.AutoFailed, fmt.Sprintf("rank candidates: %v", err)
+		return a, true
+	}
+	free := make([]domain.Candidate, 0, len(ranked))
+	for _, c := range ranked {
+		// a cat waiting on a planned mission is not free for another one
+		if _, ok := taken[c.Cat.ID]; ok || c.Busy || c.Planned {
+			continue
+		}
+		free = append(free, c)
+	}
+
+	pick, ok := s.policy.Pick(m, free)
+	if !ok {
+		a.Outcome, a.Detail = domain.AutoSkipped, "no free cat"
+		return a, true
+	}
+
+	// the mission is checked again under its row lock, so a cat picked by hand
+	// in the meantime is never overwritten
+	err = s.assigner.AssignCat(ctx, domain.AssignMissionParams{
+		MissionID:        m.ID,
+		CatID:            &pick.Cat.ID,
+		Note:             "assigned automatically by " + s.policy.Name() + " policy",
+		OnlyIfUnassigned: true,
+	})
+	if errors.Is(err, serviceerrors.ErrMissionHasAssignee) || errors.Is(err, serviceerrors.ErrMissionNotPlanned) {
+		return a, false
+	}
+	if err != nil {
+		a.Outcome, a.Detail = domain.AutoFailed, err.Error()
+		return a, true
+	}
+
+	taken[pick.Cat.ID] = struct{}{}
+	catID, score := pick.Cat.ID, pick.Score
+	a.CatID, a.Score, a.Outcome = &catID, &score, domain.AutoAssigned
+	return a, true
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type fakeRepo struct {
+	missions map[int64]domain.Mission
+	order    []int64
+	recorded []domain.AutoAssignment
+}
+
+func (r *fakeRepo) ListUnassignedPlanned(ctx context.Context, limit int) ([]domain.Mission, error) {
+	var out []domain.Mission
+	for _, id := range r.order {
+		out = append(out, r.missions[id])
+	}
+	return out, nil
+}
+func (r *fakeRepo) GetMission(ctx context.Context, id int64) (domain.Mission, error) {
+	return r.missions[id], nil
+}
+func (r *fakeRepo) InsertAutoAssignment(ctx context.Context, a *domain.AutoAssignment) error {
+	r.recorded = append(r.recorded, *a)
+	return nil
+}
+
+type fakeLocker struct{ held bool }
+
+func (l *fakeLocker) TryLock(ctx context.Context, key int64) (func(), bool, error) {
+	if l.held {
+		return nil, false, nil
+	}
+	return func() {}, true, nil
+}
+
+type fakeRanker struct{ ranked []domain.Candidate }
+
+func (r fakeRanker) Candidates(ctx context.Context, missionID int64, limit int) ([]domain.Candidate, error) {
+	return r.ranked, nil
+}
+
+type fakeAssigner struct {
+	repo *fakeRepo
+	// byHand holds cats a planner assigned after the batch was read
+	byHand map[int64]int64
+}
+
+func (a fakeAssigner) AssignCat(ctx context.Context, p domain.AssignMissionParams) error {
+	m := a.repo.missions[p.MissionID]
+	if catID, ok := a.byHand[p.MissionID]; ok {
+		m.CatID = &catID
+		a.repo.missions[p.MissionID] = m
+	}
+	if p.OnlyIfUnassigned && m.CatID != nil {
+		return serviceerrors.ErrMissionHasAssignee
+	}
+	m.CatID = p.CatID
+	a.repo.missions[p.MissionID] = m
+	return nil
+}
+
+func TestRunOnce_SpreadsFreeCats(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepo{
+		missions: map[int64]domain.Mission{
+			1: {ID: 1, Status: domain.StatusPlanned},
+			2: {ID: 2, Status: domain.StatusPlanned},
+			3: {ID: 3, Status: domain.StatusPlanned},
+		},
+		order: []int64{1, 2, 3},
+	}
+	ranker := fakeRanker{ranked: []domain.Candidate{
+		{Cat: domain.Cat{ID: 10}, Busy: true, Score: 90},
+		{Cat: domain.Cat{ID: 20}, Score: 80},
+		{Cat: domain.Cat{ID: 30}, Score: 70},
+	}}
+	policy, err := NewPolicy(PolicyBestScore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewAutoAssigner(repo, &fakeLocker{}, ranker, fakeAssigner{repo: repo}, policy, 10)
+	got, err := s.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 || len(repo.recorded) != 3 {
+		t.Fatalf("want 3 decisions recorded, got %d/%d", len(got), len(repo.recorded))
+	}
+
+	wantCats := []int64{20, 30}
+	for i, id := range wantCats {
+		if got[i].Outcome != domain.AutoAssigned || got[i].CatID == nil || *got[i].CatID != id {
+			t.Fatalf("mission %d: want cat %d assigned, got %+v", got[i].MissionID, id, got[i])
+		}
+	}
+	if got[2].Outcome != domain.AutoSkipped {
+		t.Fatalf("busy cat must not be picked, got %+v", got[2])
+	}
+}
+
+func TestRunOnce_SkipsWhenLocked(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepo{
+		missions: map[int64]domain.Mission{1: {ID: 1, Status: domain.StatusPlanned}},
+		order:    []int64{1},
+	}
+	policy, _ := NewPolicy(PolicyCheapest)
+	s := NewAutoAssigner(repo, &fakeLocker{held: true}, fakeRanker{}, fakeAssigner{repo: repo}, policy, 10)
+
+	got, err := s.RunOnce(context.Background())
+	if err != nil || got != nil || len(repo.recorded) != 0 {
+		t.Fatalf("locked run must do nothing, got %v %v", got, err)
+	}
+}
+
+func TestRunOnce_SkipsCatsWithPlannedMissions(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepo{
+		missions: map[int64]domain.Mission{1: {ID: 1, Status: domain.StatusPlanned}},
+		order:    []int64{1},
+	}
+	ranker := fakeRanker{ranked: []domain.Candidate{
+		{Cat: domain.Cat{ID: 10}, Planned: true, Score: 90},
+		{Cat: domain.Cat{ID: 20}, Score: 80},
+	}}
+	policy, _ := NewPolicy(PolicyBestScore)
+	s := NewAutoAssigner(repo, &fakeLocker{}, ranker, fakeAssigner{repo: repo}, policy, 10)
+
+	got, err := s.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].CatID == nil || *got[0].CatID != 20 {
+		t.Fatalf("want cat 20 assigned, got %+v", got)
+	}
+}
+
+func TestRunOnce_KeepsCatPickedByHand(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepo{
+		missions: map[int64]domain.Mission{1: {ID: 1, Status: domain.StatusPlanned}},
+		order:    []int64{1},
+	}
+	ranker := fakeRanker{ranked: []domain.Candidate{{Cat: domain.Cat{ID: 20}, Score: 80}}}
+	policy, _ := NewPolicy(PolicyBestScore)
+	assigner := fakeAssigner{repo: repo, byHand: map[int64]int64{1: 99}}
+	s := NewAutoAssigner(repo, &fakeLocker{}, ranker, assigner, policy, 10)
+
+	got, err := s.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 || len(repo.recorded) != 0 {
+		t.Fatalf("mission assigned by hand must be left alone, got %+v", got)
+	}
+	if c := repo.missions[1].CatID; c == nil || *c != 99 {
+		t.Fatalf("cat picked by hand was overwritten: %v", c)
+	}
+}
+
+func TestCheapestPolicy_ComparesConvertedSalaries(t *testing.T) {
+	t.Parallel()
+
+	usd := func(a int64) *domain.Money { return &domain.Money{Amount: a, Currency: domain.DefaultCurrency} }
+	candidates := []domain.Candidate{
+		{Cat: domain.Cat{ID: 1, Salary: domain.Money{Amount: 100, Currency: "JPY"}}},
+		{Cat: domain.Cat{ID: 2, Salary: domain.Money{Amount: 200000, Currency: "EUR"}}, BaseSalary: usd(400000)},
+		{Cat: domain.Cat{ID: 3, Salary: domain.Money{Amount: 300000, Currency: "USD"}}, BaseSalary: usd(300000)},
+	}
+
+	policy, _ := NewPolicy(PolicyCheapest)
+	got, ok := policy.Pick(domain.Mission{}, candidates)
+	if !ok || got.Cat.ID != 3 {
+		t.Fatalf("want cat 3, got %+v", got)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+const (
+	PolicyBestScore = "best_score"
+	PolicyCheapest  = "cheapest"
+)
+
+// Policy decides which free cat gets a mission. Candidates arrive ranked best
+// first and contain only cats that are free to take the mission.
+type Policy interface {
+	Name() string
+	Pick(m domain.Mission, candidates []domain.Candidate) (domain.Candidate, bool)
+}
+
+// NewPolicy returns the built-in policy registered under name.
+func NewPolicy(name string) (Policy, error) {
+	switch name {
+	case PolicyBestScore:
+		return bestScorePolicy{}, nil
+	case PolicyCheapest:
+		return cheapestPolicy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown assignment policy %q", name)
+	}
+}
+
+// bestScorePolicy follows the recommendation ranking.
+type bestScorePolicy struct{}
+
+func (bestScorePolicy) Name() string { return PolicyBestScore }
+
+func (bestScorePolicy) Pick(_ domain.Mission, candidates []domain.Candidate) (domain.Candidate, bool) {
+	if len(candidates) == 0 {
+		return domain.Candidate{}, false
+	}
+	return candidates[0], true
+}
+
+// cheapestPolicy takes the lowest paid cat, falling back to the ranking on ties.
+// Salaries are compared in domain.DefaultCurrency; cats whose salary cannot be
+// converted are only picked when no salary can be compared.
+type cheapestPolicy struct{}
+
+func (cheapestPolicy) Name() string { return PolicyCheapest }
+
+func (cheapestPolicy) Pick(_ domain.Mission, candidates []domain.Candidate) (domain.Candidate, bool) {
+	if len(candidates) == 0 {
+		return domain.Candidate{}, false
+	}
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.BaseSalary == nil {
+			continue
+		}
+		if best.BaseSalary == nil || c.BaseSalary.Cmp(*best.BaseSalary) < 0 {
+			best = c
+		}
+	}
+	return best, true
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type BandService interface {
+	CreateBand(ctx context.Context, b domain.SalaryBand) (domain.SalaryBand, error)
+	UpdateBand(ctx context.Context, id int64, b domain.SalaryBand) (domain.SalaryBand, error)
+	GetBand(ctx context.Context, id int64) (domain.SalaryBand, error)
+	ListBands(ctx context.Context) ([]domain.SalaryBand, error)
+	DeleteBand(ctx context.Context, id int64) error
+}
+type BandRepository interface {
+	CreateSalaryBand(ctx context.Context, b domain.SalaryBand) (domain.SalaryBand, error)
+	UpdateSalaryBand(ctx context.Context, b domain.SalaryBand) (domain.SalaryBand, error)
+	GetSalaryBand(ctx context.Context, id int64) (domain.SalaryBand, error)
+	ListSalaryBands(ctx context.Context) ([]domain.SalaryBand, error)
+	DeleteSalaryBand(ctx context.Context, id int64) error
+}
+type bandService struct {
+	repo BandRepository
+}
+
+func NewBandService(repo BandRepository) BandService {
+	return &bandService{repo: repo}
+}
+
+func (s *bandService) CreateBand(ctx context.Context, b domain.SalaryBand) (domain.SalaryBand, error) {
+	b, err := normalizeBand(b)
+	if err != nil {
+		return domain.SalaryBand{}, err
+	}
+	b.ID = 0
+	return s.repo.CreateSalaryBand(ctx, b)
+}
+
+func (s *bandService) UpdateBand(ctx context.Context, id int64, b domain.SalaryBand) (domain.SalaryBand, error) {
+	if id <= 0 {
+		return domain.SalaryBand{}, serviceerrors.ErrSalaryBandNotFound
+	}
+	b, err := normalizeBand(b)
+	if err != nil {
+		return domain.SalaryBand{}, err
+	}
+	b.ID = id
+	return s.repo.UpdateSalaryBand(ctx, b)
+}
+
+func (s *bandService) GetBand(ctx context.Context, id int64) (domain.SalaryBand, error) {
+	if id <= 0 {
+		return domain.SalaryBand{}, serviceerrors.ErrSalaryBandNotFound
+	}
+	return s.repo.GetSalaryBand(ctx, id)
+}
+
+func (s *bandService) ListBands(ctx context.Context) ([]domain.SalaryBand, error) {
+	return s.repo.ListSalaryBands(ctx)
+}
+
+func (s *bandService) DeleteBand(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return serviceerrors.ErrSalaryBandNotFound
+	}
+	return s.repo.DeleteSalaryBand(ctx, id)
+}
+
+// normalizeBand puts both ends of the band in the band's currency, USD when
+// none is given, before validating it.
+func normalizeBand(b domain.SalaryBand) (domain.SalaryBand, error) {
+	currency := domain.DefaultCurrency
+	if strings.TrimSpace(b.Min.Currency) != "" {
+		c, ok := domain.NormalizeCurrency(b.Min.Currency)
+		if !ok {
+			return domain.SalaryBand{}, serviceerrors.ErrInvalidCurrency
+		}
+		currency = c
+	}
+	b.Min.Currency, b.Max.Currency = currency, currency
+	b.Breed = strings.TrimSpace(b.Breed)
+
+	if len(b.Breed) > 64 || !b.IsValid() {
+		return domain.SalaryBand{}, serviceerrors.ErrInvalidSalaryBand
+	}
+	return b, nil
+}
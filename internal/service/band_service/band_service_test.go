@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type fakeRepo struct {
+	created []domain.SalaryBand
+	updated []domain.SalaryBand
+}
+
+func (r *fakeRepo) CreateSalaryBand(ctx context.Context, b domain.SalaryBand) (domain.SalaryBand, error) {
+	r.created = append(r.created, b)
+	return b, nil
+}
+func (r *fakeRepo) UpdateSalaryBand(ctx context.Context, b domain.SalaryBand) (domain.SalaryBand, error) {
+	r.updated = append(r.updated, b)
+	return b, nil
+}
+func (r *fakeRepo) GetSalaryBand(ctx context.Context, id int64) (domain.SalaryBand, error) {
+	return domain.SalaryBand{ID: id}, nil
+}
+func (r *fakeRepo) ListSalaryBands(ctx context.Context) ([]domain.SalaryBand, error) {
+	return nil, nil
+}
+func (r *fakeRepo) DeleteSalaryBand(ctx context.Context, id int64) error {
+	return nil
+}
+
+func TestCreateBand(t *testing.T) {
+	t.Parallel()
+
+	two := int64(2)
+	tests := []struct {
+		name         string
+		band         domain.SalaryBand
+		wantCurrency string
+		wantErr      error
+	}{
+		{
+			name:         "defaults to USD",
+			band:         domain.SalaryBand{ID: 7, MaxYears: &two, Breed: " bengal ", Min: domain.Money{Amount: 100}, Max: domain.Money{Amount: 200}},
+			wantCurrency: "USD",
+		},
+		{
+			name:         "currency of min applies to both ends",
+			band:         domain.SalaryBand{Min: domain.Money{Amount: 100, Currency: "eur"}, Max: domain.Money{Amount: 200, Currency: "USD"}},
+			wantCurrency: "EUR",
+		},
+		{
+			name:    "invalid currency",
+			band:    domain.SalaryBand{Min: domain.Money{Amount: 100, Currency: "EURO"}, Max: domain.Money{Amount: 200}},
+			wantErr: serviceerrors.ErrInvalidCurrency,
+		},
+		{
+			name:    "min above max",
+			band:    domain.SalaryBand{Min: domain.Money{Amount: 300}, Max: domain.Money{Amount: 200}},
+			wantErr: serviceerrors.ErrInvalidSalaryBand,
+		},
+		{
+			name:    "years out of order",
+			band:    domain.SalaryBand{MinYears: 3, MaxYears: &two, Min: domain.Money{Amount: 100}, Max: domain.Money{Amount: 200}},
+			wantErr: serviceerrors.ErrInvalidSalaryBand,
+		},
+		{
+			name:    "breed too long",
+			band:    domain.SalaryBand{Breed: strings.Repeat("a", 65), Min: domain.Money{Amount: 100}, Max: domain.Money{Amount: 200}},
+			wantErr: serviceerrors.ErrInvalidSalaryBand,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeRepo{}
+			svc := NewBandService(repo)
+
+			got, err := svc.CreateBand(context.Background(), tc.band)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want %v, got %v", tc.wantErr, err)
+				}
+				if len(repo.created) != 0 {
+					t.Fatal("invalid band must not be stored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != 0 {
+				t.Fatalf("id must be assigned by the repository, got %d", got.ID)
+			}
+			if got.Min.Currency != tc.wantCurrency || got.Max.Currency != tc.wantCurrency {
+				t.Fatalf("currencies %s/%s, want %s", got.Min.Currency, got.Max.Currency, tc.wantCurrency)
+			}
+			if got.Breed != strings.TrimSpace(tc.band.Breed) {
+				t.Fatalf("breed=%q, want it trimmed", got.Breed)
+			}
+		})
+	}
+}
+
+func TestUpdateBand(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepo{}
+	svc := NewBandService(repo)
+	band := domain.SalaryBand{Min: domain.Money{Amount: 100}, Max: domain.Money{Amount: 200}}
+
+	if _, err := svc.UpdateBand(context.Background(), 0, band); !errors.Is(err, serviceerrors.ErrSalaryBandNotFound) {
+		t.Fatalf("want ErrSalaryBandNotFound, got %v", err)
+	}
+
+	got, err := svc.UpdateBand(context.Background(), 5, band)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 5 || len(repo.updated) != 1 {
+		t.Fatalf("band must be updated under the path id, got %+v", got)
+	}
+}
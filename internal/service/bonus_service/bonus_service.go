@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/countries"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type BonusService interface {
+	CreateRule(ctx context.Context, r domain.BonusRule) (domain.BonusRule, error)
+	UpdateRule(ctx context.Context, id int64, r domain.BonusRule) (domain.BonusRule, error)
+	GetRule(ctx context.Context, id int64) (domain.BonusRule, error)
+	ListRules(ctx context.Context) ([]domain.BonusRule, error)
+	DeleteRule(ctx context.Context, id int64) error
+	ListRiskLevels(ctx context.Context) ([]domain.CountryRisk, error)
+	SetRiskLevel(ctx context.Context, country string, level int) (domain.CountryRisk, error)
+	DeleteRiskLevel(ctx context.Context, country string) error
+	CatBonuses(ctx context.Context, catID int64) ([]domain.Bonus, error)
+}
+type BonusRepository interface {
+	CreateRule(ctx context.Context, r domain.BonusRule) (domain.BonusRule, error)
+	UpdateRule(ctx context.Context, r domain.BonusRule) (domain.BonusRule, error)
+	GetRule(ctx context.Context, id int64) (domain.BonusRule, error)
+	ListRules(ctx context.Context) ([]domain.BonusRule, error)
+	DeleteRule(ctx context.Context, id int64) error
+	ListRiskLevels(ctx context.Context) ([]domain.CountryRisk, error)
+	UpsertRiskLevel(ctx context.Context, c domain.CountryRisk) (domain.CountryRisk, error)
+	DeleteRiskLevel(ctx context.Context, country string) error
+	ListCatBonuses(ctx context.Context, catID int64) ([]domain.Bonus, error)
+}
+type bonusService struct {
+	repo BonusRepository
+}
+
+func NewBonusService(repo BonusRepository) BonusService {
+	return &bonusService{repo: repo}
+}
+
+func (s *bonusService) CreateRule(ctx context.Context, r domain.BonusRule) (domain.BonusRule, error) {
+	r, err := normalizeRule(r)
+	if err != nil {
+		return domain.BonusRule{}, err
+	}
+	return s.repo.CreateRule(ctx, r)
+}
+
+func (s *bonusService) UpdateRule(ctx context.Context, id int64, r domain.BonusRule) (domain.BonusRule, error) {
+	if id <= 0 {
+		return domain.BonusRule{}, serviceerrors.ErrBonusRuleNotFound
+	}
+	r, err := normalizeRule(r)
+	if err != nil {
+		return domain.BonusRule{}, err
+	}
+	r.ID = id
+	return s.repo.UpdateRule(ctx, r)
+}
+
+func (s *bonusService) GetRule(ctx context.Context, id int64) (domain.BonusRule, error) {
+	if id <= 0 {
+		return domain.BonusRule{}, serviceerrors.ErrBonusRuleNotFound
+	}
+	return s.repo.GetRule(ctx, id)
+}
+
+func (s *bonusService) ListRules(ctx context.Context) ([]domain.BonusRule, error) {
+	return s.repo.ListRules(ctx)
+}
+
+func (s *bonusService) DeleteRule(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return serviceerrors.ErrBonusRuleNotFound
+	}
+	return s.repo.DeleteRule(ctx, id)
+}
+
+func (s *bonusService) ListRiskLevels(ctx context.Context) ([]domain.CountryRisk, error) {
+	return s.repo.ListRiskLevels(ctx)
+}
+
+func (s *bonusService) SetRiskLevel(ctx context.Context, country string, level int) (domain.CountryRisk, error) {
+	code, ok := countries.Normalize(country)
+	if !ok {
+		return domain.CountryRisk{}, serviceerrors.ErrInvalidCountry
+	}
+	if level < domain.MinRiskLevel || level > domain.MaxRiskLevel {
+		return domain.CountryRisk{}, serviceerrors.ErrInvalidRiskLevel
+	}
+	return s.repo.UpsertRiskLevel(ctx, domain.CountryRisk{Country: code, Level: level})
+}
+
+func (s *bonusService) DeleteRiskLevel(ctx context.Context, country string) error {
+	code, ok := countries.Normalize(country)
+	if !ok {
+		return serviceerrors.ErrInvalidCountry
+	}
+	return s.repo.DeleteRiskLevel(ctx, code)
+}
+
+func (s *bonusService) CatBonuses(ctx context.Context, catID int64) ([]domain.Bonus, error) {
+	if catID <= 0 {
+		return nil, serviceerrors.ErrCatNotFound
+	}
+	return s.repo.ListCatBonuses(ctx, catID)
+}
+
+// normalizeRule trims the name and defaults the currency to USD before
+// validating the rule.
+func normalizeRule(r domain.BonusRule) (domain.BonusRule, error) {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Kind = domain.BonusKind(strings.TrimSpace(string(r.Kind)))
+	if r.Amount.Currency == "" {
+		r.Amount.Currency = domain.DefaultCurrency
+	} else {
+		c, ok := domain.NormalizeCurrency(r.Amount.Currency)
+		if !ok {
+			return domain.BonusRule{}, serviceerrors.ErrInvalidCurrency
+		}
+		r.Amount.Currency = c
+	}
+
+	if !r.IsValid() {
+		return domain.BonusRule{}, serviceerrors.ErrInvalidBonusRule
+	}
+	return r, nil
+}
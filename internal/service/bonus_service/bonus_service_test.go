@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type fakeRepo struct {
+	BonusRepository
+
+	created []domain.BonusRule
+	updated []domain.BonusRule
+	risk    []domain.CountryRisk
+	deleted []string
+}
+
+func (r *fakeRepo) CreateRule(ctx context.Context, rule domain.BonusRule) (domain.BonusRule, error) {
+	r.created = append(r.created, rule)
+	return rule, nil
+}
+func (r *fakeRepo) UpdateRule(ctx context.Context, rule domain.BonusRule) (domain.BonusRule, error) {
+	r.updated = append(r.updated, rule)
+	return rule, nil
+}
+func (r *fakeRepo) UpsertRiskLevel(ctx context.Context, c domain.CountryRisk) (domain.CountryRisk, error) {
+	r.risk = append(r.risk, c)
+	return c, nil
+}
+func (r *fakeRepo) DeleteRiskLevel(ctx context.Context, country string) error {
+	r.deleted = append(r.deleted, country)
+	return nil
+}
+
+func TestCreateRule(t *testing.T) {
+	t.Parallel()
+
+	three := 3
+	tests := []struct {
+		name         string
+		rule         domain.BonusRule
+		wantCurrency string
+		wantErr      error
+	}{
+		{
+			name:         "defaults to USD",
+			rule:         domain.BonusRule{Name: " flat ", Kind: "flat", Amount: domain.Money{Amount: 5000}},
+			wantCurrency: "USD",
+		},
+		{
+			name:         "normalizes the currency",
+			rule:         domain.BonusRule{Name: "risky", Kind: " country_risk ", Amount: domain.Money{Amount: 5000, Currency: "eur"}, RiskLevel: &three},
+			wantCurrency: "EUR",
+		},
+		{
+			name:    "invalid currency",
+			rule:    domain.BonusRule{Name: "flat", Kind: "flat", Amount: domain.Money{Amount: 5000, Currency: "EURO"}},
+			wantErr: serviceerrors.ErrInvalidCurrency,
+		},
+		{
+			name:    "country risk without a level",
+			rule:    domain.BonusRule{Name: "risky", Kind: "country_risk", Amount: domain.Money{Amount: 5000}},
+			wantErr: serviceerrors.ErrInvalidBonusRule,
+		},
+		{
+			name:    "level on a flat rule",
+			rule:    domain.BonusRule{Name: "flat", Kind: "flat", Amount: domain.Money{Amount: 5000}, RiskLevel: &three},
+			wantErr: serviceerrors.ErrInvalidBonusRule,
+		},
+		{
+			name:    "zero amount",
+			rule:    domain.BonusRule{Name: "flat", Kind: "flat"},
+			wantErr: serviceerrors.ErrInvalidBonusRule,
+		},
+		{
+			name:    "unknown kind",
+			rule:    domain.BonusRule{Name: "weekly", Kind: "weekly", Amount: domain.Money{Amount: 5000}},
+			wantErr: serviceerrors.ErrInvalidBonusRule,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeRepo{}
+			svc := NewBonusService(repo)
+
+			got, err := svc.CreateRule(context.Background(), tc.rule)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want %v, got %v", tc.wantErr, err)
+				}
+				if len(repo.created) != 0 {
+					t.Fatal("invalid rule must not be stored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Amount.Currency != tc.wantCurrency {
+				t.Fatalf("currency=%s, want %s", got.Amount.Currency, tc.wantCurrency)
+			}
+			if got.Name != strings.TrimSpace(tc.rule.Name) || got.Kind != domain.BonusKind(strings.TrimSpace(string(tc.rule.Kind))) {
+				t.Fatalf("name and kind must be trimmed, got %q %q", got.Name, got.Kind)
+			}
+		})
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepo{}
+	svc := NewBonusService(repo)
+	rule := domain.BonusRule{Name: "flat", Kind: domain.BonusFlat, Amount: domain.Money{Amount: 5000}}
+
+	if _, err := svc.UpdateRule(context.Background(), 0, rule); !errors.Is(err, serviceerrors.ErrBonusRuleNotFound) {
+		t.Fatalf("want ErrBonusRuleNotFound, got %v", err)
+	}
+
+	got, err := svc.UpdateRule(context.Background(), 4, rule)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 4 || len(repo.updated) != 1 {
+		t.Fatalf("rule must be updated under the path id, got %+v", got)
+	}
+}
+
+func TestSetRiskLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		country string
+		level   int
+		want    string
+		wantErr error
+	}{
+		{name: "alpha-3 code", country: "deu", level: 2, want: "DE"},
+		{name: "country name", country: "France", level: 5, want: "FR"},
+		{name: "unknown country", country: "Atlantis", level: 2, wantErr: serviceerrors.ErrInvalidCountry},
+		{name: "level too low", country: "DE", level: domain.MinRiskLevel - 1, wantErr: serviceerrors.ErrInvalidRiskLevel},
+		{name: "level too high", country: "DE", level: domain.MaxRiskLevel + 1, wantErr: serviceerrors.ErrInvalidRiskLevel},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeRepo{}
+			svc := NewBonusService(repo)
+
+			got, err := svc.SetRiskLevel(context.Background(), tc.country, tc.level)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want %v, got %v", tc.wantErr, err)
+				}
+				if len(repo.risk) != 0 {
+					t.Fatal("invalid risk level must not be stored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Country != tc.want || got.Level != tc.level {
+				t.Fatalf("got %+v, want %s at level %d", got, tc.want, tc.level)
+			}
+		})
+	}
+}
+
+func TestDeleteRiskLevel_NormalizesCountry(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepo{}
+	svc := NewBonusService(repo)
+
+	if err := svc.DeleteRiskLevel(context.Background(), "de"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.deleted) != 1 || repo.deleted[0] != "DE" {
+		t.Fatalf("unexpected delete: %v", repo.deleted)
+	}
+	if err := svc.DeleteRiskLevel(context.Background(), "Atlantis"); !errors.Is(err, serviceerrors.ErrInvalidCountry) {
+		t.Fatalf("want ErrInvalidCountry, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	servieserrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+// bandPolicy checks salaries against the salary bands. Exchange rates are
+// only loaded once a salary and its band are in different currencies.
+type bandPolicy struct {
+	bands []domain.SalaryBand
+	rates RateSource
+	table domain.RateTable
+}
+
+func (s *catService) bandPolicy(ctx context.Context) (*bandPolicy, error) {
+	bands, err := s.repo.ListSalaryBands(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &bandPolicy{bands: bands, rates: s.rates}, nil
+}
+
+func (p *bandPolicy) rateTable(ctx context.Context) (domain.RateTable, error) {
+	if p.table == nil {
+		table, err := p.rates.RateTable(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.table = table
+	}
+	return p.table, nil
+}
+
+// checkSalary applies domain.MaxSalary, converting a salary in another
+// currency with the stored rates.
+func (p *bandPolicy) checkSalary(ctx context.Context, salary domain.Money) error {
+	var table domain.RateTable
+	if !salary.SameCurrency(domain.MaxSalary) {
+		var err error
+		if table, err = p.rateTable(ctx); err != nil {
+			return err
+		}
+		if _, ok := table.Rate(salary.Currency, domain.MaxSalary.Currency); !ok {
+			return fmt.Errorf("%w: %s to %s", servieserrors.ErrExchangeRateNotFound, salary.Currency, domain.MaxSalary.Currency)
+		}
+	}
+	if !domain.IsValidSalary(salary, table) {
+		return servieserrors.ErrInvalidSalary
+	}
+	return nil
+}
+
+// place finds the band of a cat and converts the salary to the band's
+// currency. ok is false when no band applies.
+func (p *bandPolicy) place(ctx context.Context, years int64, breed string, salary domain.Money) (domain.SalaryBand, domain.Money, bool, error) {
+	band, ok := domain.FindBand(p.bands, years, breed)
+	if !ok {
+		return domain.SalaryBand{}, domain.Money{}, false, nil
+	}
+	if salary.SameCurrency(band.Min) {
+		return band, salary, true, nil
+	}
+
+	table, err := p.rateTable(ctx)
+	if err != nil {
+		return domain.SalaryBand{}, domain.Money{}, false, err
+	}
+	converted, ok := table.Convert(salary, band.Min.Currency)
+	if !ok {
+		return domain.SalaryBand{}, domain.Money{}, false,
+			fmt.Errorf("%w: %s to %s", servieserrors.ErrExchangeRateNotFound, salary.Currency, band.Min.Currency)
+	}
+	return band, converted, true, nil
+}
+
+// enforce rejects a salary outside its band unless it is overridden with a
+// reason. It reports whether the override was needed, so the ledger only
+// marks salaries that really are outside their band.
+func (p *bandPolicy) enforce(ctx context.Context, years int64, breed string, salary domain.Money, ov domain.SalaryOverride) (bool, error) {
+	if ov.Override && strings.TrimSpace(ov.Reason) == "" {
+		return false, servieserrors.ErrOverrideReasonRequired
+	}
+
+	band, inBand, ok, err := p.place(ctx, years, breed, salary)
+	if err != nil {
+		// an override also accepts a salary that cannot be compared
+		if ov.Override && errors.Is(err, servieserrors.ErrExchangeRateNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+	if !ok || band.Contains(inBand) {
+		return false, nil
+	}
+	if ov.Override {
+		return true, nil
+	}
+	return false, fmt.Errorf("%w: allowed %s..%s %s", servieserrors.ErrSalaryOutOfBand, band.Min, band.Max, band.Min.Currency)
+}
+
+// OutOfBandCats lists cats whose current salary is outside their band,
+// overridden or not. Cats without a band are never listed, nor are cats paid
+// in a currency that has no rate to their band's currency.
+func (s *catService) OutOfBandCats(ctx context.Context) ([]domain.OutOfBand, error) {
+	policy, err := s.bandPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(policy.bands) == 0 {
+		return nil, nil
+	}
+
+	var out []domain.OutOfBand
+	err = s.repo.StreamCats(ctx, domain.ListCatsParams{}, func(c domain.Cat) error {
+		band, inBand, ok, err := policy.place(ctx, c.YearsExperience, c.Breed, c.Salary)
+		if errors.Is(err, servieserrors.ErrExchangeRateNotFound) {
+			return nil
+		}
+		if err != nil || !ok {
+			return err
+		}
+		if !band.Contains(inBand) {
+			out = append(out, domain.OutOfBand{Cat: c, Band: band, Salary: inBand})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
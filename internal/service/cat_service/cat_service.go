@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	servieserrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
 
@@ -12,41 +14,74 @@ import (
 )
 
 type CatService interface {
-	CreateCat(ctx context.Context, cat *domain.Cat) (int64, error)
+	CreateCat(ctx context.Context, cat *domain.Cat, ov domain.SalaryOverride) (int64, error)
+	ImportCats(ctx context.Context, rows []domain.CatImportRow, dryRun bool) ([]domain.CatImportResult, error)
 	ListCats(ctx context.Context, p domain.ListCatsParams) ([]domain.Cat, error)
-	GetCat(ctx context.Context, id int64) (domain.Cat, error)
+	ExportCats(ctx context.Context, p domain.ListCatsParams, fn func(domain.Cat) error) error
+	GetCat(ctx context.Context, id int64, currency string) (domain.Cat, error)
 	DeleteCat(ctx context.Context, id int64) (int64, error)
+	RestoreCat(ctx context.Context, id int64) (domain.Cat, error)
+	PurgeDeletedCats(ctx context.Context, retention time.Duration) (int64, error)
 	UpdateSalary(ctx context.Context, p domain.UpdateSalaryParams) (domain.Cat, error)
+	SalaryHistory(ctx context.Context, catID int64) ([]domain.SalaryChange, error)
+	Payroll(ctx context.Context, month time.Time, currency string) (domain.Payroll, error)
+	OutOfBandCats(ctx context.Context) ([]domain.OutOfBand, error)
 }
 type CatRepository interface {
-	CreateCat(ctx context.Context, cat *domain.Cat) (int64, error)
+	CreateCat(ctx context.Context, cat *domain.Cat, ov domain.SalaryOverride) (int64, error)
+	CreateCats(ctx context.Context, cats []domain.Cat) ([]int64, error)
 	ListCats(ctx context.Context, p domain.ListCatsParams) ([]domain.Cat, error)
+	StreamCats(ctx context.Context, p domain.ListCatsParams, fn func(domain.Cat) error) error
 	GetCat(ctx context.Context, id int64) (domain.Cat, error)
-	DeleteCat(ctx context.Context, id int64) (int64, error)
-	UpdateSalary(ctx context.Context, id int64, salary float64) (domain.Cat, error)
+	DeleteCat(ctx context.Context, id int64) error
+	RestoreCat(ctx context.Context, id int64) (domain.Cat, error)
+	PurgeDeletedCats(ctx context.Context, before time.Time) (int64, error)
+	UpdateSalary(ctx context.Context, p domain.UpdateSalaryParams) (domain.Cat, error)
+	SalaryHistory(ctx context.Context, catID int64) ([]domain.SalaryChange, error)
+	Payroll(ctx context.Context, month time.Time) (domain.Payroll, error)
+	ListSalaryBands(ctx context.Context) ([]domain.SalaryBand, error)
 }
 type BreedValidator interface {
 	IsValid(ctx context.Context, breed string) (bool, error)
 }
+type RateSource interface {
+	RateTable(ctx context.Context) (domain.RateTable, error)
+}
 type catService struct {
 	repo   CatRepository
 	breeds BreedValidator
+	rates  RateSource
 }
 
-func NewCatService(repo CatRepository, breeds BreedValidator) CatService {
+func NewCatService(repo CatRepository, breeds BreedValidator, rates RateSource) CatService {
 	return &catService{
 		repo:   repo,
 		breeds: breeds,
+		rates:  rates,
 	}
 }
 
-func (s *catService) CreateCat(ctx context.Context, cat *domain.Cat) (int64, error) {
+// CreateCat rejects a salary outside the cat's band unless ov overrides it.
+func (s *catService) CreateCat(ctx context.Context, cat *domain.Cat, ov domain.SalaryOverride) (int64, error) {
 	cat.Name = strings.TrimSpace(cat.Name)
 	if cat.Name == "" {
 		return 0, errors.New("name is required")
 	}
+	cur, ok := salaryCurrency(cat.Salary.Currency)
+	if !ok {
+		return 0, servieserrors.ErrInvalidCurrency
+	}
+	cat.Salary.Currency = cur
 
-	ok, err := s.breeds.IsValid(ctx, cat.Breed)
+	policy, err := s.bandPolicy(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := policy.checkSalary(ctx, cat.Salary); err != nil {
+		return 0, err
+	}
+
+	ok, err = s.breeds.IsValid(ctx, cat.Breed)
 	if err != nil {
 		return 0, servieserrors.ErrExternalService
 	}
@@ -54,13 +89,112 @@ func (s *catService) CreateCat(ctx context.Context, cat *domain.Cat) (int64, err
 		return 0, servieserrors.ErrBreedInvalid
 	}
 
-	return s.repo.CreateCat(ctx, cat)
+	ov.Reason = strings.TrimSpace(ov.Reason)
+	if ov.Override, err = policy.enforce(ctx, cat.YearsExperience, cat.Breed, cat.Salary, ov); err != nil {
+		return 0, err
+	}
+	// the first ledger entry only carries a reason when it was overridden
+	if !ov.Override {
+		ov.Reason = ""
+	}
+
+	return s.repo.CreateCat(ctx, cat, ov)
+}
+
+// ImportCats checks every row and stores the accepted ones in one go.
+// Each distinct breed is looked up once no matter how many rows use it.
+// Salaries outside their band are rejected; imports cannot override bands.
+// With dryRun nothing is written and accepted rows get no id.
+func (s *catService) ImportCats(ctx context.Context, rows []domain.CatImportRow, dryRun bool) ([]domain.CatImportResult, error) {
+	results := make([]domain.CatImportResult, len(rows))
+	breeds := make(map[string]bool)
+
+	policy, err := s.bandPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		results[i].Line = rows[i].Line
+
+		c := &rows[i].Cat
+		c.Name = strings.TrimSpace(c.Name)
+		c.Breed = strings.TrimSpace(c.Breed)
+		if c.Name == "" {
+			results[i].Err = servieserrors.ErrInvalidName
+			continue
+		}
+		cur, ok := salaryCurrency(c.Salary.Currency)
+		if !ok {
+			results[i].Err = servieserrors.ErrInvalidCurrency
+			continue
+		}
+		c.Salary.Currency = cur
+		if err := policy.checkSalary(ctx, c.Salary); err != nil {
+			if !errors.Is(err, servieserrors.ErrInvalidSalary) && !errors.Is(err, servieserrors.ErrExchangeRateNotFound) {
+				return nil, err
+			}
+			results[i].Err = err
+			continue
+		}
+
+		key := strings.ToLower(c.Breed)
+		if _, seen := breeds[key]; !seen {
+			ok, err := s.breeds.IsValid(ctx, c.Breed)
+			if err != nil {
+				return nil, servieserrors.ErrExternalService
+			}
+			breeds[key] = ok
+		}
+		if !breeds[key] {
+			results[i].Err = servieserrors.ErrBreedInvalid
+			continue
+		}
+
+		_, err := policy.enforce(ctx, c.YearsExperience, c.Breed, c.Salary, domain.SalaryOverride{})
+		switch {
+		case errors.Is(err, servieserrors.ErrSalaryOutOfBand), errors.Is(err, servieserrors.ErrExchangeRateNotFound):
+			results[i].Err = err
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	if dryRun {
+		return results, nil
+	}
+
+	accepted := make([]domain.Cat, 0, len(rows))
+	idx := make([]int, 0, len(rows))
+	for i, r := range results {
+		if r.Err == nil {
+			accepted = append(accepted, rows[i].Cat)
+			idx = append(idx, i)
+		}
+	}
+	if len(accepted) == 0 {
+		return results, nil
+	}
+
+	ids, err := s.repo.CreateCats(ctx, accepted)
+	if err != nil {
+		return nil, err
+	}
+	for j, id := range ids {
+		results[idx[j]].ID = id
+	}
+
+	return results, nil
 }
 
-func (s *catService) GetCat(ctx context.Context, id int64) (domain.Cat, error) {
+func (s *catService) GetCat(ctx context.Context, id int64, currency string) (domain.Cat, error) {
 	if id <= 0 {
 		return domain.Cat{}, errors.New("invalid cat id")
 	}
+	convert, err := s.converter(ctx, currency)
+	if err != nil {
+		return domain.Cat{}, err
+	}
 
 	cat, err := s.repo.GetCat(ctx, id)
 	if err != nil {
@@ -70,6 +204,9 @@ func (s *catService) GetCat(ctx context.Context, id int64) (domain.Cat, error) {
 		return domain.Cat{}, err
 	}
 
+	if cat.Salary, err = convert(cat.Salary); err != nil {
+		return domain.Cat{}, err
+	}
 	return cat, nil
 }
 func (s *catService) ListCats(ctx context.Context, p domain.ListCatsParams) ([]domain.Cat, error) {
@@ -77,31 +214,186 @@ func (s *catService) ListCats(ctx context.Context, p domain.ListCatsParams) ([]d
 	if p.MinYears != nil && p.MaxYears != nil && *p.MinYears > *p.MaxYears {
 		return nil, errors.New("min years cannot be greater than max years")
 	}
+	convert, err := s.converter(ctx, p.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	cats, err := s.repo.ListCats(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cats {
+		if cats[i].Salary, err = convert(cats[i].Salary); err != nil {
+			return nil, err
+		}
+	}
+	return cats, nil
+}
+func (s *catService) ExportCats(ctx context.Context, p domain.ListCatsParams, fn func(domain.Cat) error) error {
+	if p.MinYears != nil && p.MaxYears != nil && *p.MinYears > *p.MaxYears {
+		return errors.New("min years cannot be greater than max years")
+	}
+	convert, err := s.converter(ctx, p.Currency)
+	if err != nil {
+		return err
+	}
 
-	return s.repo.ListCats(ctx, p)
+	return s.repo.StreamCats(ctx, p, func(c domain.Cat) error {
+		var err error
+		if c.Salary, err = convert(c.Salary); err != nil {
+			return err
+		}
+		return fn(c)
+	})
 }
 func (s *catService) DeleteCat(ctx context.Context, id int64) (int64, error) {
 	if id <= 0 {
 		return 0, errors.New("invalid id")
 	}
 
-	affected, err := s.repo.DeleteCat(ctx, id)
-	if err != nil {
+	if err := s.repo.DeleteCat(ctx, id); err != nil {
 		return 0, err
 	}
+	return id, nil
+}
+func (s *catService) RestoreCat(ctx context.Context, id int64) (domain.Cat, error) {
+	if id <= 0 {
+		return domain.Cat{}, errors.New("invalid id")
+	}
 
-	if affected == 0 {
-		return 0, servieserrors.ErrCatNotFound
+	return s.repo.RestoreCat(ctx, id)
+}
+func (s *catService) PurgeDeletedCats(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, errors.New("retention must be positive")
 	}
-	return 0, nil
+
+	return s.repo.PurgeDeletedCats(ctx, time.Now().Add(-retention))
 }
 func (s *catService) UpdateSalary(ctx context.Context, p domain.UpdateSalaryParams) (domain.Cat, error) {
 	if p.ID <= 0 {
 		return domain.Cat{}, errors.New("invalid id")
 	}
 
-	if p.Salary < 0 || p.Salary > 1_000_000 {
+	if p.Salary.IsNegative() {
 		return domain.Cat{}, servieserrors.ErrInvalidSalary
 	}
-	return s.repo.UpdateSalary(ctx, p.ID, p.Salary)
+	// an empty currency keeps the one the cat is paid in
+	if p.Salary.Currency != "" {
+		cur, ok := domain.NormalizeCurrency(p.Salary.Currency)
+		if !ok {
+			return domain.Cat{}, servieserrors.ErrInvalidCurrency
+		}
+		p.Salary.Currency = cur
+	}
+
+	today := domain.Date(time.Now())
+	if p.EffectiveFrom == nil {
+		p.EffectiveFrom = &today
+	}
+	eff := domain.Date(*p.EffectiveFrom)
+	if eff.After(today) {
+		return domain.Cat{}, servieserrors.ErrInvalidEffectiveDate
+	}
+	p.EffectiveFrom = &eff
+	p.Reason = strings.TrimSpace(p.Reason)
+	p.Actor = strings.TrimSpace(p.Actor)
+
+	cat, err := s.repo.GetCat(ctx, p.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Cat{}, servieserrors.ErrCatNotFound
+		}
+		return domain.Cat{}, err
+	}
+	salary := p.Salary
+	if salary.Currency == "" {
+		salary.Currency = cat.Salary.Currency
+	}
+
+	policy, err := s.bandPolicy(ctx)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	if err := policy.checkSalary(ctx, salary); err != nil {
+		return domain.Cat{}, err
+	}
+	ov := domain.SalaryOverride{Override: p.Override, Reason: p.Reason}
+	if p.Override, err = policy.enforce(ctx, cat.YearsExperience, cat.Breed, salary, ov); err != nil {
+		return domain.Cat{}, err
+	}
+
+	return s.repo.UpdateSalary(ctx, p)
+}
+func (s *catService) SalaryHistory(ctx context.Context, catID int64) ([]domain.SalaryChange, error) {
+	if catID <= 0 {
+		return nil, errors.New("invalid id")
+	}
+
+	return s.repo.SalaryHistory(ctx, catID)
+}
+
+// Payroll reports the given month. Months that have not started yet have
+// nothing to pay and are rejected. With a currency every line is converted
+// and the report has a single total.
+func (s *catService) Payroll(ctx context.Context, month time.Time, currency string) (domain.Payroll, error) {
+	if month.IsZero() || domain.MonthStart(month).After(time.Now().UTC()) {
+		return domain.Payroll{}, servieserrors.ErrInvalidMonth
+	}
+	convert, err := s.converter(ctx, currency)
+	if err != nil {
+		return domain.Payroll{}, err
+	}
+
+	p, err := s.repo.Payroll(ctx, domain.MonthStart(month))
+	if err != nil {
+		return domain.Payroll{}, err
+	}
+	for i := range p.Lines {
+		l := &p.Lines[i]
+		if l.Salary, err = convert(l.Salary); err != nil {
+			return domain.Payroll{}, err
+		}
+		if l.Bonus, err = convert(l.Bonus); err != nil {
+			return domain.Payroll{}, err
+		}
+		// summed after converting so the line adds up to the cent
+		l.Amount, _ = l.Salary.Add(l.Bonus)
+	}
+	p.Tally()
+	return p, nil
+}
+
+// converter returns a function converting amounts to currency for
+// reporting. An empty currency leaves amounts as they are.
+func (s *catService) converter(ctx context.Context, currency string) (func(domain.Money) (domain.Money, error), error) {
+	if currency == "" {
+		return func(m domain.Money) (domain.Money, error) { return m, nil }, nil
+	}
+	to, ok := domain.NormalizeCurrency(currency)
+	if !ok {
+		return nil, servieserrors.ErrInvalidCurrency
+	}
+
+	table, err := s.rates.RateTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return func(m domain.Money) (domain.Money, error) {
+		out, ok := table.Convert(m, to)
+		if !ok {
+			return domain.Money{}, fmt.Errorf("%w: %s to %s", servieserrors.ErrExchangeRateNotFound, m.Currency, to)
+		}
+		return out, nil
+	}, nil
+}
+
+// salaryCurrency normalizes the currency of a new salary, defaulting to
+// domain.DefaultCurrency.
+func salaryCurrency(s string) (string, bool) {
+	if s == "" {
+		return domain.DefaultCurrency, true
+	}
+	return domain.NormalizeCurrency(s)
 }
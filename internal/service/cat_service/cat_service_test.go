@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/DavydAbbasov/spy-cat/internal/domain"
 	serviceserrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
@@ -20,28 +22,79 @@ func (m *mockBreedValidator) IsValid(ctx context.Context, breed string) (bool, e
 	return m.ok, m.err
 }
 
+type mockRates struct {
+	table domain.RateTable
+}
+
+func (m mockRates) RateTable(ctx context.Context) (domain.RateTable, error) {
+	return m.table, nil
+}
+
 type mockRepo struct {
 	createCalled bool
 	retID        int64
 	retErr       error
+	salary       domain.UpdateSalaryParams
+	cats         []domain.Cat
+	bands        []domain.SalaryBand
+	override     domain.SalaryOverride
+	payroll      domain.Payroll
 }
 
-func (r *mockRepo) CreateCat(ctx context.Context, cat *domain.Cat) (int64, error) {
+func (r *mockRepo) CreateCat(ctx context.Context, cat *domain.Cat, ov domain.SalaryOverride) (int64, error) {
 	r.createCalled = true
+	r.override = ov
 	return r.retID, r.retErr
 }
+func (r *mockRepo) CreateCats(ctx context.Context, cats []domain.Cat) ([]int64, error) {
+	r.createCalled = true
+	ids := make([]int64, len(cats))
+	for i := range cats {
+		ids[i] = r.retID + int64(i)
+	}
+	return ids, r.retErr
+}
 func (r *mockRepo) ListCats(ctx context.Context, p domain.ListCatsParams) ([]domain.Cat, error) {
-	return nil, nil
+	return append([]domain.Cat(nil), r.cats...), nil
+}
+func (r *mockRepo) StreamCats(ctx context.Context, p domain.ListCatsParams, fn func(domain.Cat) error) error {
+	for _, c := range r.cats {
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 func (r *mockRepo) GetCat(ctx context.Context, id int64) (domain.Cat, error) {
+	if len(r.cats) > 0 {
+		return r.cats[0], nil
+	}
 	return domain.Cat{}, nil
 }
-func (r *mockRepo) DeleteCat(ctx context.Context, id int64) (int64, error) {
+func (r *mockRepo) DeleteCat(ctx context.Context, id int64) error {
+	return nil
+}
+func (r *mockRepo) RestoreCat(ctx context.Context, id int64) (domain.Cat, error) {
+	return domain.Cat{}, nil
+}
+func (r *mockRepo) PurgeDeletedCats(ctx context.Context, before time.Time) (int64, error) {
 	return 0, nil
 }
-func (r *mockRepo) UpdateSalary(ctx context.Context, id int64, salary float64) (domain.Cat, error) {
+func (r *mockRepo) UpdateSalary(ctx context.Context, p domain.UpdateSalaryParams) (domain.Cat, error) {
+	r.salary = p
 	return domain.Cat{}, nil
 }
+func (r *mockRepo) SalaryHistory(ctx context.Context, catID int64) ([]domain.SalaryChange, error) {
+	return nil, nil
+}
+func (r *mockRepo) Payroll(ctx context.Context, month time.Time) (domain.Payroll, error) {
+	p := r.payroll
+	p.Month = month
+	return p, nil
+}
+func (r *mockRepo) ListSalaryBands(ctx context.Context) ([]domain.SalaryBand, error) {
+	return r.bands, nil
+}
 
 func TestCreateCat_BreedValidation(t *testing.T) {
 	t.Parallel()
@@ -82,16 +135,16 @@ func TestCreateCat_BreedValidation(t *testing.T) {
 			val := &mockBreedValidator{ok: tc.validatorOK, err: tc.validatorErr}
 			repo := &mockRepo{retID: tc.repoID}
 
-			svc := NewCatService(repo, val)
+			svc := NewCatService(repo, val, mockRates{})
 
 			cat := &domain.Cat{
 				Name:            "bro this is rapchik",
 				YearsExperience: 33,
 				Breed:           "siamese",
-				Salary:          11111,
+				Salary:          domain.NewMoney(11111, 0),
 			}
 
-			id, err := svc.CreateCat(context.Background(), cat)
+			id, err := svc.CreateCat(context.Background(), cat, domain.SalaryOverride{})
 
 			if tc.wantErr == nil {
 				if err != nil {
@@ -115,3 +168,325 @@ func TestCreateCat_BreedValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestImportCats_BreedLookupAndDryRun(t *testing.T) {
+	t.Parallel()
+
+	rows := []domain.CatImportRow{
+		{Line: 2, Cat: domain.Cat{Name: "Tom", Breed: "Siamese", Salary: domain.NewMoney(100, 0)}},
+		{Line: 3, Cat: domain.Cat{Name: "Kitty", Breed: "siamese", Salary: domain.NewMoney(200, 0)}},
+		{Line: 4, Cat: domain.Cat{Name: "  ", Breed: "Bengal", Salary: domain.NewMoney(300, 0)}},
+		{Line: 5, Cat: domain.Cat{Name: "Rex", Breed: "Bengal", Salary: domain.NewMoney(400, 0)}},
+	}
+
+	tests := []struct {
+		name             string
+		dryRun           bool
+		wantCreateCalled bool
+	}{
+		{name: "dry run does not store", dryRun: true, wantCreateCalled: false},
+		{name: "import stores accepted rows", dryRun: false, wantCreateCalled: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			val := &mockBreedValidator{ok: true}
+			repo := &mockRepo{retID: 10}
+			svc := NewCatService(repo, val, mockRates{})
+
+			in := make([]domain.CatImportRow, len(rows))
+			copy(in, rows)
+
+			res, err := svc.ImportCats(context.Background(), in, tc.dryRun)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(res) != len(rows) {
+				t.Fatalf("want %d results, got %d", len(rows), len(res))
+			}
+			if !errors.Is(res[2].Err, serviceserrors.ErrInvalidName) {
+				t.Fatalf("line 4: want ErrInvalidName, got %v", res[2].Err)
+			}
+			if val.calls != 2 {
+				t.Fatalf("breed validator calls=%d, want 2", val.calls)
+			}
+			if repo.createCalled != tc.wantCreateCalled {
+				t.Fatalf("repo.CreateCats called=%v, want %v", repo.createCalled, tc.wantCreateCalled)
+			}
+			if !tc.dryRun && (res[0].ID != 10 || res[1].ID != 11 || res[3].ID != 12) {
+				t.Fatalf("unexpected ids: %+v", res)
+			}
+		})
+	}
+}
+
+func TestUpdateSalary_EffectiveDate(t *testing.T) {
+	t.Parallel()
+
+	today := domain.Date(time.Now())
+	past := today.AddDate(0, 0, -10).Add(15 * time.Hour)
+	future := today.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name    string
+		from    *time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{name: "defaults to today", want: today},
+		{name: "backdated is truncated to a date", from: &past, want: today.AddDate(0, 0, -10)},
+		{name: "future is rejected", from: &future, wantErr: serviceserrors.ErrInvalidEffectiveDate},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &mockRepo{}
+			svc := NewCatService(repo, &mockBreedValidator{ok: true}, mockRates{})
+
+			_, err := svc.UpdateSalary(context.Background(), domain.UpdateSalaryParams{
+				ID: 1, Salary: domain.NewMoney(500, 0), EffectiveFrom: tc.from, Reason: "  raise ",
+			})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !repo.salary.EffectiveFrom.Equal(tc.want) {
+				t.Fatalf("effective from=%v, want %v", repo.salary.EffectiveFrom, tc.want)
+			}
+			if repo.salary.Reason != "raise" {
+				t.Fatalf("reason=%q, want trimmed", repo.salary.Reason)
+			}
+		})
+	}
+}
+
+func TestListCats_Currency(t *testing.T) {
+	t.Parallel()
+
+	eur := domain.Money{Amount: 100000, Currency: "EUR"}
+	usd := domain.NewMoney(500, 0)
+	repo := &mockRepo{cats: []domain.Cat{{ID: 1, Salary: eur}, {ID: 2, Salary: usd}}}
+	rates := mockRates{table: domain.NewRateTable([]domain.ExchangeRate{
+		{From: "EUR", To: "USD", Rate: big.NewRat(108, 100)},
+	})}
+	svc := NewCatService(repo, &mockBreedValidator{ok: true}, rates)
+
+	got, err := svc.ListCats(context.Background(), domain.ListCatsParams{Currency: "usd"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[0].Salary != (domain.Money{Amount: 108000, Currency: "USD"}) || got[1].Salary != usd {
+		t.Fatalf("unexpected salaries: %+v", got)
+	}
+
+	got, err = svc.ListCats(context.Background(), domain.ListCatsParams{})
+	if err != nil || got[0].Salary != eur {
+		t.Fatalf("without currency salaries must be unchanged, got %+v, %v", got, err)
+	}
+
+	if _, err := svc.ListCats(context.Background(), domain.ListCatsParams{Currency: "GBP"}); !errors.Is(err, serviceserrors.ErrExchangeRateNotFound) {
+		t.Fatalf("want ErrExchangeRateNotFound, got %v", err)
+	}
+	if _, err := svc.ListCats(context.Background(), domain.ListCatsParams{Currency: "US1"}); !errors.Is(err, serviceserrors.ErrInvalidCurrency) {
+		t.Fatalf("want ErrInvalidCurrency, got %v", err)
+	}
+}
+
+func TestSalaryBandEnforcement(t *testing.T) {
+	t.Parallel()
+
+	five := int64(5)
+	bands := []domain.SalaryBand{
+		{ID: 1, MinYears: 0, MaxYears: &five, Min: domain.NewMoney(100, 0), Max: domain.NewMoney(1000, 0)},
+		{ID: 2, MinYears: 0, Breed: "Siamese", Min: domain.NewMoney(2000, 0), Max: domain.NewMoney(3000, 0)},
+	}
+	rates := mockRates{table: domain.NewRateTable([]domain.ExchangeRate{
+		{From: "EUR", To: "USD", Rate: big.NewRat(2, 1)},
+	})}
+
+	tests := []struct {
+		name         string
+		breed        string
+		salary       domain.Money
+		ov           domain.SalaryOverride
+		wantErr      error
+		wantOverride bool
+	}{
+		{name: "inside the band", breed: "bengal", salary: domain.NewMoney(500, 0)},
+		{name: "above the band", breed: "bengal", salary: domain.NewMoney(1001, 0), wantErr: serviceserrors.ErrSalaryOutOfBand},
+		{name: "breed band wins", breed: "siamese", salary: domain.NewMoney(500, 0), wantErr: serviceserrors.ErrSalaryOutOfBand},
+		{name: "converted to the band currency", breed: "bengal", salary: domain.Money{Amount: 40000, Currency: "EUR"}},
+		{name: "no rate to the band currency", breed: "bengal", salary: domain.Money{Amount: 40000, Currency: "GBP"}, wantErr: serviceserrors.ErrExchangeRateNotFound},
+		{
+			name: "override without reason", breed: "bengal", salary: domain.NewMoney(1001, 0),
+			ov: domain.SalaryOverride{Override: true, Reason: " "}, wantErr: serviceserrors.ErrOverrideReasonRequired,
+		},
+		{
+			name: "override with reason", breed: "bengal", salary: domain.NewMoney(1001, 0),
+			ov: domain.SalaryOverride{Override: true, Reason: "retention"}, wantOverride: true,
+		},
+		{
+			name: "unneeded override is not recorded", breed: "bengal", salary: domain.NewMoney(500, 0),
+			ov: domain.SalaryOverride{Override: true, Reason: "retention"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &mockRepo{retID: 1, bands: bands}
+			svc := NewCatService(repo, &mockBreedValidator{ok: true}, rates)
+
+			cat := &domain.Cat{Name: "Tom", YearsExperience: 3, Breed: tc.breed, Salary: tc.salary}
+			_, err := svc.CreateCat(context.Background(), cat, tc.ov)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want %v, got %v", tc.wantErr, err)
+				}
+				if repo.createCalled {
+					t.Fatal("repo.CreateCat must not be called")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if repo.override.Override != tc.wantOverride {
+				t.Fatalf("override=%v, want %v", repo.override.Override, tc.wantOverride)
+			}
+		})
+	}
+}
+
+func TestUpdateSalary_Band(t *testing.T) {
+	t.Parallel()
+
+	band := domain.SalaryBand{ID: 1, Min: domain.Money{Amount: 10000, Currency: "EUR"}, Max: domain.Money{Amount: 50000, Currency: "EUR"}}
+	cat := domain.Cat{ID: 1, YearsExperience: 2, Breed: "bengal", Salary: domain.Money{Amount: 20000, Currency: "EUR"}}
+	repo := &mockRepo{cats: []domain.Cat{cat}, bands: []domain.SalaryBand{band}}
+	rates := mockRates{table: domain.NewRateTable([]domain.ExchangeRate{
+		{From: "EUR", To: "USD", Rate: big.NewRat(11, 10)},
+	})}
+	svc := NewCatService(repo, &mockBreedValidator{ok: true}, rates)
+
+	// without a currency the salary stays in the cat's currency
+	_, err := svc.UpdateSalary(context.Background(), domain.UpdateSalaryParams{ID: 1, Salary: domain.Money{Amount: 60000}})
+	if !errors.Is(err, serviceserrors.ErrSalaryOutOfBand) {
+		t.Fatalf("want ErrSalaryOutOfBand, got %v", err)
+	}
+
+	_, err = svc.UpdateSalary(context.Background(), domain.UpdateSalaryParams{
+		ID: 1, Salary: domain.Money{Amount: 60000}, Override: true, Reason: "promotion",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !repo.salary.Override || repo.salary.Reason != "promotion" {
+		t.Fatalf("override not passed to the ledger: %+v", repo.salary)
+	}
+}
+
+func TestCreateCat_SalaryCapInOtherCurrency(t *testing.T) {
+	t.Parallel()
+
+	rates := mockRates{table: domain.NewRateTable([]domain.ExchangeRate{
+		{From: "USD", To: "JPY", Rate: big.NewRat(150, 1)},
+	})}
+
+	tests := []struct {
+		name    string
+		salary  domain.Money
+		wantErr error
+	}{
+		{name: "under the cap once converted", salary: domain.Money{Amount: 100 * domain.MaxSalary.Amount, Currency: "JPY"}},
+		{name: "over the cap once converted", salary: domain.Money{Amount: 200 * domain.MaxSalary.Amount, Currency: "JPY"}, wantErr: serviceserrors.ErrInvalidSalary},
+		{name: "no rate to compare with the cap", salary: domain.Money{Amount: 100, Currency: "EUR"}, wantErr: serviceserrors.ErrExchangeRateNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &mockRepo{retID: 1}
+			svc := NewCatService(repo, &mockBreedValidator{ok: true}, rates)
+
+			_, err := svc.CreateCat(context.Background(), &domain.Cat{Name: "Tom", Breed: "bengal", Salary: tc.salary}, domain.SalaryOverride{})
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPayroll_BonusConversion(t *testing.T) {
+	t.Parallel()
+
+	eur := func(cents int64) domain.Money { return domain.Money{Amount: cents, Currency: "EUR"} }
+	repo := &mockRepo{payroll: domain.Payroll{Lines: []domain.PayrollLine{
+		{CatID: 1, Salary: eur(100001), Bonus: eur(100001), Amount: eur(200002)},
+		{CatID: 2, Salary: domain.NewMoney(0, 0), Bonus: domain.NewMoney(50, 0), Amount: domain.NewMoney(50, 0)},
+	}}}
+	rates := mockRates{table: domain.NewRateTable([]domain.ExchangeRate{
+		{From: "EUR", To: "USD", Rate: big.NewRat(1, 2)},
+	})}
+	svc := NewCatService(repo, &mockBreedValidator{ok: true}, rates)
+
+	p, err := svc.Payroll(context.Background(), time.Now().AddDate(0, -1, 0), "USD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 1000.01 EUR is 500.01 USD after rounding, so the line is the sum of the
+	// converted parts rather than the converted sum
+	if p.Lines[0].Amount != domain.NewMoney(1000, 2) {
+		t.Fatalf("line amount=%v, want 1000.02", p.Lines[0].Amount)
+	}
+	if len(p.Totals) != 1 || p.Totals[0] != domain.NewMoney(1050, 2) {
+		t.Fatalf("unexpected totals: %+v", p.Totals)
+	}
+}
+
+func TestOutOfBandCats_SkipsUnconvertibleSalaries(t *testing.T) {
+	t.Parallel()
+
+	band := domain.SalaryBand{ID: 1, Min: domain.NewMoney(100, 0), Max: domain.NewMoney(200, 0)}
+	repo := &mockRepo{
+		bands: []domain.SalaryBand{band},
+		cats: []domain.Cat{
+			{ID: 1, Salary: domain.NewMoney(150, 0)},
+			{ID: 2, Salary: domain.NewMoney(500, 0)},
+			{ID: 3, Salary: domain.Money{Amount: 50000, Currency: "GBP"}},
+			{ID: 4, Salary: domain.Money{Amount: 50000, Currency: "EUR"}},
+		},
+	}
+	rates := mockRates{table: domain.NewRateTable([]domain.ExchangeRate{
+		{From: "EUR", To: "USD", Rate: big.NewRat(1, 2)},
+	})}
+	svc := NewCatService(repo, &mockBreedValidator{ok: true}, rates)
+
+	got, err := svc.OutOfBandCats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Cat.ID != 2 || got[1].Cat.ID != 4 {
+		t.Fatalf("want cats 2 and 4, got %+v", got)
+	}
+	if got[1].Salary != domain.NewMoney(250, 0) {
+		t.Fatalf("salary must be shown in the band currency, got %v", got[1].Salary)
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+// maxRate keeps rates inside NUMERIC(18,8).
+var maxRate = big.NewRat(9_999_999_999, 1)
+
+type CurrencyService interface {
+	ListRates(ctx context.Context) ([]domain.ExchangeRate, error)
+	SetRate(ctx context.Context, from, to string, rate *big.Rat) (domain.ExchangeRate, error)
+	DeleteRate(ctx context.Context, from, to string) error
+	RateTable(ctx context.Context) (domain.RateTable, error)
+}
+type Repository interface {
+	ListRates(ctx context.Context) ([]domain.ExchangeRate, error)
+	UpsertRate(ctx context.Context, rate domain.ExchangeRate) (domain.ExchangeRate, error)
+	DeleteRate(ctx context.Context, from, to string) error
+}
+type currencyService struct {
+	repo Repository
+}
+
+func NewCurrencyService(repo Repository) CurrencyService {
+	return &currencyService{repo: repo}
+}
+
+func (s *currencyService) ListRates(ctx context.Context) ([]domain.ExchangeRate, error) {
+	return s.repo.ListRates(ctx)
+}
+
+func (s *currencyService) SetRate(ctx context.Context, from, to string, rate *big.Rat) (domain.ExchangeRate, error) {
+	from, to, err := normalizePair(from, to)
+	if err != nil {
+		return domain.ExchangeRate{}, err
+	}
+	if rate == nil || rate.Sign() <= 0 || rate.Cmp(maxRate) > 0 {
+		return domain.ExchangeRate{}, serviceerrors.ErrInvalidExchangeRate
+	}
+
+	return s.repo.UpsertRate(ctx, domain.ExchangeRate{From: from, To: to, Rate: rate})
+}
+
+func (s *currencyService) DeleteRate(ctx context.Context, from, to string) error {
+	from, to, err := normalizePair(from, to)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteRate(ctx, from, to)
+}
+
+// RateTable loads every stored rate. The table is small, so it is read per
+// request and changes take effect immediately.
+func (s *currencyService) RateTable(ctx context.Context) (domain.RateTable, error) {
+	rates, err := s.repo.ListRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewRateTable(rates), nil
+}
+
+func normalizePair(from, to string) (string, string, error) {
+	f, ok := domain.NormalizeCurrency(from)
+	if !ok {
+		return "", "", serviceerrors.ErrInvalidCurrency
+	}
+	t, ok := domain.NormalizeCurrency(to)
+	if !ok {
+		return "", "", serviceerrors.ErrInvalidCurrency
+	}
+	if f == t {
+		return "", "", serviceerrors.ErrInvalidExchangeRate
+	}
+	return f, t, nil
+}
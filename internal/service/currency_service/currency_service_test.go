@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type fakeRepo struct {
+	rates    []domain.ExchangeRate
+	upserted []domain.ExchangeRate
+	deleted  [][2]string
+}
+
+func (r *fakeRepo) ListRates(ctx context.Context) ([]domain.ExchangeRate, error) {
+	return r.rates, nil
+}
+func (r *fakeRepo) UpsertRate(ctx context.Context, rate domain.ExchangeRate) (domain.ExchangeRate, error) {
+	r.upserted = append(r.upserted, rate)
+	return rate, nil
+}
+func (r *fakeRepo) DeleteRate(ctx context.Context, from, to string) error {
+	r.deleted = append(r.deleted, [2]string{from, to})
+	return nil
+}
+
+func TestSetRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		from, to string
+		rate     *big.Rat
+		wantErr  error
+	}{
+		{name: "normalizes the pair", from: " eur", to: "usd ", rate: big.NewRat(11, 10)},
+		{name: "invalid currency", from: "EURO", to: "USD", rate: big.NewRat(1, 1), wantErr: serviceerrors.ErrInvalidCurrency},
+		{name: "same currency", from: "usd", to: "USD", rate: big.NewRat(1, 1), wantErr: serviceerrors.ErrInvalidExchangeRate},
+		{name: "missing rate", from: "EUR", to: "USD", wantErr: serviceerrors.ErrInvalidExchangeRate},
+		{name: "zero rate", from: "EUR", to: "USD", rate: new(big.Rat), wantErr: serviceerrors.ErrInvalidExchangeRate},
+		{name: "rate too large", from: "EUR", to: "USD", rate: big.NewRat(10_000_000_000, 1), wantErr: serviceerrors.ErrInvalidExchangeRate},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeRepo{}
+			svc := NewCurrencyService(repo)
+
+			got, err := svc.SetRate(context.Background(), tc.from, tc.to, tc.rate)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("want %v, got %v", tc.wantErr, err)
+				}
+				if len(repo.upserted) != 0 {
+					t.Fatal("invalid rate must not be stored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.From != "EUR" || got.To != "USD" || got.Rate.Cmp(tc.rate) != 0 {
+				t.Fatalf("unexpected rate stored: %+v", got)
+			}
+		})
+	}
+}
+
+func TestDeleteRate_NormalizesPair(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepo{}
+	svc := NewCurrencyService(repo)
+
+	if err := svc.DeleteRate(context.Background(), "eur", "usd"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.deleted) != 1 || repo.deleted[0] != [2]string{"EUR", "USD"} {
+		t.Fatalf("unexpected delete: %v", repo.deleted)
+	}
+	if err := svc.DeleteRate(context.Background(), "eur", "$$$"); !errors.Is(err, serviceerrors.ErrInvalidCurrency) {
+		t.Fatalf("want ErrInvalidCurrency, got %v", err)
+	}
+}
+
+func TestRateTable_UsesStoredRates(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepo{rates: []domain.ExchangeRate{
+		{From: "EUR", To: "USD", Rate: big.NewRat(2, 1)},
+		{From: "USD", To: "JPY", Rate: big.NewRat(150, 1)},
+	}}
+	svc := NewCurrencyService(repo)
+
+	table, err := svc.RateTable(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, ok := table.Convert(domain.Money{Amount: 100, Currency: "EUR"}, "JPY")
+	if !ok || got.Amount != 30000 || got.Currency != "JPY" {
+		t.Fatalf("EUR to JPY through USD: got %+v, %v", got, ok)
+	}
+}
@@ -4,33 +4,72 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/countries"
 	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
 	"github.com/rs/zerolog/log"
 )
 
 type MissionService interface {
 	CreateMission(ctx context.Context, p domain.CreateMissionParams) (domain.Mission, error)
-	AssignCat(ctx context.Context, missionID int64, catID *int64) error
+	CreateMissions(ctx context.Context, ps []domain.CreateMissionParams) ([]domain.Mission, error)
+	CloneMission(ctx context.Context, p domain.CloneMissionParams) (domain.Mission, error)
+	AssignCat(ctx context.Context, p domain.AssignMissionParams) error
+	Handovers(ctx context.Context, missionID int64) ([]domain.MissionHandover, error)
+	AddTeamMember(ctx context.Context, a domain.MissionAssignment) (domain.MissionAssignment, error)
+	RemoveTeamMember(ctx context.Context, missionID, catID int64) error
+	Team(ctx context.Context, missionID int64) ([]domain.MissionAssignment, error)
+	AssignGoal(ctx context.Context, missionID, goalID int64, catID *int64) (domain.MissionGoal, error)
+	CatMissions(ctx context.Context, catID int64) ([]domain.CatMission, error)
 	GetMission(ctx context.Context, id int64) (domain.Mission, []domain.MissionGoal, error)
 	List(ctx context.Context, f domain.MissionFilter) ([]domain.MissionListItem, int, error)
+	Search(ctx context.Context, p domain.SearchParams) ([]domain.SearchHit, int, error)
+	Export(ctx context.Context, f domain.MissionFilter, fn func(domain.Mission) error) error
 	UpdateStatus(ctx context.Context, p domain.UpdateMissionStatusParams) (domain.Mission, error)
+	Transitions(ctx context.Context, id int64) (domain.Mission, []domain.MissionStatus, error)
 	AddGoal(ctx context.Context, missionID int64, p domain.CreateGoalParams) (domain.MissionGoal, error)
+	AddDependency(ctx context.Context, missionID, dependsOnID int64) (domain.MissionDependency, error)
+	RemoveDependency(ctx context.Context, missionID, dependsOnID int64) error
+	Dependencies(ctx context.Context, missionID int64) (domain.DependencyGraph, error)
+	UpdateGoalStatus(ctx context.Context, p domain.UpdateGoalStatusParams) (domain.MissionGoal, error)
+	ReorderGoals(ctx context.Context, missionID int64, goalIDs []int64) ([]domain.MissionGoal, error)
 }
 type MissionRepository interface {
 	BeginTx(ctx context.Context) (Tx, error)
 	InsertMission(ctx context.Context, tx Tx, m *domain.Mission) (int64, error)
+	InsertMissions(ctx context.Context, tx Tx, ms []domain.Mission) ([]int64, error)
 	InsertGoals(ctx context.Context, tx Tx, missionID int64, goals []domain.MissionGoal) error
+	InsertGoalRows(ctx context.Context, tx Tx, goals []domain.MissionGoal) error
 	AssignCat(ctx context.Context, tx Tx, missionID int64, catID *int64) error
+	GetMissionForUpdate(ctx context.Context, tx Tx, id int64) (domain.Mission, error)
+	InsertHandover(ctx context.Context, tx Tx, h *domain.MissionHandover) error
+	AttributeDoneGoals(ctx context.Context, tx Tx, missionID, catID int64) error
+	ListHandovers(ctx context.Context, missionID int64) ([]domain.MissionHandover, error)
+	AddTeamMember(ctx context.Context, a domain.MissionAssignment) (domain.MissionAssignment, error)
+	RemoveTeamMember(ctx context.Context, missionID, catID int64) error
+	ListTeam(ctx context.Context, missionID int64) ([]domain.MissionAssignment, error)
+	SetGoalAssignee(ctx context.Context, missionID, goalID int64, catID *int64) (domain.MissionGoal, error)
+	ListCatMissions(ctx context.Context, catID int64) ([]domain.CatMission, error)
 	GetMission(ctx context.Context, id int64) (domain.Mission, error)
 	GetMissionGoals(ctx context.Context, missionID int64) ([]domain.MissionGoal, error)
 	ListMissions(ctx context.Context, f domain.MissionFilter) ([]domain.MissionListItem, int, error)
-	UpdateStatusIfCurrent(ctx context.Context, id int64, newStatus, expected domain.MissionStatus) (domain.Mission, bool, error)
+	StreamMissions(ctx context.Context, f domain.MissionFilter, fn func(domain.Mission) error) error
+	SearchMissions(ctx context.Context, p domain.SearchParams) ([]domain.SearchHit, int, error)
+	UpdateStatusIfCurrent(ctx context.Context, id int64, newStatus, expected domain.MissionStatus, reason string) (domain.Mission, bool, error)
 	InsertGoal(ctx context.Context, missionID int64, p domain.CreateGoalParams) (domain.MissionGoal, error)
+	AddDependency(ctx context.Context, missionID, dependsOnID int64) (domain.MissionDependency, error)
+	RemoveDependency(ctx context.Context, missionID, dependsOnID int64) error
+	DependencyGraph(ctx context.Context, missionID int64) (domain.DependencyGraph, error)
+	UpdateGoalStatusIfCurrent(ctx context.Context, missionID, goalID int64, newStatus, expected domain.MissionGoalStatus) (domain.MissionGoal, bool, error)
+	ReorderGoals(ctx context.Context, missionID int64, ids []int64) ([]domain.MissionGoal, error)
 }
 
+// MaxBulkMissions caps how many missions a single bulk request may create.
+const MaxBulkMissions = 500
+
 type Tx interface {
 	Commit(ctx context.Context) error
 	Rollback(ctx context.Context) error
@@ -45,34 +84,11 @@ func NewMissionService(repo MissionRepository) MissionService {
 	}
 }
 func (s *missionService) CreateMission(ctx context.Context, p domain.CreateMissionParams) (domain.Mission, error) {
-	p.Title = strings.TrimSpace(p.Title)
-	p.Description = strings.TrimSpace(p.Description)
-
-	if p.Title == "" {
-		return domain.Mission{}, serviceerrors.ErrInvalidCreateMission
+	m, goals, err := buildMission(p)
+	if err != nil {
+		return domain.Mission{}, err
 	}
 
-	goals := make([]domain.MissionGoal, 0, len(p.Goals))
-	for _, g := range p.Goals {
-		name := strings.TrimSpace(g.Name)
-		country := strings.ToUpper(strings.TrimSpace(g.Country))
-		notes := strings.TrimSpace(g.Notes)
-
-		if name == "" {
-			return domain.Mission{}, serviceerrors.ErrInvalidGoalName
-		}
-		if len(country) != 2 {
-			return domain.Mission{}, serviceerrors.ErrInvalidCountry
-		}
-
-		goals = append(goals, domain.MissionGoal{
-			Name:    name,
-			Country: country,
-			Notes:   notes,
-			Status:  domain.GoalTodo,
-		})
-
-	}
 	tx, err := s.repo.BeginTx(ctx)
 	if err != nil {
 		return domain.Mission{}, err
@@ -83,13 +99,6 @@ func (s *missionService) CreateMission(ctx context.Context, p domain.CreateMissi
 		}
 	}()
 
-	m := domain.Mission{
-		Title:       p.Title,
-		Description: p.Description,
-		Status:      domain.StatusPlanned,
-		CatID:       nil,
-	}
-
 	id, err := s.repo.InsertMission(ctx, tx, &m)
 	if err != nil {
 		return domain.Mission{}, err
@@ -107,8 +116,157 @@ func (s *missionService) CreateMission(ctx context.Context, p domain.CreateMissi
 	m.ID = id
 	return m, nil
 }
-func (s *missionService) AssignCat(ctx context.Context, missionID int64, catID *int64) error {
-	if missionID <= 0 {
+func (s *missionService) CreateMissions(ctx context.Context, ps []domain.CreateMissionParams) ([]domain.Mission, error) {
+	if len(ps) == 0 || len(ps) > MaxBulkMissions {
+		return nil, fmt.Errorf("%w: send 1 to %d missions", serviceerrors.ErrInvalidCreateMission, MaxBulkMissions)
+	}
+
+	missions := make([]domain.Mission, len(ps))
+	goalsByItem := make([][]domain.MissionGoal, len(ps))
+	itemErrs := serviceerrors.ItemErrors{}
+
+	for i, p := range ps {
+		m, goals, err := buildMission(p)
+		if err != nil {
+			itemErrs[i] = err
+			continue
+		}
+		missions[i] = m
+		goalsByItem[i] = goals
+	}
+	if len(itemErrs) > 0 {
+		return nil, itemErrs
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Warn().Err(err).Msg("rollback failed")
+		}
+	}()
+
+	ids, err := s.repo.InsertMissions(ctx, tx, missions)
+	if err != nil {
+		return nil, err
+	}
+
+	var goals []domain.MissionGoal
+	for i, id := range ids {
+		missions[i].ID = id
+		for _, g := range goalsByItem[i] {
+			g.MissionID = id
+			goals = append(goals, g)
+		}
+	}
+	if len(goals) > 0 {
+		if err := s.repo.InsertGoalRows(ctx, tx, goals); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return missions, nil
+}
+
+// CloneMission creates a new planned mission with the title, description,
+// priority and goals of an existing one. The copy has no cat and its goals
+// start over as todo.
+func (s *missionService) CloneMission(ctx context.Context, p domain.CloneMissionParams) (domain.Mission, error) {
+	src, goals, err := s.GetMission(ctx, p.ID)
+	if err != nil {
+		return domain.Mission{}, err
+	}
+
+	params := domain.CreateMissionParams{
+		Title:       src.Title,
+		Description: src.Description,
+		Status:      domain.StatusPlanned,
+		DueAt:       p.DueAt,
+		Priority:    &src.Priority,
+		Goals:       make([]domain.CreateGoalParams, 0, len(goals)),
+		Occurrence:  p.Occurrence,
+	}
+	if p.Title != nil {
+		params.Title = *p.Title
+	}
+	for _, g := range goals {
+		params.Goals = append(params.Goals, domain.CreateGoalParams{
+			Name:     g.Name,
+			Country:  g.Country,
+			Notes:    g.Notes,
+			Location: g.Location,
+		})
+	}
+
+	return s.CreateMission(ctx, params)
+}
+
+// buildMission normalizes create params and checks them before they reach the repository.
+func buildMission(p domain.CreateMissionParams) (domain.Mission, []domain.MissionGoal, error) {
+	p.Title = strings.TrimSpace(p.Title)
+	p.Description = strings.TrimSpace(p.Description)
+
+	if p.Title == "" {
+		return domain.Mission{}, nil, serviceerrors.ErrInvalidCreateMission
+	}
+
+	goals := make([]domain.MissionGoal, 0, len(p.Goals))
+	for _, g := range p.Goals {
+		name := strings.TrimSpace(g.Name)
+		notes := strings.TrimSpace(g.Notes)
+
+		if name == "" {
+			return domain.Mission{}, nil, serviceerrors.ErrInvalidGoalName
+		}
+		country, ok := countries.Normalize(g.Country)
+		if !ok {
+			return domain.Mission{}, nil, serviceerrors.ErrInvalidCountry
+		}
+		if g.Location != nil && !g.Location.IsValid() {
+			return domain.Mission{}, nil, serviceerrors.ErrInvalidLocation
+		}
+
+		goals = append(goals, domain.MissionGoal{
+			Name:     name,
+			Country:  country,
+			Notes:    notes,
+			Status:   domain.GoalTodo,
+			Position: len(goals) + 1,
+			Location: g.Location,
+		})
+	}
+
+	priority := domain.PriorityDefault
+	if p.Priority != nil {
+		priority = *p.Priority
+	}
+	if priority < domain.PriorityHighest || priority > domain.PriorityLowest {
+		return domain.Mission{}, nil, serviceerrors.ErrInvalidPriority
+	}
+
+	m := domain.Mission{
+		Title:       p.Title,
+		Description: p.Description,
+		Status:      domain.StatusPlanned,
+		CatID:       nil,
+		DueAt:       p.DueAt,
+		Priority:    priority,
+		Occurrence:  p.Occurrence,
+	}
+	return m, goals, nil
+}
+
+// AssignCat sets the cat of a mission. A planned mission is simply reassigned;
+// a mission in progress goes through a handover that needs a note, is recorded,
+// and leaves the goals done so far credited to the outgoing cat.
+func (s *missionService) AssignCat(ctx context.Context, p domain.AssignMissionParams) error {
+	if p.MissionID <= 0 {
 		return serviceerrors.ErrInvalidCreateMission
 	}
 
@@ -118,7 +276,26 @@ func (s *missionService) AssignCat(ctx context.Context, missionID int64, catID *
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
-	err = s.repo.AssignCat(ctx, tx, missionID, catID)
+	m, err := s.repo.GetMissionForUpdate(ctx, tx, p.MissionID)
+	if err != nil {
+		return err
+	}
+	if m.Status.IsTerminal() {
+		return serviceerrors.ErrMissionAlreadyCompleted
+	}
+	if p.OnlyIfUnassigned {
+		if m.Status != domain.StatusPlanned {
+			return serviceerrors.ErrMissionNotPlanned
+		}
+		if m.CatID != nil {
+			return serviceerrors.ErrMissionHasAssignee
+		}
+	}
+	if sameCat(m.CatID, p.CatID) {
+		return nil
+	}
+
+	err = s.repo.AssignCat(ctx, tx, p.MissionID, p.CatID)
 	if err != nil {
 		if errors.Is(err, serviceerrors.ErrMissionNotFound) {
 			return serviceerrors.ErrMissionNotFound
@@ -129,8 +306,123 @@ func (s *missionService) AssignCat(ctx context.Context, missionID int64, catID *
 		return err
 	}
 
+	inProgress := m.Status == domain.StatusActive || m.Status == domain.StatusPaused
+	if inProgress && m.CatID != nil {
+		note := strings.TrimSpace(p.Note)
+		if note == "" {
+			return serviceerrors.ErrHandoverNoteRequired
+		}
+
+		if err := s.repo.AttributeDoneGoals(ctx, tx, m.ID, *m.CatID); err != nil {
+			return err
+		}
+		if err := s.repo.InsertHandover(ctx, tx, &domain.MissionHandover{
+			MissionID: m.ID,
+			FromCatID: m.CatID,
+			ToCatID:   p.CatID,
+			Note:      note,
+		}); err != nil {
+			return err
+		}
+	}
+
 	return tx.Commit(ctx)
 }
+func (s *missionService) Handovers(ctx context.Context, missionID int64) ([]domain.MissionHandover, error) {
+	if missionID <= 0 {
+		return nil, serviceerrors.ErrMissionNotFound
+	}
+
+	if _, err := s.repo.GetMission(ctx, missionID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListHandovers(ctx, missionID)
+}
+
+// AddTeamMember adds a support or lookout cat. The lead is set through AssignCat.
+func (s *missionService) AddTeamMember(ctx context.Context, a domain.MissionAssignment) (domain.MissionAssignment, error) {
+	if a.MissionID <= 0 {
+		return domain.MissionAssignment{}, serviceerrors.ErrMissionNotFound
+	}
+	if a.CatID <= 0 {
+		return domain.MissionAssignment{}, serviceerrors.ErrCatNotFound
+	}
+	if !a.Role.IsValid() || a.Role == domain.RoleLead {
+		return domain.MissionAssignment{}, serviceerrors.ErrInvalidRole
+	}
+
+	m, err := s.repo.GetMission(ctx, a.MissionID)
+	if err != nil {
+		return domain.MissionAssignment{}, err
+	}
+	if m.Status.IsTerminal() {
+		return domain.MissionAssignment{}, serviceerrors.ErrMissionAlreadyCompleted
+	}
+
+	return s.repo.AddTeamMember(ctx, a)
+}
+func (s *missionService) RemoveTeamMember(ctx context.Context, missionID, catID int64) error {
+	if missionID <= 0 {
+		return serviceerrors.ErrMissionNotFound
+	}
+
+	m, err := s.repo.GetMission(ctx, missionID)
+	if err != nil {
+		return err
+	}
+	if m.Status.IsTerminal() {
+		return serviceerrors.ErrMissionAlreadyCompleted
+	}
+	if m.CatID != nil && *m.CatID == catID {
+		return serviceerrors.ErrInvalidRole
+	}
+
+	return s.repo.RemoveTeamMember(ctx, missionID, catID)
+}
+func (s *missionService) Team(ctx context.Context, missionID int64) ([]domain.MissionAssignment, error) {
+	if missionID <= 0 {
+		return nil, serviceerrors.ErrMissionNotFound
+	}
+
+	if _, err := s.repo.GetMission(ctx, missionID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListTeam(ctx, missionID)
+}
+func (s *missionService) AssignGoal(ctx context.Context, missionID, goalID int64, catID *int64) (domain.MissionGoal, error) {
+	if missionID <= 0 {
+		return domain.MissionGoal{}, serviceerrors.ErrMissionNotFound
+	}
+	if goalID <= 0 {
+		return domain.MissionGoal{}, serviceerrors.ErrGoalNotFound
+	}
+
+	m, err := s.repo.GetMission(ctx, missionID)
+	if err != nil {
+		return domain.MissionGoal{}, err
+	}
+	if m.Status.IsTerminal() {
+		return domain.MissionGoal{}, serviceerrors.ErrMissionAlreadyCompleted
+	}
+
+	return s.repo.SetGoalAssignee(ctx, missionID, goalID, catID)
+}
+func (s *missionService) CatMissions(ctx context.Context, catID int64) ([]domain.CatMission, error) {
+	if catID <= 0 {
+		return nil, serviceerrors.ErrCatNotFound
+	}
+
+	return s.repo.ListCatMissions(ctx, catID)
+}
+
+func sameCat(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
 func (s *missionService) GetMission(ctx context.Context, id int64) (domain.Mission, []domain.MissionGoal, error) {
 	if id <= 0 {
 		return domain.Mission{}, nil, serviceerrors.ErrMissionNotFound
@@ -159,9 +451,29 @@ func (s *missionService) List(ctx context.Context, f domain.MissionFilter) ([]do
 	if f.Offset < 0 {
 		f.Offset = 0
 	}
+	if f.DueBefore != nil && f.DueAfter != nil && f.DueAfter.After(*f.DueBefore) {
+		return nil, 0, serviceerrors.ErrInvalidFilter
+	}
 
 	return s.repo.ListMissions(ctx, f)
 }
+func (s *missionService) Search(ctx context.Context, p domain.SearchParams) ([]domain.SearchHit, int, error) {
+	p.Q = strings.TrimSpace(p.Q)
+	if p.Q == "" {
+		return nil, 0, serviceerrors.ErrInvalidFilter
+	}
+	if p.Limit <= 0 || p.Limit > 100 {
+		p.Limit = 20
+	}
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+
+	return s.repo.SearchMissions(ctx, p)
+}
+func (s *missionService) Export(ctx context.Context, f domain.MissionFilter, fn func(domain.Mission) error) error {
+	return s.repo.StreamMissions(ctx, f, fn)
+}
 func (s *missionService) UpdateStatus(ctx context.Context, p domain.UpdateMissionStatusParams) (domain.Mission, error) {
 	if p.ID <= 0 {
 		return domain.Mission{}, serviceerrors.ErrMissionNotFound
@@ -176,7 +488,7 @@ func (s *missionService) UpdateStatus(ctx context.Context, p domain.UpdateMissio
 	}
 
 	newStatus := domain.MissionStatus(strings.TrimSpace(string(p.Status)))
-	if newStatus != domain.StatusPlanned && newStatus != domain.StatusActive && newStatus != domain.StatusCompleted {
+	if !newStatus.IsValid() {
 		return domain.Mission{}, serviceerrors.ErrInvalidStatus
 	}
 
@@ -188,10 +500,26 @@ func (s *missionService) UpdateStatus(ctx context.Context, p domain.UpdateMissio
 		return domain.Mission{}, serviceerrors.ErrInvalidTransition
 	}
 
+	reason := strings.TrimSpace(p.Reason)
+	if newStatus.RequiresReason() && reason == "" {
+		return domain.Mission{}, serviceerrors.ErrReasonRequired
+	}
+
+	if newStatus == domain.StatusActive {
+		g, err := s.repo.DependencyGraph(ctx, p.ID)
+		if err != nil {
+			return domain.Mission{}, err
+		}
+		if blocking := g.Blocking(); len(blocking) > 0 {
+			return domain.Mission{}, fmt.Errorf("%w: waiting for missions %v", serviceerrors.ErrMissionBlocked, blocking)
+		}
+	}
+
 	updated, ok, err := s.repo.UpdateStatusIfCurrent(ctx,
 		p.ID,
 		newStatus,
 		m.Status,
+		reason,
 	)
 	if err != nil {
 		return domain.Mission{}, err
@@ -202,6 +530,18 @@ func (s *missionService) UpdateStatus(ctx context.Context, p domain.UpdateMissio
 
 	return updated, nil
 }
+func (s *missionService) Transitions(ctx context.Context, id int64) (domain.Mission, []domain.MissionStatus, error) {
+	if id <= 0 {
+		return domain.Mission{}, nil, serviceerrors.ErrMissionNotFound
+	}
+
+	m, err := s.repo.GetMission(ctx, id)
+	if err != nil {
+		return domain.Mission{}, nil, err
+	}
+
+	return m, domain.AllowedTransitions(m.Status), nil
+}
 func (s *missionService) AddGoal(ctx context.Context, missionID int64, p domain.CreateGoalParams) (domain.MissionGoal, error) {
 	if missionID <= 0 {
 		return domain.MissionGoal{}, serviceerrors.ErrMissionNotFound
@@ -212,10 +552,13 @@ func (s *missionService) AddGoal(ctx context.Context, missionID int64, p domain.
 		return domain.MissionGoal{}, serviceerrors.ErrInvalidGoalName
 	}
 
-	country := strings.ToUpper(strings.TrimSpace(p.Country))
-	if len(country) != 2 {
+	country, ok := countries.Normalize(p.Country)
+	if !ok {
 		return domain.MissionGoal{}, serviceerrors.ErrInvalidCountry
 	}
+	if p.Location != nil && !p.Location.IsValid() {
+		return domain.MissionGoal{}, serviceerrors.ErrInvalidLocation
+	}
 
 	notes := strings.TrimSpace(p.Notes)
 
@@ -226,14 +569,15 @@ func (s *missionService) AddGoal(ctx context.Context, missionID int64, p domain.
 		}
 		return domain.MissionGoal{}, err
 	}
-	if m.Status == domain.StatusCompleted {
+	if m.Status.IsTerminal() {
 		return domain.MissionGoal{}, serviceerrors.ErrMissionCompleted
 	}
 
 	goal, err := s.repo.InsertGoal(ctx, missionID, domain.CreateGoalParams{
-		Name:    name,
-		Country: country,
-		Notes:   notes,
+		Name:     name,
+		Country:  country,
+		Notes:    notes,
+		Location: p.Location,
 	})
 	if err != nil {
 		if errors.Is(err, serviceerrors.ErrMissionNotFound) {
@@ -244,3 +588,83 @@ func (s *missionService) AddGoal(ctx context.Context, missionID int64, p domain.
 
 	return goal, nil
 }
+
+func (s *missionService) AddDependency(ctx context.Context, missionID, dependsOnID int64) (domain.MissionDependency, error) {
+	if missionID <= 0 {
+		return domain.MissionDependency{}, serviceerrors.ErrMissionNotFound
+	}
+	if dependsOnID <= 0 {
+		return domain.MissionDependency{}, serviceerrors.ErrDependencyNotFound
+	}
+	if missionID == dependsOnID {
+		return domain.MissionDependency{}, serviceerrors.ErrDependencyCycle
+	}
+	return s.repo.AddDependency(ctx, missionID, dependsOnID)
+}
+func (s *missionService) RemoveDependency(ctx context.Context, missionID, dependsOnID int64) error {
+	if missionID <= 0 || dependsOnID <= 0 {
+		return serviceerrors.ErrDependencyNotFound
+	}
+	return s.repo.RemoveDependency(ctx, missionID, dependsOnID)
+}
+func (s *missionService) Dependencies(ctx context.Context, missionID int64) (domain.DependencyGraph, error) {
+	if missionID <= 0 {
+		return domain.DependencyGraph{}, serviceerrors.ErrMissionNotFound
+	}
+	return s.repo.DependencyGraph(ctx, missionID)
+}
+
+// UpdateGoalStatus moves a goal along its lifecycle. Working on a goal needs an
+// active mission; goals can still be skipped or reopened while it is planned or paused.
+func (s *missionService) UpdateGoalStatus(ctx context.Context, p domain.UpdateGoalStatusParams) (domain.MissionGoal, error) {
+	if !p.Status.IsValid() {
+		return domain.MissionGoal{}, serviceerrors.ErrInvalidGoalStatus
+	}
+
+	m, goals, err := s.GetMission(ctx, p.MissionID)
+	if err != nil {
+		return domain.MissionGoal{}, err
+	}
+	if m.Status.IsTerminal() {
+		return domain.MissionGoal{}, serviceerrors.ErrMissionCompleted
+	}
+	if p.Status.NeedsActiveMission() && m.Status != domain.StatusActive {
+		return domain.MissionGoal{}, serviceerrors.ErrMissionNotActive
+	}
+
+	var goal *domain.MissionGoal
+	for i := range goals {
+		if goals[i].ID == p.GoalID {
+			goal = &goals[i]
+			break
+		}
+	}
+	if goal == nil {
+		return domain.MissionGoal{}, serviceerrors.ErrGoalNotFound
+	}
+	if goal.Status == p.Status {
+		return *goal, nil
+	}
+	if !domain.CanTransitionGoal(goal.Status, p.Status) {
+		return domain.MissionGoal{}, serviceerrors.ErrInvalidGoalTransition
+	}
+
+	updated, ok, err := s.repo.UpdateGoalStatusIfCurrent(ctx, p.MissionID, p.GoalID, p.Status, goal.Status)
+	if err != nil {
+		return domain.MissionGoal{}, err
+	}
+	if !ok {
+		return domain.MissionGoal{}, serviceerrors.ErrConflict
+	}
+	return updated, nil
+}
+
+func (s *missionService) ReorderGoals(ctx context.Context, missionID int64, goalIDs []int64) ([]domain.MissionGoal, error) {
+	if missionID <= 0 {
+		return nil, serviceerrors.ErrMissionNotFound
+	}
+	if len(goalIDs) == 0 {
+		return nil, serviceerrors.ErrInvalidGoalOrder
+	}
+	return s.repo.ReorderGoals(ctx, missionID, goalIDs)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type fakeTx struct{ committed bool }
+
+func (t *fakeTx) Commit(ctx context.Context) error   { t.committed = true; return nil }
+func (t *fakeTx) Rollback(ctx context.Context) error { return nil }
+
+// fakeRepo implements the methods the tests need; calling any other one
+// panics on the nil embedded interface.
+type fakeRepo struct {
+	MissionRepository
+	tx       *fakeTx
+	inserted []domain.Mission
+	goals    []domain.MissionGoal
+}
+
+func (r *fakeRepo) BeginTx(ctx context.Context) (Tx, error) {
+	r.tx = &fakeTx{}
+	return r.tx, nil
+}
+func (r *fakeRepo) InsertMissions(ctx context.Context, tx Tx, ms []domain.Mission) ([]int64, error) {
+	r.inserted = ms
+	ids := make([]int64, len(ms))
+	for i := range ms {
+		ids[i] = int64(100 + i)
+	}
+	return ids, nil
+}
+func (r *fakeRepo) InsertGoalRows(ctx context.Context, tx Tx, goals []domain.MissionGoal) error {
+	r.goals = goals
+	return nil
+}
+
+func TestCreateMissions(t *testing.T) {
+	t.Parallel()
+
+	valid := domain.CreateMissionParams{
+		Title: "Watch the docks",
+		Goals: []domain.CreateGoalParams{{Name: "pier 4", Country: "NL"}},
+	}
+
+	t.Run("creates every mission with its goals", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeRepo{}
+		svc := NewMissionService(repo)
+
+		ms, err := svc.CreateMissions(context.Background(), []domain.CreateMissionParams{valid, {Title: "Tail the courier"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(ms) != 2 || ms[0].ID != 100 || ms[1].ID != 101 {
+			t.Fatalf("unexpected missions: %+v", ms)
+		}
+		if len(repo.goals) != 1 || repo.goals[0].MissionID != 100 || repo.goals[0].Country != "NL" {
+			t.Fatalf("unexpected goals: %+v", repo.goals)
+		}
+		if !repo.tx.committed {
+			t.Fatal("transaction not committed")
+		}
+	})
+
+	t.Run("invalid items reject the whole batch", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeRepo{}
+		svc := NewMissionService(repo)
+
+		badPriority := 9
+		_, err := svc.CreateMissions(context.Background(), []domain.CreateMissionParams{
+			valid,
+			{Title: " "},
+			valid,
+			{Title: "Priority", Priority: &badPriority},
+		})
+
+		var itemErrs serviceerrors.ItemErrors
+		if !errors.As(err, &itemErrs) {
+			t.Fatalf("want ItemErrors, got %v", err)
+		}
+		if len(itemErrs) != 2 ||
+			!errors.Is(itemErrs[1], serviceerrors.ErrInvalidCreateMission) ||
+			!errors.Is(itemErrs[3], serviceerrors.ErrInvalidPriority) {
+			t.Fatalf("unexpected item errors: %v", itemErrs)
+		}
+		if repo.tx != nil || repo.inserted != nil {
+			t.Fatal("nothing may be written when an item is invalid")
+		}
+	})
+
+	t.Run("cap", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeRepo{}
+		svc := NewMissionService(repo)
+
+		ps := make([]domain.CreateMissionParams, MaxBulkMissions+1)
+		for i := range ps {
+			ps[i] = valid
+		}
+		if _, err := svc.CreateMissions(context.Background(), ps); !errors.Is(err, serviceerrors.ErrInvalidCreateMission) {
+			t.Fatalf("want ErrInvalidCreateMission above the cap, got %v", err)
+		}
+		if _, err := svc.CreateMissions(context.Background(), nil); !errors.Is(err, serviceerrors.ErrInvalidCreateMission) {
+			t.Fatalf("want ErrInvalidCreateMission for an empty batch, got %v", err)
+		}
+		if _, err := svc.CreateMissions(context.Background(), ps[:MaxBulkMissions]); err != nil {
+			t.Fatalf("the cap itself must be accepted, got %v", err)
+		}
+	})
+}
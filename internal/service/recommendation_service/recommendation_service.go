@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/rs/zerolog/log"
+)
+
+// Weights of the scoring factors; a perfect candidate scores 100.
+const (
+	weightExperience     = 25.0
+	weightBreedTraits    = 15.0
+	weightWorkload       = 20.0
+	weightCountrySuccess = 25.0
+	weightSalaryCost     = 15.0
+
+	// experienceCap is the number of years after which experience stops adding points.
+	experienceCap = 15
+)
+
+type RecommendationService interface {
+	Candidates(ctx context.Context, missionID int64, limit int) ([]domain.Candidate, error)
+}
+type Repository interface {
+	GetMission(ctx context.Context, id int64) (domain.Mission, error)
+	GetMissionGoals(ctx context.Context, missionID int64) ([]domain.MissionGoal, error)
+	ListCandidateStats(ctx context.Context, missionID int64, countries []string) ([]domain.CandidateStats, error)
+}
+type TraitsSource interface {
+	Traits(ctx context.Context, breed string) (domain.BreedTraits, error)
+}
+type RateSource interface {
+	RateTable(ctx context.Context) (domain.RateTable, error)
+}
+type recommendationService struct {
+	repo   Repository
+	traits TraitsSource
+	rates  RateSource
+}
+
+func NewRecommendationService(repo Repository, traits TraitsSource, rates RateSource) RecommendationService {
+	return &recommendationService{
+		repo:   repo,
+		traits: traits,
+		rates:  rates,
+	}
+}
+
+// Candidates ranks the cats that could join the mission, best first.
+func (s *recommendationService) Candidates(ctx context.Context, missionID int64, limit int) ([]domain.Candidate, error) {
+	if missionID <= 0 {
+		return nil, serviceerrors.ErrMissionNotFound
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	m, err := s.repo.GetMission(ctx, missionID)
+	if err != nil {
+		return nil, err
+	}
+	if m.Status.IsTerminal() {
+		return nil, serviceerrors.ErrMissionAlreadyCompleted
+	}
+
+	goals, err := s.repo.GetMissionGoals(ctx, missionID)
+	if err != nil {
+		return nil, err
+	}
+	countries := goalCountries(goals)
+
+	stats, err := s.repo.ListCandidateStats(ctx, missionID, countries)
+	if err != nil {
+		return nil, err
+	}
+	table, err := s.rates.RateTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// one lookup per breed; a failing lookup only costs the trait points
+	traits := make(map[string]domain.BreedTraits)
+	for _, st := range stats {
+		key := strings.ToLower(strings.TrimSpace(st.Cat.Breed))
+		if _, ok := traits[key]; ok {
+			continue
+		}
+		t, err := s.traits.Traits(ctx, st.Cat.Breed)
+		if err != nil {
+			log.Warn().Err(err).Str("breed", st.Cat.Breed).Msg("breed traits unavailable")
+		}
+		traits[key] = t
+	}
+
+	out := Score(stats, func(breed string) domain.BreedTraits {
+		return traits[strings.ToLower(strings.TrimSpace(breed))]
+	}, table)
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// Score computes the score breakdown of every candidate and sorts them best first.
+// Ties are broken by the lower cat id so the order is stable. Salaries are
+// compared in domain.DefaultCurrency; a cat whose salary cannot be converted
+// with table gets no salary points.
+func Score(stats []domain.CandidateStats, traitsOf func(breed string) domain.BreedTraits, table domain.RateTable) []domain.Candidate {
+	base := make([]*domain.Money, len(stats))
+	maxSalary := 0.0
+	for i, st := range stats {
+		if m, ok := table.Convert(st.Cat.Salary, domain.DefaultCurrency); ok {
+			base[i] = &m
+			maxSalary = math.Max(maxSalary, m.Float64())
+		}
+	}
+
+	out := make([]domain.Candidate, 0, len(stats))
+	for i, st := range stats {
+		c := domain.Candidate{
+			Cat:        st.Cat,
+			Busy:       st.Busy,
+			Planned:    st.Planned,
+			Traits:     traitsOf(st.Cat.Breed),
+			BaseSalary: base[i],
+		}
+
+		years := math.Min(float64(st.Cat.YearsExperience), experienceCap)
+		c.Breakdown.Experience = weightExperience * years / experienceCap
+
+		if c.Traits.Known {
+			avg := float64(c.Traits.Intelligence+c.Traits.Adaptability) / 2
+			c.Breakdown.BreedTraits = weightBreedTraits * clamp01(avg/5)
+		}
+
+		if !st.Busy {
+			c.Breakdown.Workload = weightWorkload
+		}
+
+		// Laplace smoothing keeps a cat with no history at a neutral 50%
+		rate := float64(st.CountryGoalsDone+1) / float64(st.CountryGoalsTotal+2)
+		if st.CountryGoalsTotal > 0 {
+			r := float64(st.CountryGoalsDone) / float64(st.CountryGoalsTotal)
+			c.SuccessRate = &r
+		}
+		c.Breakdown.CountrySuccess = weightCountrySuccess * rate
+
+		switch {
+		case c.BaseSalary == nil:
+		case maxSalary > 0:
+			c.Breakdown.SalaryCost = weightSalaryCost * (1 - c.BaseSalary.Float64()/maxSalary)
+		default:
+			c.Breakdown.SalaryCost = weightSalaryCost
+		}
+
+		b := c.Breakdown
+		c.Score = round2(b.Experience + b.BreedTraits + b.Workload + b.CountrySuccess + b.SalaryCost)
+		c.Breakdown = domain.ScoreBreakdown{
+			Experience:     round2(b.Experience),
+			BreedTraits:    round2(b.BreedTraits),
+			Workload:       round2(b.Workload),
+			CountrySuccess: round2(b.CountrySuccess),
+			SalaryCost:     round2(b.SalaryCost),
+		}
+		out = append(out, c)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Cat.ID < out[j].Cat.ID
+	})
+	return out
+}
+
+func goalCountries(goals []domain.MissionGoal) []string {
+	seen := make(map[string]struct{}, len(goals))
+	out := make([]string, 0, len(goals))
+	for _, g := range goals {
+		if _, ok := seen[g.Country]; ok {
+			continue
+		}
+		seen[g.Country] = struct{}{}
+		out = append(out, g.Country)
+	}
+	return out
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
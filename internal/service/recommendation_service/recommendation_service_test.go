@@ -0,0 +1,93 @@
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+func TestScore_RanksAndExplains(t *testing.T) {
+	t.Parallel()
+
+	stats := []domain.CandidateStats{
+		{
+			// veteran, free, good record, expensive
+			Cat:               domain.Cat{ID: 1, Breed: "Siamese", YearsExperience: 20, Salary: domain.NewMoney(5000, 0)},
+			CountryGoalsDone:  8,
+			CountryGoalsTotal: 8,
+		},
+		{
+			// rookie, busy, no history, cheap
+			Cat:  domain.Cat{ID: 2, Breed: "Unknown", YearsExperience: 0, Salary: domain.NewMoney(0, 0)},
+			Busy: true,
+		},
+	}
+	traits := func(breed string) domain.BreedTraits {
+		if breed == "Siamese" {
+			return domain.BreedTraits{Known: true, Intelligence: 5, Adaptability: 5}
+		}
+		return domain.BreedTraits{}
+	}
+
+	got := Score(stats, traits, nil)
+	if len(got) != 2 {
+		t.Fatalf("want 2 candidates, got %d", len(got))
+	}
+	if got[0].Cat.ID != 1 {
+		t.Fatalf("want cat 1 first, got %d", got[0].Cat.ID)
+	}
+
+	best := got[0].Breakdown
+	if best.Experience != weightExperience {
+		t.Fatalf("experience must be capped at %v, got %v", weightExperience, best.Experience)
+	}
+	if best.BreedTraits != weightBreedTraits {
+		t.Fatalf("breed traits=%v, want %v", best.BreedTraits, weightBreedTraits)
+	}
+	if best.SalaryCost != 0 {
+		t.Fatalf("most expensive cat must get no salary points, got %v", best.SalaryCost)
+	}
+	if got[0].SuccessRate == nil || *got[0].SuccessRate != 1 {
+		t.Fatalf("want success rate 1, got %v", got[0].SuccessRate)
+	}
+
+	rookie := got[1]
+	if rookie.Breakdown.Workload != 0 {
+		t.Fatalf("busy cat must get no workload points, got %v", rookie.Breakdown.Workload)
+	}
+	if rookie.SuccessRate != nil {
+		t.Fatalf("cat without history must have no success rate")
+	}
+	if rookie.Breakdown.CountrySuccess != weightCountrySuccess/2 {
+		t.Fatalf("cat without history must score neutral, got %v", rookie.Breakdown.CountrySuccess)
+	}
+}
+
+func TestScore_ComparesSalariesInOneCurrency(t *testing.T) {
+	t.Parallel()
+
+	stats := []domain.CandidateStats{
+		{Cat: domain.Cat{ID: 1, Salary: domain.Money{Amount: 300000, Currency: "USD"}}},
+		// 2000 EUR is 4000 USD, the most expensive cat despite the smaller amount
+		{Cat: domain.Cat{ID: 2, Salary: domain.Money{Amount: 200000, Currency: "EUR"}}},
+		{Cat: domain.Cat{ID: 3, Salary: domain.Money{Amount: 100, Currency: "JPY"}}},
+	}
+	table := domain.NewRateTable([]domain.ExchangeRate{{From: "EUR", To: "USD", Rate: big.NewRat(2, 1)}})
+
+	got := Score(stats, func(string) domain.BreedTraits { return domain.BreedTraits{} }, table)
+	byID := make(map[int64]domain.Candidate, len(got))
+	for _, c := range got {
+		byID[c.Cat.ID] = c
+	}
+
+	if c := byID[2]; c.Breakdown.SalaryCost != 0 || c.BaseSalary == nil || c.BaseSalary.Amount != 400000 {
+		t.Fatalf("EUR cat must be converted and be the most expensive, got %+v", c)
+	}
+	if c := byID[1]; c.Breakdown.SalaryCost != weightSalaryCost/4 {
+		t.Fatalf("USD cat salary points=%v, want %v", c.Breakdown.SalaryCost, weightSalaryCost/4)
+	}
+	if c := byID[3]; c.BaseSalary != nil || c.Breakdown.SalaryCost != 0 {
+		t.Fatalf("cat without a rate must get no salary points, got %+v", c)
+	}
+}
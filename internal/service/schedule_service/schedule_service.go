@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/cron"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// lockKey is the advisory lock that keeps a single instance materialising at a time.
+	lockKey int64 = 0x5c47_5c4e
+
+	defaultLeadTime = 24 * time.Hour
+	maxLeadTime     = 30 * 24 * time.Hour
+
+	// maxRunsPerSchedule bounds how many missions one schedule creates per pass.
+	maxRunsPerSchedule = 50
+	dueBatchSize       = 100
+)
+
+type ScheduleService interface {
+	CreateSchedule(ctx context.Context, p domain.CreateScheduleParams) (domain.MissionSchedule, error)
+	GetSchedule(ctx context.Context, id int64) (domain.MissionSchedule, error)
+	ListSchedules(ctx context.Context, limit, offset int) ([]domain.MissionSchedule, int, error)
+	SetEnabled(ctx context.Context, id int64, enabled bool) (domain.MissionSchedule, error)
+	DeleteSchedule(ctx context.Context, id int64) error
+	// RunDue creates the missions of every run that entered its lead time and
+	// returns them. Runs that already have a mission are not created again.
+	RunDue(ctx context.Context) ([]domain.Mission, error)
+}
+type Repository interface {
+	InsertSchedule(ctx context.Context, s *domain.MissionSchedule) error
+	GetSchedule(ctx context.Context, id int64) (domain.MissionSchedule, error)
+	ListSchedules(ctx context.Context, limit, offset int) ([]domain.MissionSchedule, int, error)
+	ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]domain.MissionSchedule, error)
+	AdvanceSchedule(ctx context.Context, id int64, expected, next time.Time) error
+	SetScheduleEnabled(ctx context.Context, id int64, enabled bool, next time.Time) (domain.MissionSchedule, error)
+	DeleteSchedule(ctx context.Context, id int64) error
+}
+type Locker interface {
+	TryLock(ctx context.Context, key int64) (unlock func(), ok bool, err error)
+}
+
+// Instantiator creates missions from templates.
+type Instantiator interface {
+	Instantiate(ctx context.Context, p domain.InstantiateTemplateParams) (domain.Mission, error)
+}
+
+// Cloner creates missions as copies of an existing mission.
+type Cloner interface {
+	CloneMission(ctx context.Context, p domain.CloneMissionParams) (domain.Mission, error)
+}
+type scheduleService struct {
+	repo      Repository
+	locker    Locker
+	templates Instantiator
+	missions  Cloner
+	now       func() time.Time
+}
+
+func NewScheduleService(repo Repository, locker Locker, templates Instantiator, missions Cloner) ScheduleService {
+	return &scheduleService{
+		repo:      repo,
+		locker:    locker,
+		templates: templates,
+		missions:  missions,
+		now:       time.Now,
+	}
+}
+
+func (s *scheduleService) CreateSchedule(ctx context.Context, p domain.CreateScheduleParams) (domain.MissionSchedule, error) {
+	if (p.TemplateID == nil) == (p.MissionID == nil) {
+		return domain.MissionSchedule{}, fmt.Errorf("%w: exactly one of template and mission is required", serviceerrors.ErrInvalidSchedule)
+	}
+	if p.LeadTime == 0 {
+		p.LeadTime = defaultLeadTime
+	}
+	if p.LeadTime < 0 || p.LeadTime > maxLeadTime {
+		return domain.MissionSchedule{}, fmt.Errorf("%w: lead time must be within 30 days", serviceerrors.ErrInvalidSchedule)
+	}
+	p.Timezone = strings.TrimSpace(p.Timezone)
+	if p.Timezone == "" {
+		p.Timezone = "UTC"
+	}
+
+	sc := domain.MissionSchedule{
+		TemplateID: p.TemplateID,
+		MissionID:  p.MissionID,
+		Cron:       strings.TrimSpace(p.Cron),
+		Timezone:   p.Timezone,
+		LeadTime:   p.LeadTime,
+		Vars:       p.Vars,
+		Enabled:    true,
+	}
+	if sc.Vars == nil {
+		sc.Vars = map[string]string{}
+	}
+
+	next, err := s.nextRun(sc, s.now())
+	if err != nil {
+		return domain.MissionSchedule{}, err
+	}
+	sc.NextRunAt = next
+
+	if err := s.repo.InsertSchedule(ctx, &sc); err != nil {
+		return domain.MissionSchedule{}, err
+	}
+	return sc, nil
+}
+
+func (s *scheduleService) GetSchedule(ctx context.Context, id int64) (domain.MissionSchedule, error) {
+	if id <= 0 {
+		return domain.MissionSchedule{}, serviceerrors.ErrScheduleNotFound
+	}
+	return s.repo.GetSchedule(ctx, id)
+}
+
+func (s *scheduleService) ListSchedules(ctx context.Context, limit, offset int) ([]domain.MissionSchedule, int, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.ListSchedules(ctx, limit, offset)
+}
+
+// SetEnabled pauses or resumes a schedule. Runs missed while it was disabled
+// are skipped: a resumed schedule continues from its next run after now.
+func (s *scheduleService) SetEnabled(ctx context.Context, id int64, enabled bool) (domain.MissionSchedule, error) {
+	sc, err := s.GetSchedule(ctx, id)
+	if err != nil {
+		return domain.MissionSchedule{}, err
+	}
+
+	next := sc.NextRunAt
+	if enabled && !sc.Enabled {
+		if next, err = s.nextRun(sc, s.now()); err != nil {
+			return domain.MissionSchedule{}, err
+		}
+	}
+	return s.repo.SetScheduleEnabled(ctx, id, enabled, next)
+}
+
+func (s *scheduleService) DeleteSchedule(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return serviceerrors.ErrScheduleNotFound
+	}
+	return s.repo.DeleteSchedule(ctx, id)
+}
+
+func (s *scheduleService) RunDue(ctx context.Context) ([]domain.Mission, error) {
+	unlock, ok, err := s.locker.TryLock(ctx, lockKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		log.Debug().Msg("recurring missions locked by another instance")
+		return nil, nil
+	}
+	defer unlock()
+
+	now := s.now()
+	due, err := s.repo.ListDueSchedules(ctx, now, dueBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []domain.Mission
+	for _, sc := range due {
+		if ctx.Err() != nil {
+			return created, ctx.Err()
+		}
+		ms, err := s.materialise(ctx, sc, now)
+		created = append(created, ms...)
+		if err != nil {
+			log.Error().Err(err).Int64("schedule_id", sc.ID).Msg("failed to materialise recurring mission")
+		}
+	}
+	return created, nil
+}
+
+// materialise creates the missions of every run of sc up to now plus the lead
+// time and moves the schedule past them. A run is advanced only once its
+// mission exists, so a failure is retried on the next pass. Runs that are
+// already in the past are skipped rather than created late.
+func (s *scheduleService) materialise(ctx context.Context, sc domain.MissionSchedule, now time.Time) ([]domain.Mission, error) {
+	var created []domain.Mission
+	horizon := now.Add(sc.LeadTime)
+	run := sc.NextRunAt
+
+	for i := 0; i < maxRunsPerSchedule && !run.After(horizon); i++ {
+		if !run.Before(now) {
+			m, err := s.create(ctx, sc, run)
+			switch {
+			case errors.Is(err, serviceerrors.ErrOccurrenceExists):
+				// created before a restart or by another instance
+			case err != nil:
+				return created, err
+			default:
+				created = append(created, m)
+			}
+		} else {
+			log.Warn().Int64("schedule_id", sc.ID).Time("run", run).Msg("skipping missed recurring run")
+		}
+
+		next, err := s.nextRun(sc, run)
+		if err != nil {
+			return created, err
+		}
+		if err := s.repo.AdvanceSchedule(ctx, sc.ID, run, next); err != nil {
+			return created, err
+		}
+		run = next
+	}
+	return created, nil
+}
+
+func (s *scheduleService) create(ctx context.Context, sc domain.MissionSchedule, run time.Time) (domain.Mission, error) {
+	occ := &domain.Occurrence{ScheduleID: sc.ID, At: run}
+	if sc.TemplateID != nil {
+		return s.templates.Instantiate(ctx, domain.InstantiateTemplateParams{
+			TemplateID: *sc.TemplateID,
+			Vars:       sc.Vars,
+			Occurrence: occ,
+		})
+	}
+	return s.missions.CloneMission(ctx, domain.CloneMissionParams{
+		ID:         *sc.MissionID,
+		Occurrence: occ,
+	})
+}
+
+// nextRun returns the first run of sc strictly after t, evaluated in the
+// schedule's time zone.
+func (s *scheduleService) nextRun(sc domain.MissionSchedule, t time.Time) (time.Time, error) {
+	expr, err := cron.Parse(sc.Cron)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", serviceerrors.ErrInvalidSchedule, err)
+	}
+	loc, err := time.LoadLocation(sc.Timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: unknown time zone %q", serviceerrors.ErrInvalidSchedule, sc.Timezone)
+	}
+
+	next := expr.Next(t.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: expression never runs", serviceerrors.ErrInvalidSchedule)
+	}
+	return next.UTC(), nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type fakeRepo struct {
+	Repository
+	sc       domain.MissionSchedule
+	advanced []time.Time
+}
+
+func (r *fakeRepo) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]domain.MissionSchedule, error) {
+	return []domain.MissionSchedule{r.sc}, nil
+}
+func (r *fakeRepo) AdvanceSchedule(ctx context.Context, id int64, expected, next time.Time) error {
+	r.advanced = append(r.advanced, next)
+	return nil
+}
+
+type fakeLocker struct{}
+
+func (fakeLocker) TryLock(ctx context.Context, key int64) (func(), bool, error) {
+	return func() {}, true, nil
+}
+
+// fakeTemplates reports the first run as already created, like after a restart.
+type fakeTemplates struct{ runs []time.Time }
+
+func (f *fakeTemplates) Instantiate(ctx context.Context, p domain.InstantiateTemplateParams) (domain.Mission, error) {
+	f.runs = append(f.runs, p.Occurrence.At)
+	if len(f.runs) == 1 {
+		return domain.Mission{}, serviceerrors.ErrOccurrenceExists
+	}
+	return domain.Mission{ID: int64(len(f.runs)), Occurrence: p.Occurrence}, nil
+}
+
+func TestRunDue_SkipsMissedAndDuplicateRuns(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.March, 4, 8, 30, 0, 0, time.UTC) // Monday
+	tmplID := int64(3)
+	repo := &fakeRepo{sc: domain.MissionSchedule{
+		ID:         1,
+		TemplateID: &tmplID,
+		Cron:       "0 9 * * *",
+		Timezone:   "UTC",
+		LeadTime:   48 * time.Hour,
+		Enabled:    true,
+		// yesterday's run was missed while the app was down
+		NextRunAt: time.Date(2024, time.March, 3, 9, 0, 0, 0, time.UTC),
+	}}
+	templates := &fakeTemplates{}
+
+	s := NewScheduleService(repo, fakeLocker{}, templates, nil).(*scheduleService)
+	s.now = func() time.Time { return now }
+
+	created, err := s.RunDue(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// runs on the 4th and 5th are inside the lead time, the 6th is not
+	wantRuns := []time.Time{
+		time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 5, 9, 0, 0, 0, time.UTC),
+	}
+	if len(templates.runs) != len(wantRuns) {
+		t.Fatalf("want %d instantiations, got %v", len(wantRuns), templates.runs)
+	}
+	for i, want := range wantRuns {
+		if !templates.runs[i].Equal(want) {
+			t.Fatalf("run %d = %v, want %v", i, templates.runs[i], want)
+		}
+	}
+	if len(created) != 1 || !created[0].Occurrence.At.Equal(wantRuns[1]) {
+		t.Fatalf("only the new run must be reported, got %+v", created)
+	}
+	if len(repo.advanced) != 3 || !repo.advanced[2].Equal(time.Date(2024, time.March, 6, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("schedule must advance past every handled run, got %v", repo.advanced)
+	}
+}
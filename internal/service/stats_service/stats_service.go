@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+const (
+	// DefaultWeeks is how many weeks of completions the dashboard shows.
+	DefaultWeeks = 12
+	// DefaultLeaderboardWindow is used when the caller gives no window.
+	DefaultLeaderboardWindow = 30 * 24 * time.Hour
+)
+
+type StatsService interface {
+	Stats(ctx context.Context) (domain.Stats, error)
+	CatPerformance(ctx context.Context, catID int64, window time.Duration) (domain.CatPerformance, error)
+	Leaderboard(ctx context.Context, window time.Duration, metric domain.LeaderboardMetric, limit int) ([]domain.LeaderboardEntry, error)
+}
+type Repository interface {
+	Stats(ctx context.Context, weeks int) (domain.Stats, error)
+	CatPerformance(ctx context.Context, catID int64, since *time.Time) (domain.CatPerformance, error)
+	Leaderboard(ctx context.Context, p domain.LeaderboardParams) ([]domain.LeaderboardEntry, error)
+}
+
+// statsService keeps the last snapshot for ttl. Concurrent requests after
+// expiry wait for a single refresh instead of each running the aggregates.
+type statsService struct {
+	repo  Repository
+	ttl   time.Duration
+	weeks int
+	now   func() time.Time
+
+	mu        sync.Mutex
+	cached    domain.Stats
+	expiresAt time.Time
+}
+
+func NewStatsService(repo Repository, ttl time.Duration, weeks int) StatsService {
+	if weeks <= 0 {
+		weeks = DefaultWeeks
+	}
+	return &statsService{
+		repo:  repo,
+		ttl:   ttl,
+		weeks: weeks,
+		now:   time.Now,
+	}
+}
+
+func (s *statsService) Stats(ctx context.Context) (domain.Stats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Before(s.expiresAt) {
+		return s.cached, nil
+	}
+
+	st, err := s.repo.Stats(ctx, s.weeks)
+	if err != nil {
+		return domain.Stats{}, err
+	}
+
+	s.cached = st
+	s.expiresAt = now.Add(s.ttl)
+	return st, nil
+}
+
+// CatPerformance covers the last window; zero means the whole history.
+func (s *statsService) CatPerformance(ctx context.Context, catID int64, window time.Duration) (domain.CatPerformance, error) {
+	if catID <= 0 {
+		return domain.CatPerformance{}, serviceerrors.ErrCatNotFound
+	}
+	if window < 0 {
+		return domain.CatPerformance{}, serviceerrors.ErrInvalidFilter
+	}
+
+	var since *time.Time
+	if window > 0 {
+		t := s.now().Add(-window)
+		since = &t
+	}
+	return s.repo.CatPerformance(ctx, catID, since)
+}
+
+func (s *statsService) Leaderboard(ctx context.Context, window time.Duration, metric domain.LeaderboardMetric, limit int) ([]domain.LeaderboardEntry, error) {
+	if window < 0 {
+		return nil, serviceerrors.ErrInvalidFilter
+	}
+	if window == 0 {
+		window = DefaultLeaderboardWindow
+	}
+	if metric == "" {
+		metric = domain.MetricMissions
+	}
+	if !metric.IsValid() {
+		return nil, serviceerrors.ErrInvalidFilter
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	return s.repo.Leaderboard(ctx, domain.LeaderboardParams{
+		Since:  s.now().Add(-window),
+		Metric: metric,
+		Limit:  limit,
+	})
+}
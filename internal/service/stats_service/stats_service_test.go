@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+)
+
+type fakeRepo struct {
+	calls int
+	err   error
+
+	since       *time.Time
+	leaderboard domain.LeaderboardParams
+}
+
+func (f *fakeRepo) Stats(_ context.Context, weeks int) (domain.Stats, error) {
+	f.calls++
+	if f.err != nil {
+		return domain.Stats{}, f.err
+	}
+	return domain.Stats{Cats: f.calls, CompletionsPerWeek: make([]domain.WeekCount, weeks)}, nil
+}
+
+func (f *fakeRepo) CatPerformance(_ context.Context, catID int64, since *time.Time) (domain.CatPerformance, error) {
+	f.since = since
+	return domain.CatPerformance{CatID: catID, Since: since}, nil
+}
+
+func (f *fakeRepo) Leaderboard(_ context.Context, p domain.LeaderboardParams) ([]domain.LeaderboardEntry, error) {
+	f.leaderboard = p
+	return nil, nil
+}
+
+func TestStatsCachesForTTL(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := NewStatsService(repo, 30*time.Second, 0).(*statsService)
+
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	first, err := svc.Stats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.CompletionsPerWeek) != DefaultWeeks {
+		t.Fatalf("weeks = %d, want %d", len(first.CompletionsPerWeek), DefaultWeeks)
+	}
+
+	now = now.Add(29 * time.Second)
+	if st, _ := svc.Stats(context.Background()); st.Cats != 1 || repo.calls != 1 {
+		t.Fatalf("cached snapshot not reused: cats=%d calls=%d", st.Cats, repo.calls)
+	}
+
+	now = now.Add(2 * time.Second)
+	if st, _ := svc.Stats(context.Background()); st.Cats != 2 {
+		t.Fatalf("expired snapshot not refreshed: cats=%d", st.Cats)
+	}
+
+	// a failed refresh is not cached
+	repo.err = errors.New("db down")
+	now = now.Add(time.Minute)
+	if _, err := svc.Stats(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	repo.err = nil
+	if st, err := svc.Stats(context.Background()); err != nil || st.Cats != 4 {
+		t.Fatalf("after failure got cats=%d err=%v", st.Cats, err)
+	}
+}
+
+func TestPerformanceWindows(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := NewStatsService(repo, time.Minute, 0).(*statsService)
+
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := svc.CatPerformance(ctx, 7, 0); err != nil || repo.since != nil {
+		t.Fatalf("zero window must cover all history, since=%v err=%v", repo.since, err)
+	}
+	if _, err := svc.CatPerformance(ctx, 7, 7*24*time.Hour); err != nil || !repo.since.Equal(now.AddDate(0, 0, -7)) {
+		t.Fatalf("since=%v err=%v", repo.since, err)
+	}
+
+	if _, err := svc.Leaderboard(ctx, 0, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	want := domain.LeaderboardParams{Since: now.Add(-DefaultLeaderboardWindow), Metric: domain.MetricMissions, Limit: 10}
+	if repo.leaderboard != want {
+		t.Fatalf("leaderboard params = %+v, want %+v", repo.leaderboard, want)
+	}
+
+	if _, err := svc.Leaderboard(ctx, time.Hour, "salary", 5); err == nil {
+		t.Fatal("unknown metric accepted")
+	}
+}
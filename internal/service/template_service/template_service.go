@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	"github.com/DavydAbbasov/spy-cat/internal/lib/countries"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+// MaxTemplateGoals caps the number of goals a template may hold.
+const MaxTemplateGoals = 100
+
+type TemplateService interface {
+	CreateTemplate(ctx context.Context, p domain.SaveTemplateParams) (domain.MissionTemplate, error)
+	UpdateTemplate(ctx context.Context, id int64, p domain.SaveTemplateParams) (domain.MissionTemplate, error)
+	GetTemplate(ctx context.Context, id int64) (domain.MissionTemplate, error)
+	ListTemplates(ctx context.Context, limit, offset int) ([]domain.MissionTemplate, int, error)
+	DeleteTemplate(ctx context.Context, id int64) error
+	Instantiate(ctx context.Context, p domain.InstantiateTemplateParams) (domain.Mission, error)
+}
+type TemplateRepository interface {
+	CreateTemplate(ctx context.Context, t *domain.MissionTemplate) error
+	UpdateTemplate(ctx context.Context, t *domain.MissionTemplate) error
+	GetTemplate(ctx context.Context, id int64) (domain.MissionTemplate, error)
+	ListTemplates(ctx context.Context, limit, offset int) ([]domain.MissionTemplate, int, error)
+	DeleteTemplate(ctx context.Context, id int64) error
+}
+
+// MissionCreator creates the missions instantiated from templates.
+type MissionCreator interface {
+	CreateMission(ctx context.Context, p domain.CreateMissionParams) (domain.Mission, error)
+}
+type templateService struct {
+	repo     TemplateRepository
+	missions MissionCreator
+}
+
+func NewTemplateService(repo TemplateRepository, missions MissionCreator) TemplateService {
+	return &templateService{
+		repo:     repo,
+		missions: missions,
+	}
+}
+
+func (s *templateService) CreateTemplate(ctx context.Context, p domain.SaveTemplateParams) (domain.MissionTemplate, error) {
+	t, err := buildTemplate(p)
+	if err != nil {
+		return domain.MissionTemplate{}, err
+	}
+	if err := s.repo.CreateTemplate(ctx, &t); err != nil {
+		return domain.MissionTemplate{}, err
+	}
+	return t, nil
+}
+
+func (s *templateService) UpdateTemplate(ctx context.Context, id int64, p domain.SaveTemplateParams) (domain.MissionTemplate, error) {
+	if id <= 0 {
+		return domain.MissionTemplate{}, serviceerrors.ErrTemplateNotFound
+	}
+	t, err := buildTemplate(p)
+	if err != nil {
+		return domain.MissionTemplate{}, err
+	}
+	t.ID = id
+	if err := s.repo.UpdateTemplate(ctx, &t); err != nil {
+		return domain.MissionTemplate{}, err
+	}
+	return t, nil
+}
+
+func (s *templateService) GetTemplate(ctx context.Context, id int64) (domain.MissionTemplate, error) {
+	if id <= 0 {
+		return domain.MissionTemplate{}, serviceerrors.ErrTemplateNotFound
+	}
+	return s.repo.GetTemplate(ctx, id)
+}
+
+func (s *templateService) ListTemplates(ctx context.Context, limit, offset int) ([]domain.MissionTemplate, int, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.ListTemplates(ctx, limit, offset)
+}
+
+func (s *templateService) DeleteTemplate(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return serviceerrors.ErrTemplateNotFound
+	}
+	return s.repo.DeleteTemplate(ctx, id)
+}
+
+// Instantiate fills the template placeholders and creates a planned mission
+// through the mission service, so it gets the same validation as any other.
+func (s *templateService) Instantiate(ctx context.Context, p domain.InstantiateTemplateParams) (domain.Mission, error) {
+	t, err := s.GetTemplate(ctx, p.TemplateID)
+	if err != nil {
+		return domain.Mission{}, err
+	}
+
+	missing := make(map[string]struct{})
+	fill := func(s string) string {
+		out, miss := domain.FillPlaceholders(s, p.Vars)
+		for _, name := range miss {
+			missing[name] = struct{}{}
+		}
+		return out
+	}
+
+	title := t.Title
+	if p.Title != nil {
+		title = *p.Title
+	}
+	priority := t.Priority
+	if p.Priority != nil {
+		priority = *p.Priority
+	}
+
+	params := domain.CreateMissionParams{
+		Title:       fill(title),
+		Description: fill(t.Description),
+		Status:      domain.StatusPlanned,
+		DueAt:       p.DueAt,
+		Priority:    &priority,
+		Goals:       make([]domain.CreateGoalParams, 0, len(t.Goals)),
+		Occurrence:  p.Occurrence,
+	}
+	for _, g := range t.Goals {
+		params.Goals = append(params.Goals, domain.CreateGoalParams{
+			Name:    fill(g.Name),
+			Country: fill(g.Country),
+			Notes:   fill(g.Notes),
+		})
+	}
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return domain.Mission{}, fmt.Errorf("%w: %s", serviceerrors.ErrTemplateVarMissing, strings.Join(names, ", "))
+	}
+
+	return s.missions.CreateMission(ctx, params)
+}
+
+// buildTemplate normalizes and validates a template. Goal countries may be a
+// placeholder that is only resolved when the template is instantiated.
+func buildTemplate(p domain.SaveTemplateParams) (domain.MissionTemplate, error) {
+	t := domain.MissionTemplate{
+		Name:        strings.TrimSpace(p.Name),
+		Title:       strings.TrimSpace(p.Title),
+		Description: strings.TrimSpace(p.Description),
+		Priority:    domain.PriorityDefault,
+	}
+	if t.Name == "" || t.Title == "" {
+		return domain.MissionTemplate{}, serviceerrors.ErrInvalidTemplate
+	}
+	if p.Priority != nil {
+		t.Priority = *p.Priority
+	}
+	if t.Priority < domain.PriorityHighest || t.Priority > domain.PriorityLowest {
+		return domain.MissionTemplate{}, serviceerrors.ErrInvalidPriority
+	}
+	if len(p.Goals) > MaxTemplateGoals {
+		return domain.MissionTemplate{}, serviceerrors.ErrInvalidTemplate
+	}
+
+	t.Goals = make([]domain.TemplateGoal, 0, len(p.Goals))
+	for _, g := range p.Goals {
+		name := strings.TrimSpace(g.Name)
+		country := strings.TrimSpace(g.Country)
+		if name == "" {
+			return domain.MissionTemplate{}, serviceerrors.ErrInvalidGoalName
+		}
+		if !domain.HasPlaceholder(country) {
+			code, ok := countries.Normalize(country)
+			if !ok {
+				return domain.MissionTemplate{}, serviceerrors.ErrInvalidCountry
+			}
+			country = code
+		}
+		t.Goals = append(t.Goals, domain.TemplateGoal{
+			Name:    name,
+			Country: country,
+			Notes:   strings.TrimSpace(g.Notes),
+		})
+	}
+	return t, nil
+}
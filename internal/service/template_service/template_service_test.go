@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DavydAbbasov/spy-cat/internal/domain"
+	serviceerrors "github.com/DavydAbbasov/spy-cat/internal/servies_errors"
+)
+
+type fakeRepo struct {
+	TemplateRepository
+	t domain.MissionTemplate
+}
+
+func (r fakeRepo) GetTemplate(ctx context.Context, id int64) (domain.MissionTemplate, error) {
+	return r.t, nil
+}
+
+type fakeCreator struct{ got *domain.CreateMissionParams }
+
+func (c fakeCreator) CreateMission(ctx context.Context, p domain.CreateMissionParams) (domain.Mission, error) {
+	*c.got = p
+	return domain.Mission{ID: 7}, nil
+}
+
+func TestInstantiate_FillsPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	tmpl := domain.MissionTemplate{
+		ID:       1,
+		Title:    "Watch {{target}}",
+		Priority: 2,
+		Goals: []domain.TemplateGoal{
+			{Name: "Follow {{ target }}", Country: "{{country}}"},
+		},
+	}
+	if got := tmpl.Placeholders(); len(got) != 2 || got[0] != "country" || got[1] != "target" {
+		t.Fatalf("placeholders=%v", got)
+	}
+
+	var got domain.CreateMissionParams
+	s := NewTemplateService(fakeRepo{t: tmpl}, fakeCreator{got: &got})
+
+	_, err := s.Instantiate(context.Background(), domain.InstantiateTemplateParams{
+		TemplateID: 1,
+		Vars:       map[string]string{"target": "Dr. Whiskers"},
+	})
+	if !errors.Is(err, serviceerrors.ErrTemplateVarMissing) {
+		t.Fatalf("want ErrTemplateVarMissing, got %v", err)
+	}
+
+	m, err := s.Instantiate(context.Background(), domain.InstantiateTemplateParams{
+		TemplateID: 1,
+		Vars:       map[string]string{"target": "Dr. Whiskers", "country": "FR"},
+	})
+	if err != nil || m.ID != 7 {
+		t.Fatalf("unexpected result %v %v", m, err)
+	}
+	if got.Title != "Watch Dr. Whiskers" || got.Goals[0].Name != "Follow Dr. Whiskers" || got.Goals[0].Country != "FR" {
+		t.Fatalf("placeholders not filled: %+v", got)
+	}
+	if got.Priority == nil || *got.Priority != 2 {
+		t.Fatalf("template priority must be kept, got %v", got.Priority)
+	}
+}
@@ -0,0 +1,29 @@
+package servieserrors
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ErrInvalidBatch = errors.New("batch contains invalid items")
+
+// ItemErrors holds validation errors of a batch keyed by item index.
+type ItemErrors map[int]error
+
+func (e ItemErrors) Error() string {
+	idx := make([]int, 0, len(e))
+	for i := range e {
+		idx = append(idx, i)
+	}
+	sort.Ints(idx)
+
+	parts := make([]string, 0, len(idx))
+	for _, i := range idx {
+		parts = append(parts, fmt.Sprintf("item %d: %v", i, e[i]))
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidBatch, strings.Join(parts, "; "))
+}
+
+func (e ItemErrors) Unwrap() error { return ErrInvalidBatch }
@@ -6,6 +6,26 @@ var (
 	ErrCatNotFound     = errors.New("cat not found")
 	ErrBreedInvalid    = errors.New("breed invalid")
 	ErrInvalidSalary   = errors.New("salary invalid")
+	ErrInvalidName     = errors.New("name invalid")
 	ErrExternalService = errors.New("external service")
-)
 
+	ErrCatHasActiveMission = errors.New("cat has an active mission")
+	ErrCatNotDeleted       = errors.New("cat is not deleted")
+
+	ErrInvalidEffectiveDate = errors.New("effective date must not be in the future or before the latest salary change")
+	ErrInvalidMonth         = errors.New("month is invalid")
+
+	ErrInvalidCurrency      = errors.New("currency must be a three letter ISO 4217 code")
+	ErrInvalidExchangeRate  = errors.New("exchange rate is invalid")
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
+	ErrSalaryOutOfBand        = errors.New("salary is outside the salary band")
+	ErrOverrideReasonRequired = errors.New("a reason is required to override the salary band")
+	ErrSalaryBandNotFound     = errors.New("salary band not found")
+	ErrSalaryBandOverlap      = errors.New("salary band overlaps an existing band")
+	ErrInvalidSalaryBand      = errors.New("salary band is invalid")
+	ErrBonusRuleNotFound      = errors.New("bonus rule not found")
+	ErrInvalidBonusRule       = errors.New("bonus rule is invalid")
+	ErrInvalidRiskLevel       = errors.New("risk level must be between 1 and 5")
+	ErrCountryRiskNotFound    = errors.New("country risk level not found")
+)
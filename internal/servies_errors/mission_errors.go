@@ -12,17 +12,33 @@ var (
 	ErrMissionNotActive        = errors.New("mission is not in active status")
 	ErrMissionHasAssignee      = errors.New("mission is assigned to a cat")
 	ErrMissionAlreadyExists    = errors.New("mission with same title already exists")
+	ErrHandoverNoteRequired    = errors.New("handover note is required")
+	ErrAlreadyTeamMember       = errors.New("cat is already on the mission team")
+	ErrNotTeamMember           = errors.New("cat is not on the mission team")
+	ErrInvalidRole             = errors.New("team role is invalid")
+	ErrOccurrenceExists        = errors.New("mission for this schedule run already exists")
+	ErrMissionBlocked          = errors.New("mission has unfinished dependencies")
+	ErrDependencyCycle         = errors.New("dependency would create a cycle")
+	ErrDependencyExists        = errors.New("dependency already exists")
+	ErrDependencyNotFound      = errors.New("dependency not found")
 )
 var (
-	ErrGoalAlreadyDone     = errors.New("goal already done")
-	ErrGoalDeleteForbidden = errors.New("cannot delete a completed goal")
+	ErrGoalAlreadyDone       = errors.New("goal already done")
+	ErrGoalDeleteForbidden   = errors.New("cannot delete a completed goal")
+	ErrInvalidGoalStatus     = errors.New("goal status is invalid")
+	ErrInvalidGoalTransition = errors.New("goal status transition is not allowed")
+	ErrInvalidGoalOrder      = errors.New("goal order must list every goal of the mission once")
 )
 var (
 	ErrInvalidCreateMission = errors.New("create mission invalid")
 	ErrInvalidGoalName      = errors.New("goal name is invalid")
 	ErrInvalidCountry       = errors.New("counrty is invalid")
+	ErrInvalidLocation      = errors.New("goal location is invalid")
 	ErrInvalidStatus        = errors.New("invalid status")
 	ErrInvalidTransition    = errors.New("invalid transition")
+	ErrReasonRequired       = errors.New("status reason is required")
+	ErrInvalidPriority      = errors.New("priority is invalid")
+	ErrInvalidFilter        = errors.New("filter is invalid")
 	ErrConflict             = errors.New("status conflict")
 	ErrMissionCompleted     = errors.New("failed -'course status mission complete")
 )
@@ -0,0 +1,8 @@
+package servieserrors
+
+import "errors"
+
+var (
+	ErrScheduleNotFound = errors.New("mission schedule not found")
+	ErrInvalidSchedule  = errors.New("mission schedule is invalid")
+)
@@ -0,0 +1,10 @@
+package servieserrors
+
+import "errors"
+
+var (
+	ErrTemplateNotFound   = errors.New("mission template not found")
+	ErrInvalidTemplate    = errors.New("mission template is invalid")
+	ErrTemplateNameTaken  = errors.New("mission template with same name already exists")
+	ErrTemplateVarMissing = errors.New("template placeholder has no value")
+)
@@ -0,0 +1,8 @@
+ALTER TABLE missions DROP CONSTRAINT IF EXISTS fk_missions_cat;
+ALTER TABLE missions
+  ADD CONSTRAINT fk_missions_cat
+    FOREIGN KEY (cat_id) REFERENCES cats(id) ON DELETE SET NULL;
+
+DROP INDEX IF EXISTS idx_cats_deleted_at;
+ALTER TABLE cats DROP COLUMN IF EXISTS purged_at;
+ALTER TABLE cats DROP COLUMN IF EXISTS deleted_at;
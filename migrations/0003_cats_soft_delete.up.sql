@@ -0,0 +1,12 @@
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ NULL;
+
+-- set when the purge job anonymises a deleted cat; such a cat can't be restored
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS purged_at TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS idx_cats_deleted_at ON cats(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- cats are soft deleted now; a hard delete must never orphan mission history
+ALTER TABLE missions DROP CONSTRAINT IF EXISTS fk_missions_cat;
+ALTER TABLE missions
+  ADD CONSTRAINT fk_missions_cat
+    FOREIGN KEY (cat_id) REFERENCES cats(id) ON DELETE RESTRICT;
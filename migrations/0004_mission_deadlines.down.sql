@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_missions_priority;
+DROP INDEX IF EXISTS idx_missions_due_at;
+
+ALTER TABLE missions DROP CONSTRAINT IF EXISTS chk_mission_priority;
+
+ALTER TABLE missions
+  DROP COLUMN IF EXISTS completed_at,
+  DROP COLUMN IF EXISTS started_at,
+  DROP COLUMN IF EXISTS priority,
+  DROP COLUMN IF EXISTS due_at;
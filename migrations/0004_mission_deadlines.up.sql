@@ -0,0 +1,11 @@
+ALTER TABLE missions
+  ADD COLUMN IF NOT EXISTS due_at       TIMESTAMPTZ NULL,
+  ADD COLUMN IF NOT EXISTS priority     SMALLINT    NOT NULL DEFAULT 3,
+  ADD COLUMN IF NOT EXISTS started_at   TIMESTAMPTZ NULL,
+  ADD COLUMN IF NOT EXISTS completed_at TIMESTAMPTZ NULL;
+
+ALTER TABLE missions
+  ADD CONSTRAINT chk_mission_priority CHECK (priority BETWEEN 1 AND 5);
+
+CREATE INDEX IF NOT EXISTS idx_missions_due_at   ON missions(due_at) WHERE due_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_missions_priority ON missions(priority);
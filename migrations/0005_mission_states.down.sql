@@ -0,0 +1,10 @@
+ALTER TABLE missions DROP COLUMN IF EXISTS status_reason;
+
+-- missions in the new states have no equivalent in the old set
+UPDATE missions SET status = 'active'    WHERE status = 'paused';
+UPDATE missions SET status = 'completed' WHERE status IN ('aborted','failed');
+
+ALTER TABLE missions DROP CONSTRAINT IF EXISTS chk_mission_status;
+ALTER TABLE missions
+  ADD CONSTRAINT chk_mission_status
+    CHECK (status IN ('planned','active','completed'));
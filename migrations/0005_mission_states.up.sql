@@ -0,0 +1,6 @@
+ALTER TABLE missions DROP CONSTRAINT IF EXISTS chk_mission_status;
+ALTER TABLE missions
+  ADD CONSTRAINT chk_mission_status
+    CHECK (status IN ('planned','active','paused','completed','aborted','failed'));
+
+ALTER TABLE missions ADD COLUMN IF NOT EXISTS status_reason TEXT NOT NULL DEFAULT '';
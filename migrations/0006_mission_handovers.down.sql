@@ -0,0 +1,2 @@
+ALTER TABLE mission_goals DROP COLUMN IF EXISTS done_by_cat_id;
+DROP TABLE IF EXISTS mission_handovers;
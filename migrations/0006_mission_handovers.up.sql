@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS mission_handovers (
+  id          BIGSERIAL PRIMARY KEY,
+  mission_id  BIGINT      NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+  from_cat_id BIGINT      NULL REFERENCES cats(id) ON DELETE RESTRICT,
+  to_cat_id   BIGINT      NULL REFERENCES cats(id) ON DELETE RESTRICT,
+  note        TEXT        NOT NULL,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_mission_handovers_mid ON mission_handovers(mission_id, created_at);
+
+ALTER TABLE mission_goals
+  ADD COLUMN IF NOT EXISTS done_by_cat_id BIGINT NULL REFERENCES cats(id) ON DELETE RESTRICT;
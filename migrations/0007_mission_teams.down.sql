@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_mission_goals_assignee;
+ALTER TABLE mission_goals DROP COLUMN IF EXISTS assignee_cat_id;
+
+DROP TABLE IF EXISTS mission_assignments;
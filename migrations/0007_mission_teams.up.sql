@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS mission_assignments (
+  mission_id  BIGINT      NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+  cat_id      BIGINT      NOT NULL REFERENCES cats(id) ON DELETE RESTRICT,
+  role        TEXT        NOT NULL,
+  assigned_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (mission_id, cat_id),
+  CONSTRAINT chk_assignment_role CHECK (role IN ('lead','support','lookout'))
+);
+-- missions.cat_id stays the lead for old clients, so there is at most one
+CREATE UNIQUE INDEX IF NOT EXISTS uq_mission_assignments_lead ON mission_assignments(mission_id) WHERE role = 'lead';
+CREATE INDEX IF NOT EXISTS idx_mission_assignments_cat ON mission_assignments(cat_id);
+
+INSERT INTO mission_assignments (mission_id, cat_id, role)
+SELECT id, cat_id, 'lead'
+FROM missions
+WHERE cat_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE mission_goals
+  ADD COLUMN IF NOT EXISTS assignee_cat_id BIGINT NULL REFERENCES cats(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_mission_goals_assignee ON mission_goals(assignee_cat_id) WHERE assignee_cat_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_missions_unassigned_planned;
+DROP TABLE IF EXISTS auto_assignments;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS auto_assignments (
+  id          BIGSERIAL PRIMARY KEY,
+  mission_id  BIGINT           NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+  cat_id      BIGINT           NULL REFERENCES cats(id) ON DELETE RESTRICT,
+  policy      TEXT             NOT NULL,
+  score       DOUBLE PRECISION NULL,
+  outcome     TEXT             NOT NULL,
+  detail      TEXT             NOT NULL DEFAULT '',
+  created_at  TIMESTAMPTZ      NOT NULL DEFAULT now(),
+
+  CONSTRAINT chk_auto_assignment_outcome CHECK (outcome IN ('assigned','skipped','failed'))
+);
+CREATE INDEX IF NOT EXISTS idx_auto_assignments_mission ON auto_assignments(mission_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_missions_unassigned_planned ON missions(created_at) WHERE status = 'planned' AND cat_id IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mission_template_goals;
+DROP TABLE IF EXISTS mission_templates;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS mission_templates (
+  id          BIGSERIAL PRIMARY KEY,
+  name        TEXT        NOT NULL,
+  title       TEXT        NOT NULL,
+  description TEXT        NOT NULL DEFAULT '',
+  priority    SMALLINT    NOT NULL DEFAULT 3,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT uq_mission_templates_name UNIQUE (name),
+  CONSTRAINT chk_mission_templates_priority CHECK (priority BETWEEN 1 AND 5)
+);
+CREATE TABLE IF NOT EXISTS mission_template_goals (
+  id          BIGSERIAL PRIMARY KEY,
+  template_id BIGINT NOT NULL REFERENCES mission_templates(id) ON DELETE CASCADE,
+  position    INT    NOT NULL,
+  name        TEXT   NOT NULL,
+  country     TEXT   NOT NULL,
+  notes       TEXT   NOT NULL DEFAULT '',
+
+  CONSTRAINT uq_mission_template_goals_position UNIQUE (template_id, position)
+);
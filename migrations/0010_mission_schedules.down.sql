@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS uq_missions_occurrence;
+ALTER TABLE missions
+  DROP COLUMN IF EXISTS scheduled_for,
+  DROP COLUMN IF EXISTS schedule_id;
+DROP TABLE IF EXISTS mission_schedules;
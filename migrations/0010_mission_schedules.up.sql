@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS mission_schedules (
+  id           BIGSERIAL PRIMARY KEY,
+  template_id  BIGINT      NULL REFERENCES mission_templates(id) ON DELETE CASCADE,
+  mission_id   BIGINT      NULL REFERENCES missions(id) ON DELETE CASCADE,
+  cron         TEXT        NOT NULL,
+  timezone     TEXT        NOT NULL DEFAULT 'UTC',
+  lead_time    INTERVAL    NOT NULL DEFAULT '24 hours',
+  vars         JSONB       NOT NULL DEFAULT '{}',
+  enabled      BOOLEAN     NOT NULL DEFAULT true,
+  next_run_at  TIMESTAMPTZ NOT NULL,
+  last_run_at  TIMESTAMPTZ NULL,
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT chk_mission_schedules_source CHECK ((template_id IS NULL) <> (mission_id IS NULL))
+);
+CREATE INDEX IF NOT EXISTS idx_mission_schedules_due ON mission_schedules(next_run_at) WHERE enabled;
+
+ALTER TABLE missions
+  ADD COLUMN IF NOT EXISTS schedule_id   BIGINT      NULL REFERENCES mission_schedules(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS scheduled_for TIMESTAMPTZ NULL;
+
+-- one mission per schedule run, whatever instance or retry creates it
+CREATE UNIQUE INDEX IF NOT EXISTS uq_missions_occurrence
+  ON missions(schedule_id, scheduled_for)
+  WHERE schedule_id IS NOT NULL;
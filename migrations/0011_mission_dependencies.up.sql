@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS mission_dependencies (
+  mission_id     BIGINT      NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+  depends_on_id  BIGINT      NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+  created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (mission_id, depends_on_id),
+  CONSTRAINT chk_mission_dependencies_self CHECK (mission_id <> depends_on_id)
+);
+CREATE INDEX IF NOT EXISTS idx_mission_dependencies_depends_on ON mission_dependencies(depends_on_id);
@@ -0,0 +1,11 @@
+UPDATE mission_goals SET status = 'todo' WHERE status IN ('in_progress','failed','skipped');
+
+ALTER TABLE mission_goals DROP CONSTRAINT IF EXISTS chk_goal_status;
+ALTER TABLE mission_goals
+  ADD CONSTRAINT chk_goal_status CHECK (status IN ('todo','done'));
+
+ALTER TABLE mission_goals DROP CONSTRAINT IF EXISTS uq_mission_goals_position;
+ALTER TABLE mission_goals
+  DROP COLUMN IF EXISTS finished_at,
+  DROP COLUMN IF EXISTS started_at,
+  DROP COLUMN IF EXISTS position;
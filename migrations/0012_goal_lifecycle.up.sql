@@ -0,0 +1,24 @@
+ALTER TABLE mission_goals
+  ADD COLUMN IF NOT EXISTS position    INT         NULL,
+  ADD COLUMN IF NOT EXISTS started_at  TIMESTAMPTZ NULL,
+  ADD COLUMN IF NOT EXISTS finished_at TIMESTAMPTZ NULL;
+
+UPDATE mission_goals g
+SET position = o.pos
+FROM (
+  SELECT id, row_number() OVER (PARTITION BY mission_id ORDER BY id) AS pos
+  FROM mission_goals
+) o
+WHERE g.id = o.id;
+
+UPDATE mission_goals SET finished_at = updated_at WHERE status = 'done';
+
+ALTER TABLE mission_goals ALTER COLUMN position SET NOT NULL;
+
+-- deferred so a reorder can swap positions inside one transaction
+ALTER TABLE mission_goals
+  ADD CONSTRAINT uq_mission_goals_position UNIQUE (mission_id, position) DEFERRABLE INITIALLY DEFERRED;
+
+ALTER TABLE mission_goals DROP CONSTRAINT IF EXISTS chk_goal_status;
+ALTER TABLE mission_goals
+  ADD CONSTRAINT chk_goal_status CHECK (status IN ('todo','in_progress','done','failed','skipped'));
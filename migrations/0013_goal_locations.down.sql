@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_mission_goals_location;
+
+ALTER TABLE mission_goals DROP CONSTRAINT IF EXISTS chk_goal_location;
+
+ALTER TABLE mission_goals
+  DROP COLUMN IF EXISTS radius_m,
+  DROP COLUMN IF EXISTS longitude,
+  DROP COLUMN IF EXISTS latitude;
@@ -0,0 +1,16 @@
+ALTER TABLE mission_goals
+  ADD COLUMN IF NOT EXISTS latitude  DOUBLE PRECISION NULL,
+  ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION NULL,
+  ADD COLUMN IF NOT EXISTS radius_m  INT              NULL;
+
+ALTER TABLE mission_goals
+  ADD CONSTRAINT chk_goal_location CHECK (
+    (latitude IS NULL AND longitude IS NULL AND radius_m IS NULL)
+    OR (latitude  BETWEEN -90  AND 90
+    AND longitude BETWEEN -180 AND 180
+    AND (radius_m IS NULL OR radius_m BETWEEN 1 AND 1000000))
+  );
+
+CREATE INDEX IF NOT EXISTS idx_mission_goals_location
+  ON mission_goals (latitude, longitude)
+  WHERE latitude IS NOT NULL;
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_missions_search_vector;
+
+DROP TRIGGER IF EXISTS trg_mission_goals_search_delete ON mission_goals;
+DROP TRIGGER IF EXISTS trg_mission_goals_search_update ON mission_goals;
+DROP TRIGGER IF EXISTS trg_mission_goals_search_insert ON mission_goals;
+DROP TRIGGER IF EXISTS trg_missions_search_vector ON missions;
+
+DROP FUNCTION IF EXISTS mission_goals_search_vector_trg();
+DROP FUNCTION IF EXISTS missions_search_vector_trg();
+DROP FUNCTION IF EXISTS mission_search_document(BIGINT, TEXT, TEXT);
+
+ALTER TABLE missions DROP COLUMN IF EXISTS search_vector;
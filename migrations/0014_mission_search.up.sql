@@ -0,0 +1,83 @@
+ALTER TABLE missions
+  ADD COLUMN IF NOT EXISTS search_vector TSVECTOR NOT NULL DEFAULT ''::tsvector;
+
+-- title weighs most, then description and goal names, then goal notes
+CREATE OR REPLACE FUNCTION mission_search_document(p_id BIGINT, p_title TEXT, p_description TEXT)
+RETURNS TSVECTOR
+LANGUAGE sql STABLE AS $$
+  SELECT setweight(to_tsvector('english', coalesce(p_title, '')), 'A')
+      || setweight(to_tsvector('english', coalesce(p_description, '')), 'B')
+      || setweight(to_tsvector('english', coalesce(string_agg(g.name, ' '), '')), 'B')
+      || setweight(to_tsvector('english', coalesce(string_agg(g.notes, ' '), '')), 'C')
+  FROM mission_goals g
+  WHERE g.mission_id = p_id
+$$;
+
+CREATE OR REPLACE FUNCTION missions_search_vector_trg()
+RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+  NEW.search_vector := mission_search_document(NEW.id, NEW.title, NEW.description);
+  RETURN NEW;
+END
+$$;
+
+CREATE TRIGGER trg_missions_search_vector
+  BEFORE INSERT OR UPDATE OF title, description ON missions
+  FOR EACH ROW EXECUTE FUNCTION missions_search_vector_trg();
+
+-- goal changes refresh the vector of every mission they touched, once per
+-- statement; updates that leave name and notes alone (status, assignee,
+-- position) are skipped
+CREATE OR REPLACE FUNCTION mission_goals_search_vector_trg()
+RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    UPDATE missions m
+    SET search_vector = mission_search_document(m.id, m.title, m.description)
+    WHERE m.id IN (SELECT mission_id FROM new_goals);
+  ELSIF TG_OP = 'UPDATE' THEN
+    UPDATE missions m
+    SET search_vector = mission_search_document(m.id, m.title, m.description)
+    WHERE m.id IN (
+      SELECT n.mission_id
+      FROM new_goals n
+      JOIN old_goals o ON o.id = n.id
+      WHERE n.name IS DISTINCT FROM o.name
+         OR n.notes IS DISTINCT FROM o.notes
+         OR n.mission_id IS DISTINCT FROM o.mission_id
+      UNION
+      SELECT o.mission_id
+      FROM old_goals o
+      JOIN new_goals n ON n.id = o.id
+      WHERE n.mission_id IS DISTINCT FROM o.mission_id
+    );
+  ELSE
+    UPDATE missions m
+    SET search_vector = mission_search_document(m.id, m.title, m.description)
+    WHERE m.id IN (SELECT mission_id FROM old_goals);
+  END IF;
+  RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER trg_mission_goals_search_insert
+  AFTER INSERT ON mission_goals
+  REFERENCING NEW TABLE AS new_goals
+  FOR EACH STATEMENT EXECUTE FUNCTION mission_goals_search_vector_trg();
+
+CREATE TRIGGER trg_mission_goals_search_update
+  AFTER UPDATE ON mission_goals
+  REFERENCING OLD TABLE AS old_goals NEW TABLE AS new_goals
+  FOR EACH STATEMENT EXECUTE FUNCTION mission_goals_search_vector_trg();
+
+CREATE TRIGGER trg_mission_goals_search_delete
+  AFTER DELETE ON mission_goals
+  REFERENCING OLD TABLE AS old_goals
+  FOR EACH STATEMENT EXECUTE FUNCTION mission_goals_search_vector_trg();
+
+UPDATE missions m
+SET search_vector = mission_search_document(m.id, m.title, m.description);
+
+CREATE INDEX IF NOT EXISTS idx_missions_search_vector ON missions USING GIN (search_vector);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_mission_goals_done_by;
+DROP INDEX IF EXISTS idx_missions_completed_at;
@@ -0,0 +1,6 @@
+-- cat performance and leaderboard read finished work by time window
+CREATE INDEX IF NOT EXISTS idx_missions_completed_at
+  ON missions(completed_at) WHERE status = 'completed';
+
+CREATE INDEX IF NOT EXISTS idx_mission_goals_done_by
+  ON mission_goals(done_by_cat_id, finished_at) WHERE status = 'done';
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS salary_changes (
+  id             BIGSERIAL PRIMARY KEY,
+  cat_id         BIGINT        NOT NULL REFERENCES cats(id) ON DELETE RESTRICT,
+  old_salary     NUMERIC(12,2) NULL,
+  new_salary     NUMERIC(12,2) NOT NULL CHECK (new_salary >= 0),
+  effective_from DATE          NOT NULL,
+  reason         TEXT          NOT NULL DEFAULT '',
+  actor          TEXT          NOT NULL DEFAULT '',
+  created_at     TIMESTAMPTZ   NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_salary_changes_cat ON salary_changes(cat_id, effective_from, id);
+
+-- every existing cat starts its ledger with the salary it has today
+INSERT INTO salary_changes (cat_id, old_salary, new_salary, effective_from, reason, actor)
+SELECT id, NULL, salary, (created_at AT TIME ZONE 'UTC')::date, 'initial', 'migration'
+FROM cats;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE salary_changes
+  DROP COLUMN IF EXISTS currency,
+  DROP COLUMN IF EXISTS old_currency;
+
+ALTER TABLE cats DROP COLUMN IF EXISTS salary_currency;
@@ -0,0 +1,19 @@
+ALTER TABLE cats
+  ADD COLUMN IF NOT EXISTS salary_currency CHAR(3) NOT NULL DEFAULT 'USD'
+    CHECK (salary_currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE salary_changes
+  ADD COLUMN IF NOT EXISTS old_currency CHAR(3) NULL,
+  ADD COLUMN IF NOT EXISTS currency     CHAR(3) NOT NULL DEFAULT 'USD';
+
+UPDATE salary_changes SET old_currency = 'USD' WHERE old_salary IS NOT NULL;
+
+-- one rate per ordered pair: 1 from_currency = rate to_currency
+CREATE TABLE IF NOT EXISTS exchange_rates (
+  from_currency CHAR(3)       NOT NULL CHECK (from_currency ~ '^[A-Z]{3}$'),
+  to_currency   CHAR(3)       NOT NULL CHECK (to_currency ~ '^[A-Z]{3}$'),
+  rate          NUMERIC(18,8) NOT NULL CHECK (rate > 0),
+  updated_at    TIMESTAMPTZ   NOT NULL DEFAULT now(),
+  PRIMARY KEY (from_currency, to_currency),
+  CONSTRAINT chk_exchange_rate_pair CHECK (from_currency <> to_currency)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE salary_changes DROP COLUMN IF EXISTS overridden;
+
+DROP TABLE IF EXISTS salary_bands;
@@ -0,0 +1,19 @@
+-- a band applies to cats with min_years..max_years of experience (max_years
+-- NULL means no upper bound); an empty breed applies to every breed
+CREATE TABLE IF NOT EXISTS salary_bands (
+  id         BIGSERIAL PRIMARY KEY,
+  min_years  BIGINT        NOT NULL CHECK (min_years >= 0),
+  max_years  BIGINT        NULL,
+  breed      TEXT          NOT NULL DEFAULT '',
+  min_salary NUMERIC(12,2) NOT NULL CHECK (min_salary >= 0),
+  max_salary NUMERIC(12,2) NOT NULL,
+  currency   CHAR(3)       NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$'),
+  created_at TIMESTAMPTZ   NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ   NOT NULL DEFAULT now(),
+  CONSTRAINT chk_salary_band_years  CHECK (max_years IS NULL OR max_years >= min_years),
+  CONSTRAINT chk_salary_band_salary CHECK (max_salary >= min_salary)
+);
+CREATE INDEX IF NOT EXISTS idx_salary_bands_breed ON salary_bands (lower(breed), min_years);
+
+ALTER TABLE salary_changes
+  ADD COLUMN IF NOT EXISTS overridden BOOLEAN NOT NULL DEFAULT false;
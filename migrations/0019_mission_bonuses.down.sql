@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bonuses;
+DROP TABLE IF EXISTS bonus_rules;
+DROP TABLE IF EXISTS country_risk_levels;
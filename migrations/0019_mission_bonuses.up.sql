@@ -0,0 +1,39 @@
+-- risk level of a country, 1 (lowest) to 5 (highest)
+CREATE TABLE IF NOT EXISTS country_risk_levels (
+  country    CHAR(2)     PRIMARY KEY,
+  level      SMALLINT    NOT NULL CHECK (level BETWEEN 1 AND 5),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- flat: amount once per completed mission
+-- per_goal: amount per done goal
+-- country_risk: amount per done goal in a country of risk_level
+CREATE TABLE IF NOT EXISTS bonus_rules (
+  id         BIGSERIAL     PRIMARY KEY,
+  name       TEXT          NOT NULL,
+  kind       TEXT          NOT NULL CHECK (kind IN ('flat', 'per_goal', 'country_risk')),
+  amount     NUMERIC(12,2) NOT NULL CHECK (amount > 0),
+  currency   CHAR(3)       NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$'),
+  risk_level SMALLINT      NULL CHECK (risk_level BETWEEN 1 AND 5),
+  active     BOOLEAN       NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ   NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ   NOT NULL DEFAULT now(),
+  CONSTRAINT chk_bonus_rule_risk CHECK ((kind = 'country_risk') = (risk_level IS NOT NULL))
+);
+
+-- bonuses keep the name and amount of their rule, so editing or deleting a
+-- rule never changes what was already awarded
+CREATE TABLE IF NOT EXISTS bonuses (
+  id         BIGSERIAL     PRIMARY KEY,
+  cat_id     BIGINT        NOT NULL REFERENCES cats(id) ON DELETE RESTRICT,
+  mission_id BIGINT        NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+  rule_id    BIGINT        NULL REFERENCES bonus_rules(id) ON DELETE SET NULL,
+  rule_name  TEXT          NOT NULL,
+  kind       TEXT          NOT NULL,
+  quantity   INT           NOT NULL CHECK (quantity > 0),
+  amount     NUMERIC(12,2) NOT NULL CHECK (amount > 0),
+  currency   CHAR(3)       NOT NULL,
+  awarded_at TIMESTAMPTZ   NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_bonuses_cat ON bonuses(cat_id, awarded_at);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_bonuses_mission_rule ON bonuses(mission_id, rule_id) WHERE rule_id IS NOT NULL;